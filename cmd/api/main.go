@@ -18,12 +18,15 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth/impersonation"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc/credentials/insecure"
 	"net/http"
+	"os"
 	"path"
 	"strings"
 	"time"
@@ -44,9 +47,12 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/tektoncd/results/pkg/api/server/config"
+	resultsdb "github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/logger"
 	v1alpha2 "github.com/tektoncd/results/pkg/api/server/v1alpha2"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/retention"
 	v1alpha2pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	_ "go.uber.org/automaxprocs"
 	"google.golang.org/grpc"
@@ -54,7 +60,6 @@ import (
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 )
@@ -65,40 +70,77 @@ func main() {
 	log := logger.Get(serverConfig.LOG_LEVEL)
 	defer log.Sync()
 
-	// Load server TLS
-	certFile := path.Join(serverConfig.TLS_PATH, "tls.crt")
-	keyFile := path.Join(serverConfig.TLS_PATH, "tls.key")
-	creds, tlsError := credentials.NewServerTLSFromFile(certFile, keyFile)
-	if tlsError != nil {
-		log.Errorf("Error loading server TLS: %v", tlsError)
-		log.Warn("TLS will be disabled")
-		creds = insecure.NewCredentials()
-	}
-
-	if serverConfig.DB_USER == "" || serverConfig.DB_PASSWORD == "" {
+	if serverConfig.DB_TYPE != resultsdb.SQLite && (serverConfig.DB_USER == "" || serverConfig.DB_PASSWORD == "") {
 		log.Fatal("Must provide both DB_USER and DB_PASSWORD")
 	}
 	// Connect to the database.
-	// DSN derived from https://pkg.go.dev/gorm.io/driver/postgres
-
-	dbURI := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s", serverConfig.DB_HOST, serverConfig.DB_USER, serverConfig.DB_PASSWORD, serverConfig.DB_NAME, serverConfig.DB_PORT, serverConfig.DB_SSLMODE)
-
 	gormConfig := &gorm.Config{}
 	if log.Level() != zap.DebugLevel {
 		gormConfig.Logger = gormlogger.Default.LogMode(gormlogger.Silent)
 	}
-	db, err := gorm.Open(postgres.Open(dbURI), gormConfig)
+	db, err := resultsdb.Open(serverConfig, gormConfig)
 	if err != nil {
 		log.Fatalf("Failed to open the results.db: %v", err)
 	}
 
+	// Manage the migrations of the database schema instead of serving the
+	// API, e.g. "api migrate up".
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), db, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Load server TLS
+	certFile := path.Join(serverConfig.TLS_PATH, "tls.crt")
+	keyFile := path.Join(serverConfig.TLS_PATH, "tls.key")
+	creds, tlsError := credentials.NewServerTLSFromFile(certFile, keyFile)
+	if tlsError != nil {
+		log.Errorf("Error loading server TLS: %v", tlsError)
+		log.Warn("TLS will be disabled")
+		creds = insecure.NewCredentials()
+	}
+	// Request client certificates, verified with the CAs, if set.
+	var clientCAs *x509.CertPool
+	if serverConfig.TLS_CLIENT_CA_PATH != "" {
+		if tlsError != nil {
+			log.Warn("TLS is disabled, TLS_CLIENT_CA_PATH will be ignored")
+		} else {
+			pem, err := os.ReadFile(serverConfig.TLS_CLIENT_CA_PATH)
+			if err != nil {
+				log.Fatalf("Error loading client CAs: %v", err)
+			}
+			clientCAs = x509.NewCertPool()
+			if !clientCAs.AppendCertsFromPEM(pem) {
+				log.Fatalf("Error loading client CAs: no certificates found in %s", serverConfig.TLS_CLIENT_CA_PATH)
+			}
+		}
+	}
+
 	// Create the authorization authCheck
 	var authCheck auth.Checker
+	// authorizer authorizes the users of OIDC tokens.
+	var authorizer auth.Authorizer
 	var serverMuxOptions []runtime.ServeMuxOption
-	if serverConfig.AUTH_DISABLE {
+	switch {
+	case serverConfig.AUTH_DISABLE:
 		log.Warn("Kubernetes RBAC authorization check disabled - all requests will be allowed by the API server")
 		authCheck = &auth.AllowAll{}
-	} else {
+	case serverConfig.AUTH_POLICY_PATH != "":
+		log.Infof("Static authorization policy %s enabled", serverConfig.AUTH_POLICY_PATH)
+		policy, err := auth.NewPolicyChecker(serverConfig.AUTH_POLICY_PATH)
+		if err != nil {
+			log.Fatalf("Error loading authorization policy: %v", err)
+		}
+		go func() {
+			if err := policy.Watch(context.Background()); err != nil {
+				log.Errorf("Error watching authorization policy, changes will not be reloaded: %v", err)
+			}
+		}()
+		authCheck = policy
+		authorizer = policy
+	default:
 		log.Info("Kubernetes RBAC authorization check enabled")
 		// Create k8s client
 		k8sConfig, err := rest.InClusterConfig()
@@ -114,7 +156,26 @@ func main() {
 			log.Info("Kubernetes RBAC impersonation enabled")
 			serverMuxOptions = append(serverMuxOptions, runtime.WithIncomingHeaderMatcher(impersonation.HeaderMatcher))
 		}
-		authCheck = auth.NewRBAC(k8s, auth.WithImpersonation(serverConfig.AUTH_IMPERSONATE))
+		authCheck = auth.NewRBAC(k8s,
+			auth.WithImpersonation(serverConfig.AUTH_IMPERSONATE),
+			auth.WithCache(serverConfig.AUTH_CACHE_SIZE, serverConfig.AUTH_CACHE_TTL, serverConfig.AUTH_CACHE_NEGATIVE_TTL),
+		)
+		authorizer = auth.NewCachedAuthorizer(auth.NewSubjectAccessReview(k8s.AuthorizationV1()),
+			serverConfig.AUTH_CACHE_SIZE, serverConfig.AUTH_CACHE_TTL, serverConfig.AUTH_CACHE_NEGATIVE_TTL)
+	}
+	if authorizer != nil && serverConfig.AUTH_OIDC_ISSUER != "" {
+		log.Infof("OIDC authentication of the tokens issued by %s enabled", serverConfig.AUTH_OIDC_ISSUER)
+		oidc, err := auth.NewOIDC(serverConfig.AUTH_OIDC_ISSUER, serverConfig.AUTH_OIDC_JWKS, authorizer,
+			auth.WithAudience(serverConfig.AUTH_OIDC_AUDIENCE),
+			auth.WithUsernameClaim(serverConfig.AUTH_OIDC_USERNAME_CLAIM, serverConfig.AUTH_OIDC_USERNAME_PREFIX),
+			auth.WithGroupsClaim(serverConfig.AUTH_OIDC_GROUPS_CLAIM, serverConfig.AUTH_OIDC_GROUPS_PREFIX),
+		)
+		if err != nil {
+			log.Fatalf("Error creating OIDC authorization check: %v", err)
+		}
+		// OIDC tokens are verified first, since it does not take
+		// requests to the API server.
+		authCheck = auth.Any{oidc, authCheck}
 	}
 
 	// Register API server(s)
@@ -123,6 +184,20 @@ func main() {
 		log.Fatalf("Failed to create server: %v", err)
 	}
 
+	retentionPolicy, err := retention.FromConfig(serverConfig)
+	if err != nil {
+		log.Fatalf("Failed to load retention policy: %v", err)
+	}
+	if retentionPolicy != nil {
+		log.Infof("Retention policy enabled with %d rules", len(retentionPolicy.Rules))
+	}
+	if retentionPolicy != nil || serverConfig.SOFT_DELETE_EXPIRY > 0 {
+		go v1a2.RunRetention(context.Background(), retentionPolicy)
+	}
+	// Retry the deletions of stored logs that failed, and delete orphaned
+	// logs if enabled.
+	go v1a2.RunLogDeletions(context.Background())
+
 	// Shared options for the logger, with a custom gRPC code to log level function.
 	zapOpts := []grpc_zap.Option{
 		grpc_zap.WithDurationField(func(duration time.Duration) zapcore.Field {
@@ -133,22 +208,41 @@ func main() {
 	// Customize logger, so it can be passed to the gRPC interceptors
 	grpcLogger := log.Desugar().With(zap.Bool("grpc.auth_disabled", serverConfig.AUTH_DISABLE))
 
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		// The grpc_ctxtags context updater should be before everything else
+		grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+		grpc_zap.UnaryServerInterceptor(grpcLogger, zapOpts...),
+		grpc_auth.UnaryServerInterceptor(determineAuth),
+		prometheus.UnaryServerInterceptor,
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		// The grpc_ctxtags context updater should be before everything else
+		grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+		grpc_zap.StreamServerInterceptor(grpcLogger, zapOpts...),
+		grpc_auth.StreamServerInterceptor(determineAuth),
+		prometheus.StreamServerInterceptor,
+	}
+	if serverConfig.AUDIT_SINK != "" {
+		var auditPolicy *audit.Policy
+		if serverConfig.AUDIT_POLICY_PATH != "" {
+			if auditPolicy, err = audit.LoadPolicy(serverConfig.AUDIT_POLICY_PATH); err != nil {
+				log.Fatalf("Failed to load audit policy: %v", err)
+			}
+		}
+		sink, err := audit.NewSink(serverConfig.AUDIT_SINK, log)
+		if err != nil {
+			log.Fatalf("Failed to create audit sink: %v", err)
+		}
+		log.Infof("Audit log enabled, writing events to %s", serverConfig.AUDIT_SINK)
+		auditor := audit.New(sink, auditPolicy, log)
+		unaryInterceptors = append(unaryInterceptors, auditor.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, auditor.StreamServerInterceptor())
+	}
+
 	gs := grpc.NewServer(
 		grpc.Creds(creds),
-		grpc_middleware.WithUnaryServerChain(
-			// The grpc_ctxtags context updater should be before everything else
-			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-			grpc_zap.UnaryServerInterceptor(grpcLogger, zapOpts...),
-			grpc_auth.UnaryServerInterceptor(determineAuth),
-			prometheus.UnaryServerInterceptor,
-		),
-		grpc_middleware.WithStreamServerChain(
-			// The grpc_ctxtags context updater should be before everything else
-			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-			grpc_zap.StreamServerInterceptor(grpcLogger, zapOpts...),
-			grpc_auth.StreamServerInterceptor(determineAuth),
-			prometheus.StreamServerInterceptor,
-		),
+		grpc_middleware.WithUnaryServerChain(unaryInterceptors...),
+		grpc_middleware.WithStreamServerChain(streamInterceptors...),
 	)
 	v1alpha2pb.RegisterResultsServer(gs, v1a2)
 	if serverConfig.LOGS_API {
@@ -204,7 +298,17 @@ func main() {
 	if tlsError != nil {
 		log.Fatal(http.ListenAndServe(":"+serverConfig.SERVER_PORT, grpcHandlerFunc(gs, httpMux)))
 	} else {
-		log.Fatal(http.ListenAndServeTLS(":"+serverConfig.SERVER_PORT, certFile, keyFile, grpcHandlerFunc(gs, httpMux)))
+		srv := &http.Server{
+			Addr:    ":" + serverConfig.SERVER_PORT,
+			Handler: grpcHandlerFunc(gs, httpMux),
+		}
+		if clientCAs != nil {
+			srv.TLSConfig = &tls.Config{
+				ClientAuth: tls.VerifyClientCertIfGiven,
+				ClientCAs:  clientCAs,
+			}
+		}
+		log.Fatal(srv.ListenAndServeTLS(certFile, keyFile))
 	}
 }
 
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tektoncd/results/pkg/api/server/db/migrate"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = `usage: api migrate <command>
+
+Commands:
+  status   show the applied and pending migrations (default)
+  up       apply the pending migrations
+  dry-run  show the SQL of the pending migrations without applying them
+  down     revert the latest applied migration`
+
+// runMigrate runs the migrate command of the API server, which manages the
+// migrations of the database schema instead of serving the API.
+func runMigrate(ctx context.Context, db *gorm.DB, args []string, w io.Writer) error {
+	command := "status"
+	if len(args) > 0 {
+		command = args[0]
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("unexpected arguments %q\n%s", args[1:], migrateUsage)
+	}
+
+	m, err := migrate.New(db)
+	if err != nil {
+		return err
+	}
+	switch command {
+	case "status":
+		s, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(w, s)
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, mg := range applied {
+			fmt.Fprintf(w, "applied %s\n", mg)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(w, "no pending migrations")
+		}
+		return nil
+	case "dry-run":
+		s, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if err := s.Check(); err != nil {
+			return err
+		}
+		if len(s.Pending) == 0 {
+			fmt.Fprintln(w, "no pending migrations")
+		}
+		for _, mg := range s.Pending {
+			fmt.Fprintf(w, "-- %s\n%s\n", mg, strings.TrimSpace(mg.Up))
+		}
+		return nil
+	case "down":
+		reverted, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			fmt.Fprintln(w, "no applied migrations")
+			return nil
+		}
+		fmt.Fprintf(w, "reverted %s\n", reverted)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, migrateUsage)
+	}
+}
+
+// printStatus writes the version of the database schema, followed by the
+// applied and pending migrations.
+func printStatus(w io.Writer, s *migrate.Status) error {
+	fmt.Fprintf(w, "version: %d\nlatest:  %d\n\n", s.Version, s.Latest)
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS")
+	// Applied migrations are listed by version, including the unknown ones,
+	// followed by the pending ones.
+	unknown := map[int64]bool{}
+	for _, a := range s.Unknown {
+		unknown[a.Version] = true
+	}
+	for _, a := range s.Applied {
+		state := "applied " + a.AppliedTime.UTC().Format("2006-01-02T15:04:05Z")
+		if unknown[a.Version] {
+			state += " (unknown)"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", a.Version, a.Name, state)
+	}
+	for _, mg := range s.Pending {
+		fmt.Fprintf(tw, "%d\t%s\tpending\n", mg.Version, mg.Name)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return s.Check()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/results/pkg/api/server/db/migrate"
+	"github.com/tektoncd/results/pkg/api/server/test"
+)
+
+func Test_runMigrate(t *testing.T) {
+	db := test.NewDB(t)
+	ctx := context.Background()
+	m, err := migrate.New(db)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	s, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	latest := s.Pending[len(s.Pending)-1]
+
+	for _, tc := range []struct {
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			args: nil,
+			want: []string{"version: 0", "1        create_results_and_records  pending"},
+		},
+		{
+			args: []string{"dry-run"},
+			want: []string{"-- 0001_create_results_and_records\nCREATE TABLE results", "-- " + latest.String()},
+		},
+		{
+			args: []string{"up"},
+			want: []string{"applied 0001_create_results_and_records", "applied " + latest.String()},
+		},
+		{
+			args: []string{"up"},
+			want: []string{"no pending migrations"},
+		},
+		{
+			args: []string{"status"},
+			want: []string{fmt.Sprintf("version: %d", latest.Version), "3        create_record_revisions     applied"},
+		},
+		{
+			args: []string{"down"},
+			want: []string{"reverted " + latest.String()},
+		},
+		{
+			args: []string{"dry-run"},
+			want: []string{"-- " + latest.String() + "\n" + strings.SplitN(latest.Up, "\n", 2)[0]},
+		},
+		{
+			args:    []string{"sideways"},
+			wantErr: true,
+		},
+		{
+			args:    []string{"up", "now"},
+			wantErr: true,
+		},
+	} {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			var b bytes.Buffer
+			err := runMigrate(ctx, db, tc.args, &b)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("runMigrate: want error %t, got %v", tc.wantErr, err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, b.String())
+				}
+			}
+		})
+	}
+}
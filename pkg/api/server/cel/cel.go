@@ -45,16 +45,48 @@ func ParseFilter(env *cel.Env, filter string) (cel.Program, error) {
 		return allowAll{}, nil
 	}
 
+	ast, err := CompileFilter(env, filter)
+	if err != nil {
+		return nil, err
+	}
+	return NewProgram(env, ast)
+}
+
+// CompileFilter parses and type-checks the given filter string.
+func CompileFilter(env *cel.Env, filter string) (*cel.Ast, error) {
 	ast, issues := env.Compile(filter)
 	if issues != nil && issues.Err() != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "error parsing filter: %v", issues.Err())
 	}
+	return ast, nil
+}
 
+// NewProgram creates a CEL program evaluating the given compiled filter.
+func NewProgram(env *cel.Env, ast *cel.Ast) (cel.Program, error) {
 	prg, err := env.Program(ast)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "error creating filter query evaluator: %v", err)
 	}
-	return prg, nil
+	cond, err := presence(ast)
+	if err != nil || cond == "" {
+		return prg, nil
+	}
+	present, err := compileProgram(env, cond)
+	if err != nil {
+		// Missing keys are then reported as errors, as other errors.
+		log.Printf("failed to check the presence of the keys of the filter: %v", err)
+		return prg, nil
+	}
+	return &program{Program: prg, present: present}, nil
+}
+
+// compileProgram compiles a filter into a program with no presence check.
+func compileProgram(env *cel.Env, filter string) (cel.Program, error) {
+	ast, err := CompileFilter(env, filter)
+	if err != nil {
+		return nil, err
+	}
+	return env.Program(ast)
 }
 
 // allowAll is a CEL program implementation that always returns true.
@@ -69,6 +101,12 @@ func (allowAll) Eval(interface{}) (ref.Val, *cel.EvalDetails, error) {
 }
 
 // Match determines whether the given CEL filter matches the result.
+//
+// Filters that fail to evaluate because they read a missing key do not
+// match. This is the semantics of the filters translated to SQL, where
+// missing values are NULL, so that a filter selects the same rows whether it
+// is evaluated by the database or in memory. Other evaluation errors, e.g.
+// comparing values of different types, are reported.
 func Match(prg cel.Program, data map[string]interface{}) (bool, error) {
 	if prg == nil {
 		return true, nil
@@ -79,6 +117,9 @@ func Match(prg cel.Program, data map[string]interface{}) (bool, error) {
 
 	out, details, err := prg.Eval(data)
 	if err != nil {
+		if p, ok := prg.(*program); ok && p.missing(data) {
+			return false, nil
+		}
 		log.Printf("failed to evaluate the expression: %v", err)
 		return false, status.Errorf(codes.InvalidArgument, "failed to evaluate filter: %v. Details: %+v", err, details)
 	}
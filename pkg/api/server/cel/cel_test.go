@@ -17,6 +17,9 @@ package cel
 import (
 	"testing"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -58,3 +61,59 @@ func TestParseFilter(t *testing.T) {
 		}
 	})
 }
+
+func TestMatch(t *testing.T) {
+	env, err := NewEnv()
+	if err != nil {
+		t.Fatalf("NewEnv: %v", err)
+	}
+	env, err = env.Extend(cel.Declarations(decls.NewVar("data", decls.Dyn)))
+	if err != nil {
+		t.Fatalf("Extend: %v", err)
+	}
+	data := map[string]interface{}{
+		"kind": "x",
+		"metadata": map[string]interface{}{
+			"name":   "a",
+			"labels": map[string]interface{}{"app": "a"},
+		},
+		"items": []interface{}{"a"},
+	}
+	result := &pb.Result{Annotations: map[string]string{"foo": "bar"}}
+
+	for _, tc := range []struct {
+		filter string
+		match  bool
+		status codes.Code
+	}{
+		{filter: `data.metadata.labels["app"] == "a"`, match: true},
+		{filter: `result.annotations["foo"] == "bar"`, match: true},
+		// Missing keys don't match, as NULL values in SQL.
+		{filter: `data.metadata.labels["team"] == "a"`},
+		{filter: `!(data.metadata.labels["team"] == "a")`},
+		{filter: `data.spec.name == "a"`},
+		{filter: `data.items[1] == "a"`},
+		{filter: `result.annotations["baz"] == "bar"`},
+		{filter: `data.metadata.labels["team"] == "a" || data.kind == "x"`, match: true},
+		{filter: `data.items.exists(i, i == data.metadata.labels["team"])`},
+		// Other errors are reported.
+		{filter: `data.metadata.name > 1`, status: codes.InvalidArgument},
+		{filter: `data.items[0] + 1 == 2`, status: codes.InvalidArgument},
+		{filter: `data.kind.startsWith("x") || data.items[0] > 1`, match: true},
+		{filter: `data.kind.startsWith("y") || data.items[0] > 1`, status: codes.InvalidArgument},
+	} {
+		t.Run(tc.filter, func(t *testing.T) {
+			prg, err := ParseFilter(env, tc.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter: %v", err)
+			}
+			got, err := Match(prg, map[string]interface{}{"data": data, "result": result})
+			if status.Code(err) != tc.status {
+				t.Fatalf("want %v, got %v", tc.status, err)
+			}
+			if got != tc.match {
+				t.Errorf("want %t, got %t", tc.match, got)
+			}
+		})
+	}
+}
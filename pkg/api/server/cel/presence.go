@@ -0,0 +1,194 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/types"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// program is a filter along with a program checking that the keys it reads
+// are present, which tells evaluation errors on missing keys apart from
+// other errors.
+type program struct {
+	cel.Program
+	present cel.Program
+}
+
+// missing reports whether a key read by the filter is missing from data.
+func (p *program) missing(data map[string]interface{}) bool {
+	if p.present == nil {
+		return false
+	}
+	out, _, err := p.present.Eval(data)
+	return err == nil && out == types.False
+}
+
+// presence returns a condition that holds if the map keys and list indexes
+// that the checked filter reads with constants from its variables are
+// present, e.g. `has(data.metadata) && "app" in data.metadata.labels` for
+// `data.metadata.labels["app"]`. Fields of messages are always present. It
+// returns an empty string if the filter reads no such key.
+func presence(ast *cel.Ast) (string, error) {
+	checked, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return "", err
+	}
+	p := &presenceBuilder{
+		types: checked.GetTypeMap(),
+		refs:  checked.GetReferenceMap(),
+		seen:  make(map[string]bool),
+	}
+	p.walk(checked.GetExpr(), nil)
+	return strings.Join(p.conds, " && "), nil
+}
+
+type presenceBuilder struct {
+	types map[int64]*exprpb.Type
+	refs  map[int64]*exprpb.Reference
+	seen  map[string]bool
+	conds []string
+}
+
+func (p *presenceBuilder) add(cond string) {
+	if !p.seen[cond] {
+		p.seen[cond] = true
+		p.conds = append(p.conds, cond)
+	}
+}
+
+// walk adds the conditions for the keys read by e and its subexpressions.
+// bound holds the variables of the enclosing comprehensions, which are not
+// defined outside of them.
+func (p *presenceBuilder) walk(e *exprpb.Expr, bound map[string]bool) {
+	switch k := e.GetExprKind().(type) {
+	case *exprpb.Expr_SelectExpr:
+		sel := k.SelectExpr
+		if !sel.GetTestOnly() && p.dynamic(sel.GetOperand()) {
+			if src, ok := p.source(e, bound); ok {
+				p.add(fmt.Sprintf("has(%s)", src))
+			}
+		}
+		p.walk(sel.GetOperand(), bound)
+	case *exprpb.Expr_CallExpr:
+		call := k.CallExpr
+		if call.GetFunction() == operators.Index && len(call.GetArgs()) == 2 {
+			p.index(call.GetArgs()[0], call.GetArgs()[1], bound)
+		}
+		if call.GetTarget() != nil {
+			p.walk(call.GetTarget(), bound)
+		}
+		for _, arg := range call.GetArgs() {
+			p.walk(arg, bound)
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, elem := range k.ListExpr.GetElements() {
+			p.walk(elem, bound)
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range k.StructExpr.GetEntries() {
+			if key := entry.GetMapKey(); key != nil {
+				p.walk(key, bound)
+			}
+			p.walk(entry.GetValue(), bound)
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		comp := k.ComprehensionExpr
+		p.walk(comp.GetIterRange(), bound)
+		p.walk(comp.GetAccuInit(), bound)
+		inner := map[string]bool{comp.GetIterVar(): true, comp.GetAccuVar(): true}
+		for name := range bound {
+			inner[name] = true
+		}
+		p.walk(comp.GetLoopCondition(), inner)
+		p.walk(comp.GetLoopStep(), inner)
+		p.walk(comp.GetResult(), inner)
+	}
+}
+
+// index adds the condition for reading the constant key from container.
+func (p *presenceBuilder) index(container, key *exprpb.Expr, bound map[string]bool) {
+	src, ok := p.source(container, bound)
+	if !ok {
+		return
+	}
+	t := p.types[container.GetId()]
+	switch v := key.GetConstExpr().GetConstantKind().(type) {
+	case *exprpb.Constant_StringValue:
+		if t.GetMapType() != nil || t.GetDyn() != nil {
+			p.add(fmt.Sprintf("%s in %s", strconv.Quote(v.StringValue), src))
+		}
+	case *exprpb.Constant_Int64Value:
+		switch {
+		case t.GetListType() != nil:
+			p.add(fmt.Sprintf("size(%s) > %d", src, v.Int64Value))
+		case t.GetMapType() != nil:
+			p.add(fmt.Sprintf("%d in %s", v.Int64Value, src))
+		case t.GetDyn() != nil:
+			p.add(fmt.Sprintf("(type(%[1]s) == list ? size(%[1]s) > %[2]d : %[2]d in %[1]s)", src, v.Int64Value))
+		}
+	}
+}
+
+// dynamic reports whether e is a map or a dynamic value, whose keys may be
+// missing.
+func (p *presenceBuilder) dynamic(e *exprpb.Expr) bool {
+	t := p.types[e.GetId()]
+	return t.GetMapType() != nil || t.GetDyn() != nil
+}
+
+// source returns the CEL source of a chain of field selections and constant
+// indexes rooted at a variable of the environment.
+func (p *presenceBuilder) source(e *exprpb.Expr, bound map[string]bool) (string, bool) {
+	switch k := e.GetExprKind().(type) {
+	case *exprpb.Expr_IdentExpr:
+		name := k.IdentExpr.GetName()
+		if _, ok := p.refs[e.GetId()]; !ok || bound[name] {
+			return "", false
+		}
+		return name, true
+	case *exprpb.Expr_SelectExpr:
+		if k.SelectExpr.GetTestOnly() {
+			return "", false
+		}
+		src, ok := p.source(k.SelectExpr.GetOperand(), bound)
+		if !ok {
+			return "", false
+		}
+		return src + "." + k.SelectExpr.GetField(), true
+	case *exprpb.Expr_CallExpr:
+		call := k.CallExpr
+		if call.GetFunction() != operators.Index || len(call.GetArgs()) != 2 {
+			return "", false
+		}
+		src, ok := p.source(call.GetArgs()[0], bound)
+		if !ok {
+			return "", false
+		}
+		switch key := call.GetArgs()[1].GetConstExpr().GetConstantKind().(type) {
+		case *exprpb.Constant_StringValue:
+			return fmt.Sprintf("%s[%s]", src, strconv.Quote(key.StringValue)), true
+		case *exprpb.Constant_Int64Value:
+			return fmt.Sprintf("%s[%d]", src, key.Int64Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,449 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cel2sql translates CEL filter expressions into SQL conditions so
+// that List queries can be filtered by the database instead of in memory.
+//
+// Only a subset of CEL is supported: field access on the variables exposed by
+// a View, comparisons against literals, &&, ||, !, `in` and the
+// startsWith/endsWith/contains string functions. Expressions outside of this
+// subset are reported as unsupported, and callers are expected to fall back
+// to evaluating the filter in memory.
+package cel2sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// errUnsupported is returned when an expression cannot be represented in SQL.
+var errUnsupported = errors.New("unsupported expression")
+
+// Clause is a SQL boolean condition along with its bind arguments, suitable
+// for passing to gorm's Where.
+type Clause struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Translate converts the checked CEL expression into a SQL condition over the
+// columns described by view, using the SQL dialect named by dialect (as
+// reported by gorm.Dialector.Name).
+//
+// If the whole expression can be translated, complete is true and the
+// returned Clause is equivalent to the filter. If only some of the top-level
+// && conjuncts can be translated, the Clause contains those conjuncts and
+// complete is false: the Clause narrows down the candidate rows, but the
+// filter still has to be evaluated in memory. If nothing can be translated,
+// the returned Clause is nil.
+func Translate(ast *cel.Ast, view *View, dialect string) (clause *Clause, complete bool, err error) {
+	d, ok := dialects[dialect]
+	if !ok || ast == nil {
+		return nil, false, nil
+	}
+	checked, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c := &converter{
+		view:    view,
+		dialect: d,
+		refs:    checked.GetReferenceMap(),
+	}
+
+	complete = true
+	var conds []string
+	for _, e := range conjuncts(checked.GetExpr()) {
+		mark := len(c.args)
+		sql, err := c.condition(e)
+		if errors.Is(err, errUnsupported) {
+			// Drop any arguments the partial translation may have added.
+			c.args = c.args[:mark]
+			complete = false
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		conds = append(conds, sql)
+	}
+	if len(conds) == 0 {
+		return nil, false, nil
+	}
+	if len(conds) == 1 {
+		return &Clause{SQL: conds[0], Args: c.args}, complete, nil
+	}
+	return &Clause{SQL: "(" + strings.Join(conds, " AND ") + ")", Args: c.args}, complete, nil
+}
+
+// conjuncts splits an expression into its top-level && operands.
+func conjuncts(e *exprpb.Expr) []*exprpb.Expr {
+	call := e.GetCallExpr()
+	if call == nil || call.GetFunction() != operators.LogicalAnd || len(call.GetArgs()) != 2 {
+		return []*exprpb.Expr{e}
+	}
+	return append(conjuncts(call.GetArgs()[0]), conjuncts(call.GetArgs()[1])...)
+}
+
+type converter struct {
+	view    *View
+	dialect dialect
+	refs    map[int64]*exprpb.Reference
+	args    []interface{}
+}
+
+// operand is either a column reference or a literal value.
+type operand struct {
+	// sql is the SQL expression of a column operand.
+	sql  string
+	kind Kind
+	// guard, if set, is a condition that must hold for sql to have the same
+	// type as in CEL. It is used for values read out of JSON documents, which
+	// are only comparable with strings when they are JSON strings.
+	guard string
+
+	literal bool
+	value   interface{}
+}
+
+// condition translates a boolean valued expression.
+func (c *converter) condition(e *exprpb.Expr) (string, error) {
+	call := e.GetCallExpr()
+	if call == nil {
+		return "", errUnsupported
+	}
+	args := call.GetArgs()
+	switch fn := call.GetFunction(); fn {
+	case operators.LogicalAnd, operators.LogicalOr:
+		if len(args) != 2 {
+			return "", errUnsupported
+		}
+		lhs, err := c.condition(args[0])
+		if err != nil {
+			return "", err
+		}
+		rhs, err := c.condition(args[1])
+		if err != nil {
+			return "", err
+		}
+		op := "AND"
+		if fn == operators.LogicalOr {
+			op = "OR"
+		}
+		return fmt.Sprintf("(%s %s %s)", lhs, op, rhs), nil
+	case operators.LogicalNot:
+		if len(args) != 1 {
+			return "", errUnsupported
+		}
+		inner, err := c.condition(args[0])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("NOT (%s)", inner), nil
+	case operators.Equals, operators.NotEquals, operators.Less, operators.LessEquals, operators.Greater, operators.GreaterEquals:
+		if len(args) != 2 {
+			return "", errUnsupported
+		}
+		return c.comparison(fn, args[0], args[1])
+	case operators.In, operators.OldIn:
+		if len(args) != 2 {
+			return "", errUnsupported
+		}
+		return c.in(args[0], args[1])
+	case overloads.StartsWith, overloads.EndsWith, overloads.Contains:
+		if call.GetTarget() == nil || len(args) != 1 {
+			return "", errUnsupported
+		}
+		return c.match(fn, call.GetTarget(), args[0])
+	}
+	return "", errUnsupported
+}
+
+var sqlOperators = map[string]string{
+	operators.Equals:        "=",
+	operators.NotEquals:     "<>",
+	operators.Less:          "<",
+	operators.LessEquals:    "<=",
+	operators.Greater:       ">",
+	operators.GreaterEquals: ">=",
+}
+
+// flipped maps an operator to its equivalent when the operands are swapped.
+var flipped = map[string]string{
+	operators.Equals:        operators.Equals,
+	operators.NotEquals:     operators.NotEquals,
+	operators.Less:          operators.Greater,
+	operators.LessEquals:    operators.GreaterEquals,
+	operators.Greater:       operators.Less,
+	operators.GreaterEquals: operators.LessEquals,
+}
+
+func (c *converter) comparison(fn string, lhs, rhs *exprpb.Expr) (string, error) {
+	l, err := c.operand(lhs)
+	if err != nil {
+		return "", err
+	}
+	r, err := c.operand(rhs)
+	if err != nil {
+		return "", err
+	}
+	if l.literal && !r.literal {
+		l, r = r, l
+		fn = flipped[fn]
+	}
+	if l.literal || !r.literal {
+		return "", errUnsupported
+	}
+	if !compatible(l.kind, r.value) {
+		return "", errUnsupported
+	}
+	if fn == operators.NotEquals && l.guard != "" {
+		// Values of other types are never equal to the literal in CEL, so
+		// they must match the negation.
+		return fmt.Sprintf("NOT %s", l.guarded(fmt.Sprintf("%s = %s", l.sql, c.bind(r.value)))), nil
+	}
+	op := sqlOperators[fn]
+	if l.kind == KindString && fn != operators.Equals && fn != operators.NotEquals {
+		// CEL orders strings by code point, which the database collation may
+		// not.
+		return l.guarded(fmt.Sprintf("%s %s %s", l.sql, op, c.dialect.binaryCollate(c.bind(r.value)))), nil
+	}
+	return l.guarded(fmt.Sprintf("%s %s %s", l.sql, op, c.bind(r.value))), nil
+}
+
+// guarded returns cond restricted to the rows where the operand has the
+// expected type.
+func (o *operand) guarded(cond string) string {
+	if o.guard == "" {
+		return cond
+	}
+	return fmt.Sprintf("(%s AND %s)", o.guard, cond)
+}
+
+// compatible reports whether a literal can be compared with a column of the
+// given kind with the same semantics as in CEL.
+func compatible(k Kind, v interface{}) bool {
+	switch v.(type) {
+	case string:
+		return k == KindString
+	case int64, uint64:
+		return k == KindInt
+	case time.Time:
+		return k == KindTime
+	}
+	return false
+}
+
+func (c *converter) in(elem, container *exprpb.Expr) (string, error) {
+	// `"key" in result.annotations`: map key membership.
+	if p, ok := c.path(container); ok {
+		key, err := c.operand(elem)
+		if err != nil {
+			return "", err
+		}
+		s, ok := key.value.(string)
+		if !key.literal || !ok {
+			return "", errUnsupported
+		}
+		field, rest, ok := c.view.lookup(p)
+		if !ok || field.Kind != KindMap || len(rest) != 0 {
+			return "", errUnsupported
+		}
+		col, err := c.dialect.jsonText(field.Column, []interface{}{s})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s IS NOT NULL", col), nil
+	}
+
+	// `data_type in ["a", "b"]`: list membership.
+	list := container.GetListExpr()
+	if list == nil || len(list.GetElements()) == 0 {
+		return "", errUnsupported
+	}
+	col, err := c.operand(elem)
+	if err != nil {
+		return "", err
+	}
+	if col.literal {
+		return "", errUnsupported
+	}
+	placeholders := make([]string, 0, len(list.GetElements()))
+	for _, e := range list.GetElements() {
+		v, err := c.operand(e)
+		if err != nil {
+			return "", err
+		}
+		if !v.literal || !compatible(col.kind, v.value) {
+			return "", errUnsupported
+		}
+		placeholders = append(placeholders, c.bind(v.value))
+	}
+	return col.guarded(fmt.Sprintf("%s IN (%s)", col.sql, strings.Join(placeholders, ", "))), nil
+}
+
+func (c *converter) match(fn string, target, arg *exprpb.Expr) (string, error) {
+	col, err := c.operand(target)
+	if err != nil {
+		return "", err
+	}
+	v, err := c.operand(arg)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.value.(string)
+	if col.literal || !v.literal || !ok || col.kind != KindString {
+		return "", errUnsupported
+	}
+	var prefix, suffix bool
+	switch fn {
+	case overloads.StartsWith:
+		prefix = true
+	case overloads.EndsWith:
+		suffix = true
+	}
+	pattern, op := c.dialect.pattern(s, prefix, suffix)
+	return col.guarded(fmt.Sprintf("%s %s %s", col.sql, op, c.bind(pattern))), nil
+}
+
+// operand translates an expression into either a literal or a column.
+func (c *converter) operand(e *exprpb.Expr) (*operand, error) {
+	// Enum values and other constants resolved by the type checker.
+	if ref, ok := c.refs[e.GetId()]; ok && ref.GetValue() != nil {
+		return literal(ref.GetValue())
+	}
+
+	switch k := e.GetExprKind().(type) {
+	case *exprpb.Expr_ConstExpr:
+		return literal(k.ConstExpr)
+	case *exprpb.Expr_CallExpr:
+		call := k.CallExpr
+		if call.GetFunction() == overloads.TypeConvertTimestamp && len(call.GetArgs()) == 1 && call.GetTarget() == nil {
+			s, ok := call.GetArgs()[0].GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue)
+			if !ok {
+				return nil, errUnsupported
+			}
+			t, err := time.Parse(time.RFC3339Nano, s.StringValue)
+			if err != nil {
+				return nil, errUnsupported
+			}
+			return &operand{literal: true, value: t}, nil
+		}
+	}
+
+	p, ok := c.path(e)
+	if !ok {
+		return nil, errUnsupported
+	}
+	field, rest, ok := c.view.lookup(p)
+	if !ok {
+		return nil, errUnsupported
+	}
+	switch field.Kind {
+	case KindMap:
+		if len(rest) != 1 {
+			return nil, errUnsupported
+		}
+		if _, ok := rest[0].(string); !ok {
+			return nil, errUnsupported
+		}
+	case KindJSON:
+		if len(rest) == 0 {
+			return nil, errUnsupported
+		}
+	default:
+		if len(rest) != 0 || !c.dialect.supports(field.Kind) {
+			return nil, errUnsupported
+		}
+		sql := c.dialect.column(field)
+		if field.Nullable {
+			sql = fmt.Sprintf("COALESCE(%s, %s)", sql, c.dialect.epoch())
+		}
+		return &operand{sql: sql, kind: field.Kind}, nil
+	}
+	sql, err := c.dialect.jsonText(field.Column, rest)
+	if err != nil {
+		return nil, err
+	}
+	// Map values are always strings, but JSON documents may hold values of
+	// any type at the path.
+	o := &operand{sql: sql, kind: KindString}
+	if field.Kind == KindJSON {
+		o.guard, err = c.dialect.jsonIsString(field.Column, rest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+func literal(k *exprpb.Constant) (*operand, error) {
+	switch v := k.GetConstantKind().(type) {
+	case *exprpb.Constant_StringValue:
+		return &operand{literal: true, value: v.StringValue}, nil
+	case *exprpb.Constant_Int64Value:
+		return &operand{literal: true, value: v.Int64Value}, nil
+	case *exprpb.Constant_Uint64Value:
+		return &operand{literal: true, value: v.Uint64Value}, nil
+	}
+	return nil, errUnsupported
+}
+
+// path flattens a chain of field selections and constant indexes rooted at an
+// identifier, e.g. data.metadata["name"] -> [data metadata name].
+func (c *converter) path(e *exprpb.Expr) ([]interface{}, bool) {
+	switch k := e.GetExprKind().(type) {
+	case *exprpb.Expr_IdentExpr:
+		return []interface{}{k.IdentExpr.GetName()}, true
+	case *exprpb.Expr_SelectExpr:
+		if k.SelectExpr.GetTestOnly() {
+			return nil, false
+		}
+		p, ok := c.path(k.SelectExpr.GetOperand())
+		if !ok {
+			return nil, false
+		}
+		return append(p, k.SelectExpr.GetField()), true
+	case *exprpb.Expr_CallExpr:
+		call := k.CallExpr
+		if call.GetFunction() != operators.Index || len(call.GetArgs()) != 2 {
+			return nil, false
+		}
+		p, ok := c.path(call.GetArgs()[0])
+		if !ok {
+			return nil, false
+		}
+		switch key := call.GetArgs()[1].GetConstExpr().GetConstantKind().(type) {
+		case *exprpb.Constant_StringValue:
+			return append(p, key.StringValue), true
+		case *exprpb.Constant_Int64Value:
+			return append(p, key.Int64Value), true
+		}
+	}
+	return nil, false
+}
+
+// bind records a bind argument and returns its placeholder.
+func (c *converter) bind(v interface{}) string {
+	c.args = append(c.args, v)
+	return "?"
+}
@@ -0,0 +1,327 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel2sql
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/go-cmp/cmp"
+	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func recordsEnv(t *testing.T) *cel.Env {
+	t.Helper()
+	env, err := cel.NewEnv(
+		cel.Types(&pb.Record{}),
+		cel.Declarations(decls.NewVar("name", decls.String)),
+		cel.Declarations(decls.NewVar("data_type", decls.String)),
+		cel.Declarations(decls.NewVar("data", decls.Dyn)),
+	)
+	if err != nil {
+		t.Fatalf("NewEnv: %v", err)
+	}
+	return env
+}
+
+func resultsEnv(t *testing.T) *cel.Env {
+	t.Helper()
+	env, err := resultscel.NewEnv()
+	if err != nil {
+		t.Fatalf("NewEnv: %v", err)
+	}
+	return env
+}
+
+func compile(t *testing.T, env *cel.Env, filter string) *cel.Ast {
+	t.Helper()
+	ast, err := resultscel.CompileFilter(env, filter)
+	if err != nil {
+		t.Fatalf("CompileFilter(%q): %v", filter, err)
+	}
+	return ast
+}
+
+func TestTranslate(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		env      *cel.Env
+		view     *View
+		dialect  string
+		filter   string
+		want     *Clause
+		complete bool
+	}{
+		{
+			name:     "string equality",
+			env:      recordsEnv(t),
+			view:     RecordsView,
+			dialect:  "postgres",
+			filter:   `data_type == "foo"`,
+			want:     &Clause{SQL: "type = ?", Args: []interface{}{"foo"}},
+			complete: true,
+		},
+		{
+			name:     "literal on the left",
+			env:      recordsEnv(t),
+			view:     RecordsView,
+			dialect:  "postgres",
+			filter:   `"foo" < data_type`,
+			want:     &Clause{SQL: `type > ? COLLATE "C"`, Args: []interface{}{"foo"}},
+			complete: true,
+		},
+		{
+			name:     "concatenated name",
+			env:      recordsEnv(t),
+			view:     RecordsView,
+			dialect:  "postgres",
+			filter:   `name.startsWith("a/results/b_%")`,
+			want:     &Clause{SQL: "(parent || '/results/' || result_name || '/records/' || name) LIKE ?", Args: []interface{}{`a/results/b\_\%%`}},
+			complete: true,
+		},
+		{
+			name:     "sqlite glob",
+			env:      recordsEnv(t),
+			view:     RecordsView,
+			dialect:  "sqlite",
+			filter:   `data_type.contains("a*[b]?")`,
+			want:     &Clause{SQL: "type GLOB ?", Args: []interface{}{"*a[*][[]b][?]*"}},
+			complete: true,
+		},
+		{
+			name:    "json data",
+			env:     recordsEnv(t),
+			view:    RecordsView,
+			dialect: "postgres",
+			filter:  `data.metadata["app.kubernetes.io/name"] == "foo" || data.items[0] != "bar"`,
+			want: &Clause{
+				SQL:  `((jsonb_typeof(data->'metadata'->'app.kubernetes.io/name') = 'string' AND data->'metadata'->>'app.kubernetes.io/name' = ?) OR NOT (jsonb_typeof(data->'items'->0) = 'string' AND data->'items'->>0 = ?))`,
+				Args: []interface{}{"foo", "bar"},
+			},
+			complete: true,
+		},
+		{
+			name:    "sqlite json data",
+			env:     recordsEnv(t),
+			view:    RecordsView,
+			dialect: "sqlite",
+			filter:  `data.metadata["app.kubernetes.io/name"] in ["a", "b"]`,
+			want: &Clause{
+				SQL:  `(json_type(data, '$."metadata"."app.kubernetes.io/name"') = 'text' AND data->>'$."metadata"."app.kubernetes.io/name"' IN (?, ?))`,
+				Args: []interface{}{"a", "b"},
+			},
+			complete: true,
+		},
+		{
+			name:    "mysql json data",
+			env:     recordsEnv(t),
+			view:    RecordsView,
+			dialect: "mysql",
+			filter:  `data.metadata.name.startsWith("a_b")`,
+			want: &Clause{
+				SQL:  `(JSON_TYPE(JSON_EXTRACT(data, '$."metadata"."name"')) = 'STRING' AND data->>'$."metadata"."name"' LIKE ?)`,
+				Args: []interface{}{`a\_b%`},
+			},
+			complete: true,
+		},
+		{
+			name:     "enum and negation",
+			env:      resultsEnv(t),
+			view:     ResultsView,
+			dialect:  "postgres",
+			filter:   `!(result.summary.status == tekton.results.v1alpha2.RecordSummary.Status.SUCCESS)`,
+			want:     &Clause{SQL: "NOT (recordsummary_status = ?)", Args: []interface{}{int64(pb.RecordSummary_SUCCESS)}},
+			complete: true,
+		},
+		{
+			name:     "annotations",
+			env:      resultsEnv(t),
+			view:     ResultsView,
+			dialect:  "postgres",
+			filter:   `"foo" in result.annotations && result.summary.annotations["bar"] == "baz"`,
+			want:     &Clause{SQL: "(annotations->>'foo' IS NOT NULL AND recordsummary_annotations->>'bar' = ?)", Args: []interface{}{"baz"}},
+			complete: true,
+		},
+		{
+			name:     "timestamps",
+			env:      resultsEnv(t),
+			view:     ResultsView,
+			dialect:  "postgres",
+			filter:   `result.create_time >= timestamp("2023-01-02T03:04:05Z")`,
+			want:     &Clause{SQL: "created_time >= ?", Args: []interface{}{ts}},
+			complete: true,
+		},
+		{
+			name:     "nullable timestamps",
+			env:      resultsEnv(t),
+			view:     ResultsView,
+			dialect:  "postgres",
+			filter:   `result.summary.end_time < timestamp("2023-01-02T03:04:05Z")`,
+			want:     &Clause{SQL: "COALESCE(recordsummary_end_time, TIMESTAMPTZ 'epoch') < ?", Args: []interface{}{ts}},
+			complete: true,
+		},
+		{
+			name:    "sqlite timestamps",
+			env:     resultsEnv(t),
+			view:    ResultsView,
+			dialect: "sqlite",
+			filter:  `result.create_time >= timestamp("2023-01-02T03:04:05Z")`,
+		},
+		{
+			name:     "mysql timestamps",
+			env:      resultsEnv(t),
+			view:     ResultsView,
+			dialect:  "mysql",
+			filter:   `result.create_time >= timestamp("2023-01-02T03:04:05Z")`,
+			want:     &Clause{SQL: "created_time >= ?", Args: []interface{}{ts}},
+			complete: true,
+		},
+		{
+			name:    "partial",
+			env:     resultsEnv(t),
+			view:    ResultsView,
+			dialect: "postgres",
+			filter:  `result.id == "a" && result.id.size() > 1 && result.etag == "b"`,
+			want:    &Clause{SQL: "(id = ? AND etag = ?)", Args: []interface{}{"a", "b"}},
+		},
+		{
+			name:    "unsupported",
+			env:     recordsEnv(t),
+			view:    RecordsView,
+			dialect: "postgres",
+			filter:  `data.status.conditions.exists(c, c.type == "Succeeded")`,
+		},
+		{
+			name:    "type mismatch",
+			env:     recordsEnv(t),
+			view:    RecordsView,
+			dialect: "postgres",
+			filter:  `data.spec.count == 1`,
+		},
+		{
+			name:    "unknown dialect",
+			env:     recordsEnv(t),
+			view:    RecordsView,
+			dialect: "oracle",
+			filter:  `data_type == "foo"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, complete, err := Translate(compile(t, tc.env, tc.filter), tc.view, tc.dialect)
+			if err != nil {
+				t.Fatalf("Translate: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+			if complete != tc.complete {
+				t.Errorf("complete: want %t, got %t", tc.complete, complete)
+			}
+		})
+	}
+}
+
+// TestTranslateSQLite checks that translated filters select the same Records
+// as evaluating the filters in memory.
+func TestTranslateSQLite(t *testing.T) {
+	gdb := test.NewDB(t)
+	if err := gdb.AutoMigrate(&db.Result{}, &db.Record{}); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+	if err := gdb.Create(&db.Result{Parent: "foo", ID: "1", Name: "bar"}).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	data := []string{
+		`{"metadata": {"name": "a", "labels": {"app.kubernetes.io/name": "x"}}}`,
+		`{"metadata": {"name": "b*"}, "items": ["c"]}`,
+		`{"metadata": {"name": 1}}`,
+		`{"metadata": {"name": "B"}}`,
+		`{}`,
+	}
+	for i, d := range data {
+		r := &db.Record{Parent: "foo", ResultID: "1", ResultName: "bar", ID: fmt.Sprint(i), Name: fmt.Sprintf("r-%d", i), Type: fmt.Sprintf("type-%d", i%2), Data: []byte(d)}
+		if err := gdb.Create(r).Error; err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	env := recordsEnv(t)
+	for _, filter := range []string{
+		`data_type == "type-1"`,
+		`name.endsWith("-3") || name.startsWith("foo/results/bar/records/r-1")`,
+		`data.metadata.name == "a"`,
+		`data.metadata.name != "a"`,
+		`!(data.metadata.name == "a")`,
+		`data.metadata.name > "B"`,
+		`data.metadata.name.startsWith("b*")`,
+		`data.metadata.labels["app.kubernetes.io/name"] == "x"`,
+		`data.items[0] in ["c", "d"]`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			ast := compile(t, env, filter)
+			clause, complete, err := Translate(ast, RecordsView, "sqlite")
+			if err != nil || !complete {
+				t.Fatalf("Translate: complete %t, %v", complete, err)
+			}
+			var rows []*db.Record
+			if err := gdb.Where(clause.SQL, clause.Args...).Find(&rows).Error; err != nil {
+				t.Fatalf("Find: %v", err)
+			}
+			var got []string
+			for _, r := range rows {
+				got = append(got, r.ID)
+			}
+			sort.Strings(got)
+
+			prg, err := resultscel.NewProgram(env, ast)
+			if err != nil {
+				t.Fatalf("NewProgram: %v", err)
+			}
+			var want []string
+			for i := range data {
+				r, err := record.ToAPI(&db.Record{Parent: "foo", ResultName: "bar", ID: fmt.Sprint(i), Name: fmt.Sprintf("r-%d", i), Type: fmt.Sprintf("type-%d", i%2), Data: []byte(data[i])})
+				if err != nil {
+					t.Fatalf("ToAPI: %v", err)
+				}
+				ok, err := record.Match(r, prg)
+				// Values of other types than the literals are errors in
+				// memory, and don't match in the database.
+				if status.Code(err) == codes.InvalidArgument {
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Match(%s): %v", data[i], err)
+				}
+				if ok {
+					want = append(want, fmt.Sprint(i))
+				}
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel2sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dialect generates the database specific parts of a condition.
+type dialect interface {
+	// supports reports whether columns of the given kind can be compared in
+	// the database with the same semantics as in CEL.
+	supports(k Kind) bool
+	// column returns the SQL expression for a non-JSON field.
+	column(f *Field) string
+	// jsonText returns the SQL expression extracting the value at path from
+	// a JSON column as text.
+	jsonText(column string, path []interface{}) (string, error)
+	// jsonIsString returns a condition checking that the value at path in a
+	// JSON column is a string.
+	jsonIsString(column string, path []interface{}) (string, error)
+	// pattern returns a pattern matching strings that start with (prefix),
+	// end with (suffix) or contain s, and the case-sensitive operator to
+	// match it with.
+	pattern(s string, prefix, suffix bool) (pattern, op string)
+	// binaryCollate makes a string comparison operand order by code point.
+	binaryCollate(operand string) string
+	// timestamp makes a timestamp operand comparable with time arguments.
+	timestamp(operand string) string
+	// epoch returns the Unix epoch as a timestamp literal.
+	epoch() string
+	// seconds returns the number of seconds elapsed between two timestamps.
+	seconds(start, end string) string
+}
+
+var dialects = map[string]dialect{
+	"postgres": postgres{},
+	"sqlite":   sqlite{},
+	"mysql":    mysql{},
+}
+
+// quote returns s as a SQL string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// concat joins SQL expressions with the standard || operator.
+func concat(f *Field) string {
+	if len(f.Concat) == 0 {
+		return f.Column
+	}
+	return "(" + strings.Join(f.Concat, " || ") + ")"
+}
+
+type postgres struct{}
+
+func (postgres) supports(Kind) bool {
+	return true
+}
+
+func (postgres) column(f *Field) string {
+	return concat(f)
+}
+
+// jsonText uses the jsonb -> and ->> operators, e.g. data->'a'->>'b'.
+func (postgres) jsonText(column string, path []interface{}) (string, error) {
+	return postgresPath(column, path, "->>")
+}
+
+func (postgres) jsonIsString(column string, path []interface{}) (string, error) {
+	value, err := postgresPath(column, path, "->")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("jsonb_typeof(%s) = 'string'", value), nil
+}
+
+// postgresPath navigates path with the -> operator, using last for the final
+// element.
+func postgresPath(column string, path []interface{}, last string) (string, error) {
+	var b strings.Builder
+	b.WriteString(column)
+	for i, p := range path {
+		op := "->"
+		if i == len(path)-1 {
+			op = last
+		}
+		switch k := p.(type) {
+		case string:
+			fmt.Fprintf(&b, "%s%s", op, quote(k))
+		case int64:
+			fmt.Fprintf(&b, "%s%d", op, k)
+		default:
+			return "", errUnsupported
+		}
+	}
+	return b.String(), nil
+}
+
+// pattern uses LIKE, escaping wildcards with Postgres' default escape
+// character.
+func (postgres) pattern(s string, prefix, suffix bool) (string, string) {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return wildcards(s, "%", prefix, suffix), "LIKE"
+}
+
+func (postgres) binaryCollate(operand string) string {
+	return operand + ` COLLATE "C"`
+}
+
+func (postgres) timestamp(operand string) string {
+	return operand
+}
+
+func (postgres) epoch() string {
+	return "TIMESTAMPTZ 'epoch'"
+}
+
+func (postgres) seconds(start, end string) string {
+	return fmt.Sprintf("CAST(EXTRACT(EPOCH FROM (%s - %s)) AS DOUBLE PRECISION)", end, start)
+}
+
+type sqlite struct{}
+
+// supports excludes timestamps, which SQLite stores as text that does not
+// order correctly across time zones.
+func (sqlite) supports(k Kind) bool {
+	return k != KindTime
+}
+
+func (sqlite) column(f *Field) string {
+	return concat(f)
+}
+
+// jsonText uses a quoted JSON path with the ->> operator (SQLite 3.38+), so
+// that keys containing dots are not split, e.g. data->>'$."a"."b"'.
+func (sqlite) jsonText(column string, path []interface{}) (string, error) {
+	p, err := jsonPath(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s->>%s", column, quote(p)), nil
+}
+
+func (sqlite) jsonIsString(column string, path []interface{}) (string, error) {
+	p, err := jsonPath(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("json_type(%s, %s) = 'text'", column, quote(p)), nil
+}
+
+// jsonPath returns the quoted JSON path used by SQLite and MySQL, e.g.
+// $."a"[0].
+func jsonPath(path []interface{}) (string, error) {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, p := range path {
+		switch k := p.(type) {
+		case string:
+			if strings.ContainsAny(k, `"\`) {
+				return "", errUnsupported
+			}
+			fmt.Fprintf(&b, `."%s"`, k)
+		case int64:
+			fmt.Fprintf(&b, "[%d]", k)
+		default:
+			return "", errUnsupported
+		}
+	}
+	return b.String(), nil
+}
+
+// pattern uses GLOB, since SQLite's LIKE is case-insensitive.
+func (sqlite) pattern(s string, prefix, suffix bool) (string, string) {
+	s = strings.NewReplacer(`*`, `[*]`, `?`, `[?]`, `[`, `[[]`).Replace(s)
+	return wildcards(s, "*", prefix, suffix), "GLOB"
+}
+
+// binaryCollate is a no-op: SQLite compares strings with memcmp by default.
+func (sqlite) binaryCollate(operand string) string {
+	return operand
+}
+
+// timestamp converts the text representation of timestamps to Julian day
+// numbers, which compare correctly across time zones.
+func (sqlite) timestamp(operand string) string {
+	return fmt.Sprintf("julianday(%s)", operand)
+}
+
+func (sqlite) epoch() string {
+	return "'1970-01-01 00:00:00+00:00'"
+}
+
+func (sqlite) seconds(start, end string) string {
+	return fmt.Sprintf("((julianday(%s) - julianday(%s)) * 86400.0)", end, start)
+}
+
+type mysql struct{}
+
+func (mysql) supports(Kind) bool {
+	return true
+}
+
+// column uses CONCAT, since || is the logical OR operator in MySQL.
+func (mysql) column(f *Field) string {
+	if len(f.Concat) == 0 {
+		return f.Column
+	}
+	return "CONCAT(" + strings.Join(f.Concat, ", ") + ")"
+}
+
+// jsonText uses the same quoted JSON paths as SQLite with the ->> operator,
+// e.g. data->>'$."a"."b"'.
+func (mysql) jsonText(column string, path []interface{}) (string, error) {
+	p, err := jsonPath(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s->>%s", column, quote(p)), nil
+}
+
+func (mysql) jsonIsString(column string, path []interface{}) (string, error) {
+	p, err := jsonPath(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("JSON_TYPE(JSON_EXTRACT(%s, %s)) = 'STRING'", column, quote(p)), nil
+}
+
+// pattern uses LIKE, which is case-sensitive on the binary collation of the
+// tables and of JSON values.
+func (mysql) pattern(s string, prefix, suffix bool) (string, string) {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return wildcards(s, "%", prefix, suffix), "LIKE"
+}
+
+// binaryCollate is a no-op: columns use the binary utf8mb4_bin collation,
+// which takes precedence over the collation of the arguments.
+func (mysql) binaryCollate(operand string) string {
+	return operand
+}
+
+func (mysql) timestamp(operand string) string {
+	return operand
+}
+
+func (mysql) epoch() string {
+	return "TIMESTAMP '1970-01-01 00:00:00'"
+}
+
+func (mysql) seconds(start, end string) string {
+	return fmt.Sprintf("(TIMESTAMPDIFF(MICROSECOND, %s, %s) / 1000000.0)", start, end)
+}
+
+func wildcards(s, any string, prefix, suffix bool) string {
+	switch {
+	case prefix:
+		return s + any
+	case suffix:
+		return any + s
+	}
+	return any + s + any
+}
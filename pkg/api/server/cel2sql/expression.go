@@ -0,0 +1,111 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel2sql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+)
+
+// Expression translates a checked CEL expression selecting a field of view,
+// such as result.summary.annotations["repo"], into a SQL expression. Values
+// of map and JSON fields are returned as text, or NULL if they are missing or
+// are not strings.
+func Expression(ast *cel.Ast, view *View, dialect string) (string, error) {
+	d, ok := dialects[dialect]
+	if !ok {
+		return "", fmt.Errorf("unsupported dialect %q", dialect)
+	}
+	checked, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return "", err
+	}
+	c := &converter{
+		view:    view,
+		dialect: d,
+		refs:    checked.GetReferenceMap(),
+	}
+	o, err := c.operand(checked.GetExpr())
+	if err != nil {
+		return "", err
+	}
+	if o.literal {
+		return "", errUnsupported
+	}
+	if o.guard != "" {
+		return fmt.Sprintf("CASE WHEN %s THEN %s END", o.guard, o.sql), nil
+	}
+	return o.sql, nil
+}
+
+// Timestamp returns the SQL expression of a timestamp column, or of a time
+// argument for "?", that compares and orders correctly with other timestamps.
+func Timestamp(dialect, column string) (string, error) {
+	d, ok := dialects[dialect]
+	if !ok {
+		return "", fmt.Errorf("unsupported dialect %q", dialect)
+	}
+	return d.timestamp(column), nil
+}
+
+// TimeRange returns a condition selecting the rows whose timestamp column is
+// in [start, end). Zero times leave the corresponding side unbounded. It
+// returns nil if both are zero.
+func TimeRange(dialect, column string, start, end time.Time) (*Clause, error) {
+	d, ok := dialects[dialect]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect %q", dialect)
+	}
+	var (
+		conds []string
+		args  []interface{}
+	)
+	if !start.IsZero() {
+		conds = append(conds, fmt.Sprintf("%s >= %s", d.timestamp(column), d.timestamp("?")))
+		args = append(args, start)
+	}
+	if !end.IsZero() {
+		conds = append(conds, fmt.Sprintf("%s < %s", d.timestamp(column), d.timestamp("?")))
+		args = append(args, end)
+	}
+	if len(conds) == 0 {
+		return nil, nil
+	}
+	return &Clause{SQL: strings.Join(conds, " AND "), Args: args}, nil
+}
+
+// Seconds returns a SQL expression of the number of seconds, as a floating
+// point number, elapsed between the start and end timestamp expressions.
+func Seconds(dialect, start, end string) (string, error) {
+	d, ok := dialects[dialect]
+	if !ok {
+		return "", fmt.Errorf("unsupported dialect %q", dialect)
+	}
+	return d.seconds(start, end), nil
+}
+
+// JSONText returns a SQL expression extracting the value at path from a JSON
+// column as text. Path elements are object keys (strings) or array indexes
+// (int64).
+func JSONText(dialect, column string, path ...interface{}) (string, error) {
+	d, ok := dialects[dialect]
+	if !ok {
+		return "", fmt.Errorf("unsupported dialect %q", dialect)
+	}
+	return d.jsonText(column, path)
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel2sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpression(t *testing.T) {
+	for _, tc := range []struct {
+		expr    string
+		dialect string
+		want    string
+		wantErr bool
+	}{
+		{expr: "result.summary.type", dialect: "postgres", want: "recordsummary_type"},
+		{expr: "result.name", dialect: "sqlite", want: "(parent || '/results/' || name)"},
+		{expr: `result.summary.annotations["repo"]`, dialect: "postgres", want: "recordsummary_annotations->>'repo'"},
+		{expr: `result.annotations["a.b"]`, dialect: "sqlite", want: `annotations->>'$."a.b"'`},
+		{expr: "result.created_time", dialect: "sqlite", wantErr: true},
+		{expr: `"foo"`, dialect: "postgres", wantErr: true},
+		{expr: "result.name", dialect: "mysql", want: "CONCAT(parent, '/results/', name)"},
+		{expr: `result.annotations["a.b"]`, dialect: "mysql", want: `annotations->>'$."a.b"'`},
+		{expr: "result.summary.type", dialect: "oracle", wantErr: true},
+	} {
+		t.Run(tc.expr+"/"+tc.dialect, func(t *testing.T) {
+			ast, issues := resultsEnv(t).Compile(tc.expr)
+			if issues != nil && issues.Err() != nil {
+				t.Fatalf("Compile(%q): %v", tc.expr, issues.Err())
+			}
+			got, err := Expression(ast, ResultsView, tc.dialect)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	for _, tc := range []struct {
+		name       string
+		dialect    string
+		start, end time.Time
+		want       *Clause
+	}{
+		{
+			name:    "unbounded",
+			dialect: "postgres",
+		},
+		{
+			name:    "start",
+			dialect: "postgres",
+			start:   start,
+			want:    &Clause{SQL: "created_time >= ?", Args: []interface{}{start}},
+		},
+		{
+			name:    "start and end",
+			dialect: "sqlite",
+			start:   start,
+			end:     end,
+			want: &Clause{
+				SQL:  "julianday(created_time) >= julianday(?) AND julianday(created_time) < julianday(?)",
+				Args: []interface{}{start, end},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := TimeRange(tc.dialect, "created_time", tc.start, tc.end)
+			if err != nil {
+				t.Fatalf("TimeRange: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONText(t *testing.T) {
+	for _, tc := range []struct {
+		dialect string
+		want    string
+	}{
+		{dialect: "postgres", want: "data->'spec'->'resource'->>'uid'"},
+		{dialect: "sqlite", want: `data->>'$."spec"."resource"."uid"'`},
+		{dialect: "mysql", want: `data->>'$."spec"."resource"."uid"'`},
+	} {
+		t.Run(tc.dialect, func(t *testing.T) {
+			got, err := JSONText(tc.dialect, "data", "spec", "resource", "uid")
+			if err != nil {
+				t.Fatalf("JSONText: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+	if _, err := JSONText("oracle", "data", "spec"); err == nil {
+		t.Error("JSONText: want error for unknown dialect")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel2sql
+
+import "strings"
+
+// Kind describes how the values of a Field are stored.
+type Kind int
+
+const (
+	// KindString is a text column.
+	KindString Kind = iota
+	// KindInt is an integer column.
+	KindInt
+	// KindTime is a timestamp column.
+	KindTime
+	// KindMap is a JSON object of string values, such as annotations. Only
+	// its keys can be accessed.
+	KindMap
+	// KindJSON is an arbitrary JSON document, such as Record data. Values at
+	// any path in the document can be accessed, and are compared as text.
+	KindJSON
+)
+
+// Field maps a CEL field to the database.
+type Field struct {
+	Kind Kind
+	// Column is the name of the column storing the field.
+	Column string
+	// Concat, if set, computes the field by concatenating the listed columns
+	// and quoted string literals instead of reading Column.
+	Concat []string
+	// Nullable is set for timestamp columns that may be NULL. Unset
+	// timestamps are the Unix epoch in CEL, so NULL values are compared as
+	// the epoch.
+	Nullable bool
+}
+
+// View describes the CEL variables exposed to a filter and where they are
+// stored in the database.
+type View struct {
+	// Fields maps dotted CEL paths (e.g. "result.summary.status") to fields.
+	Fields map[string]*Field
+}
+
+// lookup finds the field for the longest prefix of the path, returning the
+// rest of the path.
+func (v *View) lookup(path []interface{}) (*Field, []interface{}, bool) {
+	var parts []string
+	for _, p := range path {
+		s, ok := p.(string)
+		if !ok {
+			break
+		}
+		parts = append(parts, s)
+	}
+	for i := len(parts); i > 0; i-- {
+		if f, ok := v.Fields[strings.Join(parts[:i], ".")]; ok {
+			return f, path[i:], true
+		}
+	}
+	return nil, nil, false
+}
+
+// ResultsView is the View of the db.Result table for the `result` variable of
+// the Results CEL environment.
+var ResultsView = &View{
+	Fields: map[string]*Field{
+		"result.name":                {Kind: KindString, Concat: []string{"parent", "'/results/'", "name"}},
+		"result.id":                  {Kind: KindString, Column: "id"},
+		"result.uid":                 {Kind: KindString, Column: "id"},
+		"result.etag":                {Kind: KindString, Column: "etag"},
+		"result.created_time":        {Kind: KindTime, Column: "created_time"},
+		"result.create_time":         {Kind: KindTime, Column: "created_time"},
+		"result.updated_time":        {Kind: KindTime, Column: "updated_time"},
+		"result.update_time":         {Kind: KindTime, Column: "updated_time"},
+		"result.delete_time":         {Kind: KindTime, Column: "delete_time", Nullable: true},
+		"result.annotations":         {Kind: KindMap, Column: "annotations"},
+		"result.summary.record":      {Kind: KindString, Column: "recordsummary_record"},
+		"result.summary.type":        {Kind: KindString, Column: "recordsummary_type"},
+		"result.summary.status":      {Kind: KindInt, Column: "recordsummary_status"},
+		"result.summary.start_time":  {Kind: KindTime, Column: "recordsummary_start_time", Nullable: true},
+		"result.summary.end_time":    {Kind: KindTime, Column: "recordsummary_end_time", Nullable: true},
+		"result.summary.annotations": {Kind: KindMap, Column: "recordsummary_annotations"},
+	},
+}
+
+// RecordsView is the View of the db.Record table for the Records CEL
+// environment, which exposes the `name`, `data_type` and `data` variables.
+var RecordsView = &View{
+	Fields: map[string]*Field{
+		"name":      {Kind: KindString, Concat: []string{"parent", "'/results/'", "result_name", "'/records/'", "name"}},
+		"data_type": {Kind: KindString, Column: "type"},
+		"data":      {Kind: KindJSON, Column: "data"},
+	},
+}
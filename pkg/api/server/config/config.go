@@ -1,11 +1,14 @@
 package config
 
 import (
-	"github.com/spf13/viper"
 	"log"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 type Config struct {
+	DB_TYPE                  string `mapstructure:"DB_TYPE"`
 	DB_USER                  string `mapstructure:"DB_USER"`
 	DB_PASSWORD              string `mapstructure:"DB_PASSWORD"`
 	DB_HOST                  string `mapstructure:"DB_HOST"`
@@ -18,22 +21,59 @@ type Config struct {
 	LOG_LEVEL                string `mapstructure:"LOG_LEVEL"`
 	TLS_HOSTNAME_OVERRIDE    string `mapstructure:"TLS_HOSTNAME_OVERRIDE"`
 	TLS_PATH                 string `mapstructure:"TLS_PATH"`
+	TLS_CLIENT_CA_PATH       string `mapstructure:"TLS_CLIENT_CA_PATH"`
+
+	AUTH_DISABLE            bool          `mapstructure:"AUTH_DISABLE"`
+	AUTH_IMPERSONATE        bool          `mapstructure:"AUTH_IMPERSONATE"`
+	AUTH_CACHE_SIZE         int           `mapstructure:"AUTH_CACHE_SIZE"`
+	AUTH_CACHE_TTL          time.Duration `mapstructure:"AUTH_CACHE_TTL"`
+	AUTH_CACHE_NEGATIVE_TTL time.Duration `mapstructure:"AUTH_CACHE_NEGATIVE_TTL"`
+	AUTH_POLICY_PATH        string        `mapstructure:"AUTH_POLICY_PATH"`
+	AUTH_FILTER_LISTS       bool          `mapstructure:"AUTH_FILTER_LISTS"`
+
+	AUTH_OIDC_ISSUER          string `mapstructure:"AUTH_OIDC_ISSUER"`
+	AUTH_OIDC_JWKS            string `mapstructure:"AUTH_OIDC_JWKS"`
+	AUTH_OIDC_AUDIENCE        string `mapstructure:"AUTH_OIDC_AUDIENCE"`
+	AUTH_OIDC_USERNAME_CLAIM  string `mapstructure:"AUTH_OIDC_USERNAME_CLAIM"`
+	AUTH_OIDC_USERNAME_PREFIX string `mapstructure:"AUTH_OIDC_USERNAME_PREFIX"`
+	AUTH_OIDC_GROUPS_CLAIM    string `mapstructure:"AUTH_OIDC_GROUPS_CLAIM"`
+	AUTH_OIDC_GROUPS_PREFIX   string `mapstructure:"AUTH_OIDC_GROUPS_PREFIX"`
+
+	LOGS_API                bool          `mapstructure:"LOGS_API"`
+	LOGS_TYPE               string        `mapstructure:"LOGS_TYPE"`
+	LOGS_BUFFER_SIZE        int           `mapstructure:"LOGS_BUFFER_SIZE"`
+	LOGS_PATH               string        `mapstructure:"LOGS_PATH"`
+	LOGS_COMPRESSION        string        `mapstructure:"LOGS_COMPRESSION"`
+	LOGS_DELETION_INTERVAL  time.Duration `mapstructure:"LOGS_DELETION_INTERVAL"`
+	LOGS_RECONCILE_INTERVAL time.Duration `mapstructure:"LOGS_RECONCILE_INTERVAL"`
+
+	S3_BUCKET_NAME         string        `mapstructure:"S3_BUCKET_NAME"`
+	S3_ENDPOINT            string        `mapstructure:"S3_ENDPOINT"`
+	S3_HOSTNAME_IMMUTABLE  bool          `mapstructure:"S3_HOSTNAME_IMMUTABLE"`
+	S3_REGION              string        `mapstructure:"S3_REGION"`
+	S3_ACCESS_KEY_ID       string        `mapstructure:"S3_ACCESS_KEY_ID"`
+	S3_SECRET_ACCESS_KEY   string        `mapstructure:"S3_SECRET_ACCESS_KEY"`
+	S3_MULTI_PART_SIZE     int64         `mapstructure:"S3_MULTI_PART_SIZE"`
+	S3_DOWNLOAD_URL_EXPIRY time.Duration `mapstructure:"S3_DOWNLOAD_URL_EXPIRY"`
+
+	RETENTION_POLICY_PATH string        `mapstructure:"RETENTION_POLICY_PATH"`
+	RETENTION_MAX_AGE     time.Duration `mapstructure:"RETENTION_MAX_AGE"`
+	RETENTION_MAX_COUNT   int           `mapstructure:"RETENTION_MAX_COUNT"`
+	RETENTION_INTERVAL    time.Duration `mapstructure:"RETENTION_INTERVAL"`
+	RETENTION_BATCH_SIZE  int           `mapstructure:"RETENTION_BATCH_SIZE"`
+
+	SOFT_DELETE_EXPIRY time.Duration `mapstructure:"SOFT_DELETE_EXPIRY"`
+
+	REDACTION_ENABLE      bool   `mapstructure:"REDACTION_ENABLE"`
+	REDACTION_POLICY_PATH string `mapstructure:"REDACTION_POLICY_PATH"`
+	REDACTION_PARAMS      string `mapstructure:"REDACTION_PARAMS"`
+
+	RECORD_REVISIONS_ENABLE    bool          `mapstructure:"RECORD_REVISIONS_ENABLE"`
+	RECORD_REVISIONS_MAX_AGE   time.Duration `mapstructure:"RECORD_REVISIONS_MAX_AGE"`
+	RECORD_REVISIONS_MAX_COUNT int           `mapstructure:"RECORD_REVISIONS_MAX_COUNT"`
 
-	AUTH_DISABLE     bool `mapstructure:"AUTH_DISABLE"`
-	AUTH_IMPERSONATE bool `mapstructure:"AUTH_IMPERSONATE"`
-
-	LOGS_API         bool   `mapstructure:"LOGS_API"`
-	LOGS_TYPE        string `mapstructure:"LOGS_TYPE"`
-	LOGS_BUFFER_SIZE int    `mapstructure:"LOGS_BUFFER_SIZE"`
-	LOGS_PATH        string `mapstructure:"LOGS_PATH"`
-
-	S3_BUCKET_NAME        string `mapstructure:"S3_BUCKET_NAME"`
-	S3_ENDPOINT           string `mapstructure:"S3_ENDPOINT"`
-	S3_HOSTNAME_IMMUTABLE bool   `mapstructure:"S3_HOSTNAME_IMMUTABLE"`
-	S3_REGION             string `mapstructure:"S3_REGION"`
-	S3_ACCESS_KEY_ID      string `mapstructure:"S3_ACCESS_KEY_ID"`
-	S3_SECRET_ACCESS_KEY  string `mapstructure:"S3_SECRET_ACCESS_KEY"`
-	S3_MULTI_PART_SIZE    int64  `mapstructure:"S3_MULTI_PART_SIZE"`
+	AUDIT_SINK        string `mapstructure:"AUDIT_SINK"`
+	AUDIT_POLICY_PATH string `mapstructure:"AUDIT_POLICY_PATH"`
 }
 
 func Get() *Config {
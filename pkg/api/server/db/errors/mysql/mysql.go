@@ -0,0 +1,45 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mysql provides MySQL-specific error checking.
+package mysql
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code converts MySQL error numbers to gRPC status codes. This is not an
+// exhaustive list.
+// See https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+// for the list of error numbers.
+func Code(err error) codes.Code {
+	var merr *mysql.MySQLError
+	if !errors.As(err, &merr) {
+		return status.Code(err)
+	}
+
+	switch merr.Number {
+	case 1062: // ER_DUP_ENTRY
+		return codes.AlreadyExists
+	case 1451, 1452: // ER_ROW_IS_REFERENCED_2, ER_NO_REFERENCED_ROW_2
+		return codes.FailedPrecondition
+	case 1048, 1406, 3819: // ER_BAD_NULL_ERROR, ER_DATA_TOO_LONG, ER_CHECK_CONSTRAINT_VIOLATED
+		return codes.InvalidArgument
+	}
+	return status.Code(err)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgres provides Postgres-specific error checking.
+package postgres
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code converts Postgres error codes to gRPC status codes. This is not an
+// exhaustive list.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html for the
+// list of error codes.
+func Code(err error) codes.Code {
+	var perr *pgconn.PgError
+	if !errors.As(err, &perr) {
+		return status.Code(err)
+	}
+
+	switch perr.Code {
+	case "23505": // unique_violation
+		return codes.AlreadyExists
+	case "23503": // foreign_key_violation
+		return codes.FailedPrecondition
+	}
+	// Class 23 - Integrity Constraint Violation
+	if strings.HasPrefix(perr.Code, "23") {
+		return codes.InvalidArgument
+	}
+	return status.Code(err)
+}
@@ -25,11 +25,11 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// sqlite converts sqlite3 error codes to gRPC status codes. This is not an
+// Code converts sqlite3 error codes to gRPC status codes. This is not an
 // exhaustive list.
 // See https://pkg.go.dev/github.com/mattn/go-sqlite3#pkg-variables for list of
 // error codes.
-func sqlite(err error) codes.Code {
+func Code(err error) codes.Code {
 	serr, ok := err.(sqlite3.Error)
 	if !ok {
 		return status.Code(err)
@@ -51,5 +51,5 @@ func sqlite(err error) codes.Code {
 }
 
 func init() {
-	errors.RegisterErrorSpace(sqlite)
+	errors.RegisterErrorSpace(Code)
 }
@@ -0,0 +1,390 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migrate applies the versioned migrations of the database schema.
+//
+// Migrations are SQL files embedded in the binary, under
+// migrations/<dialect>/<version>_<name>.(up|down).sql, where the dialect is
+// the name of the gorm dialector. Every dialect has the same versions, so
+// that a version describes the same schema on all databases. The versions
+// applied to a database are recorded in the schema_migrations table.
+//
+// Statements of a migration are separated by semicolons at the end of a
+// line. Migrations run in a transaction, unless their first line is
+// "-- migrate:no-transaction", e.g. to build indexes concurrently on
+// Postgres. Such migrations must be safe to run again if they fail halfway.
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+// noTransaction is the directive of migrations that cannot run in a
+// transaction.
+const noTransaction = "-- migrate:no-transaction"
+
+// table is the name of the table recording the applied migrations.
+const table = "schema_migrations"
+
+// createTable creates the schema_migrations table, by dialect.
+var createTable = map[string]string{
+	"postgres": "CREATE TABLE IF NOT EXISTS " + table + " (version bigint PRIMARY KEY, name varchar(255) NOT NULL, applied_time timestamptz NOT NULL)",
+	"mysql":    "CREATE TABLE IF NOT EXISTS " + table + " (version bigint PRIMARY KEY, name varchar(255) NOT NULL, applied_time datetime(3) NOT NULL)",
+	"sqlite":   "CREATE TABLE IF NOT EXISTS " + table + " (version integer PRIMARY KEY, name text NOT NULL, applied_time datetime NOT NULL)",
+}
+
+// lockKey is the key of the Postgres advisory lock serializing migrations, so
+// that API servers starting at the same time do not apply them twice.
+const lockKey = 0x7265_7375_6c74_73 // "results"
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrNewerSchema is returned when the database has migrations applied that are
+// unknown to this version of the API server.
+var ErrNewerSchema = errors.New("database schema is newer than supported by this version of the API server")
+
+// Migration is a version of the database schema.
+type Migration struct {
+	Version int64
+	Name    string
+	// Up and Down are the SQL statements migrating the database to and from
+	// this version.
+	Up, Down string
+	// NoTransaction is set for migrations that cannot run in a transaction.
+	NoTransaction bool
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Applied is a migration applied to the database.
+type Applied struct {
+	Version     int64
+	Name        string
+	AppliedTime time.Time
+}
+
+// TableName returns the name of the table recording the applied migrations.
+// This implements the gorm schema.Tabler interface.
+func (Applied) TableName() string {
+	return table
+}
+
+// Status is the state of the database schema.
+type Status struct {
+	// Version is the latest migration applied to the database, or 0 if no
+	// migration was applied.
+	Version int64
+	// Latest is the latest migration known to the API server.
+	Latest int64
+	// Applied lists the migrations applied to the database, by version.
+	Applied []Applied
+	// Pending lists the known migrations not applied yet, by version.
+	Pending []Migration
+	// Unknown lists the migrations applied to the database that are unknown
+	// to the API server.
+	Unknown []Applied
+}
+
+// Check returns ErrNewerSchema if the database has migrations applied that
+// are unknown to the API server.
+func (s *Status) Check() error {
+	if len(s.Unknown) == 0 {
+		return nil
+	}
+	versions := make([]string, 0, len(s.Unknown))
+	for _, a := range s.Unknown {
+		versions = append(versions, strconv.FormatInt(a.Version, 10))
+	}
+	return fmt.Errorf("%w: unknown migrations %s applied, latest known migration is %d", ErrNewerSchema, strings.Join(versions, ", "), s.Latest)
+}
+
+// Migrator applies the migrations of the dialect of a database.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New returns a Migrator with the embedded migrations of the dialect of the
+// database.
+func New(db *gorm.DB) (*Migrator, error) {
+	return newMigrator(db, migrations)
+}
+
+func newMigrator(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	if _, ok := createTable[dialect]; !ok {
+		return nil, fmt.Errorf("migrations are not supported on %s", dialect)
+	}
+	ms, err := load(fsys, path.Join("migrations", dialect))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: ms}, nil
+}
+
+// load reads the migrations in the directory, sorted by version.
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		match := fileName.FindStringSubmatch(e.Name())
+		if match == nil || e.IsDir() {
+			return nil, fmt.Errorf("invalid migration file %s", path.Join(dir, e.Name()))
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid version of migration file %s", path.Join(dir, e.Name()))
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", m, match[2])
+		}
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration: %w", err)
+		}
+		if match[3] == "up" {
+			m.Up = string(b)
+			m.NoTransaction = strings.HasPrefix(m.Up, noTransaction)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %s must have both up and down files", m)
+		}
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// Status returns the state of the database schema. It does not modify the
+// database.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	return m.status(m.db.WithContext(ctx))
+}
+
+func (m *Migrator) status(db *gorm.DB) (*Status, error) {
+	s := &Status{}
+	if len(m.migrations) > 0 {
+		s.Latest = m.migrations[len(m.migrations)-1].Version
+	}
+	if db.Migrator().HasTable(table) {
+		if err := db.Order("version").Find(&s.Applied).Error; err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", table, err)
+		}
+	}
+
+	applied := make(map[int64]bool, len(s.Applied))
+	for _, a := range s.Applied {
+		applied[a.Version] = true
+		if a.Version > s.Version {
+			s.Version = a.Version
+		}
+	}
+	known := make(map[int64]bool, len(m.migrations))
+	for _, mg := range m.migrations {
+		known[mg.Version] = true
+		if !applied[mg.Version] {
+			s.Pending = append(s.Pending, mg)
+		}
+	}
+	for _, a := range s.Applied {
+		if !known[a.Version] {
+			s.Unknown = append(s.Unknown, a)
+		}
+	}
+	return s, nil
+}
+
+// Up applies the pending migrations in order, and returns the migrations that
+// were applied. It refuses to migrate a database with migrations unknown to
+// the API server.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	db := m.db.WithContext(ctx)
+	if err := db.Exec(createTable[db.Dialector.Name()]).Error; err != nil {
+		return nil, fmt.Errorf("error creating %s: %w", table, err)
+	}
+	s, err := m.status(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Check(); err != nil {
+		return nil, err
+	}
+
+	var out []Migration
+	for _, mg := range s.Pending {
+		mg := mg
+		applied, err := m.run(db, mg, mg.Up, false, func(tx *gorm.DB) error {
+			return tx.Create(&Applied{Version: mg.Version, Name: mg.Name, AppliedTime: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return out, err
+		}
+		if applied {
+			out = append(out, mg)
+		}
+	}
+	return out, nil
+}
+
+// Down reverts the latest migration applied to the database, and returns it.
+// It returns nil if no migration is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	db := m.db.WithContext(ctx)
+	s, err := m.status(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Check(); err != nil {
+		return nil, err
+	}
+	if len(s.Applied) == 0 {
+		return nil, nil
+	}
+
+	last := s.Applied[len(s.Applied)-1]
+	for _, mg := range m.migrations {
+		if mg.Version != last.Version {
+			continue
+		}
+		if _, err := m.run(db, mg, mg.Down, true, func(tx *gorm.DB) error {
+			return tx.Delete(&Applied{}, "version = ?", mg.Version).Error
+		}); err != nil {
+			return nil, err
+		}
+		return &mg, nil
+	}
+	// Unreachable, as the applied migrations are all known.
+	return nil, fmt.Errorf("migration %d not found", last.Version)
+}
+
+// run runs the statements of the migration and records it in the
+// schema_migrations table with record. Unless the migration is marked as
+// not transactional, both run in a transaction, which is skipped if the
+// migration is no longer in the applied state when the transaction starts,
+// e.g. because another API server migrated the database concurrently. It
+// returns whether the migration was run.
+func (m *Migrator) run(db *gorm.DB, mg Migration, sql string, applied bool, record func(tx *gorm.DB) error) (bool, error) {
+	statements, err := split(sql)
+	if err != nil {
+		return false, fmt.Errorf("migration %s: %w", mg, err)
+	}
+	exec := func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return fmt.Errorf("migration %s: %w", mg, err)
+			}
+		}
+		if err := record(tx); err != nil {
+			return fmt.Errorf("migration %s: error updating %s: %w", mg, table, err)
+		}
+		return nil
+	}
+	if mg.NoTransaction {
+		return true, exec(db)
+	}
+
+	var ran bool
+	err = db.Transaction(func(tx *gorm.DB) error {
+		current, err := m.lock(tx, mg.Version)
+		if err != nil || current != applied {
+			return err
+		}
+		ran = true
+		return exec(tx)
+	})
+	return ran, err
+}
+
+// lock takes the migration lock of the transaction where supported, and
+// returns whether the migration is applied. The lock is released when the
+// transaction ends. Other databases rely on the primary key of the
+// schema_migrations table instead: the migration fails if it was applied
+// concurrently.
+func (m *Migrator) lock(tx *gorm.DB, version int64) (bool, error) {
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+			return false, fmt.Errorf("error locking %s: %w", table, err)
+		}
+	}
+	var n int64
+	if err := tx.Model(&Applied{}).Where("version = ?", version).Count(&n).Error; err != nil {
+		return false, fmt.Errorf("error reading %s: %w", table, err)
+	}
+	return n > 0, nil
+}
+
+// split splits SQL into statements, on semicolons at the end of a line.
+// Comment lines are dropped.
+func split(sql string) ([]string, error) {
+	var (
+		out  []string
+		stmt strings.Builder
+	)
+	scanner := bufio.NewScanner(strings.NewReader(sql))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if stmt.Len() > 0 {
+			stmt.WriteString("\n")
+		}
+		if strings.HasSuffix(line, ";") {
+			stmt.WriteString(strings.TrimSuffix(line, ";"))
+			out = append(out, stmt.String())
+			stmt.Reset()
+			continue
+		}
+		stmt.WriteString(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if stmt.Len() > 0 {
+		return nil, fmt.Errorf("statement not terminated by a semicolon: %s", stmt.String())
+	}
+	return out, nil
+}
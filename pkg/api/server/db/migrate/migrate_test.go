@@ -0,0 +1,298 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"gorm.io/gorm"
+)
+
+// versions returns the versions of the migrations.
+func versions(ms []Migration) []int64 {
+	out := make([]int64, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, m.Version)
+	}
+	return out
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	want, err := load(migrations, "migrations/postgres")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	for _, dialect := range []string{db.Postgres, db.MySQL, db.SQLite} {
+		t.Run(dialect, func(t *testing.T) {
+			got, err := load(migrations, path.Join("migrations", dialect))
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			// All dialects have the same versions.
+			if diff := cmp.Diff(names(want), names(got)); diff != "" {
+				t.Errorf("migrations (-postgres, +%s): %s", dialect, diff)
+			}
+			for _, m := range got {
+				for _, sql := range []string{m.Up, m.Down} {
+					if _, err := split(sql); err != nil {
+						t.Errorf("migration %s: %v", m, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// names returns the names of the migrations.
+func names(ms []Migration) []string {
+	out := make([]string, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, m.String())
+	}
+	return out
+}
+
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	gdb := test.NewDB(t)
+	m, err := New(gdb)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if diff := cmp.Diff(versions(m.migrations), versions(applied)); diff != "" {
+		t.Errorf("applied migrations (-want, +got): %s", diff)
+	}
+	// The schema has the columns of all the models.
+	for _, model := range []interface{}{&db.Result{}, &db.Record{}, &db.RecordRevision{}, &db.LogChunk{}, &db.LogDeletion{}} {
+		stmt := &gorm.Statement{DB: gdb}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName != "" && !gdb.Migrator().HasColumn(model, f.DBName) {
+				t.Errorf("table %s: missing column %s", stmt.Schema.Table, f.DBName)
+			}
+		}
+	}
+
+	// Migrations are only applied once.
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("Up: want no migration applied, got %v, %v", applied, err)
+	}
+	s, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if s.Version != s.Latest || len(s.Applied) != len(m.migrations) || len(s.Pending) != 0 || s.Check() != nil {
+		t.Errorf("Status: want all migrations applied, got %+v", s)
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		reverted, err := m.Down(ctx)
+		if err != nil {
+			t.Fatalf("Down: %v", err)
+		}
+		if reverted.Version != m.migrations[i].Version {
+			t.Errorf("Down: want migration %s reverted, got %s", m.migrations[i], reverted)
+		}
+	}
+	if reverted, err := m.Down(ctx); reverted != nil || err != nil {
+		t.Errorf("Down: want no migration reverted, got %v, %v", reverted, err)
+	}
+	for _, table := range []string{"results", "records", "record_revisions", "log_chunks", "log_deletions"} {
+		if gdb.Migrator().HasTable(table) {
+			t.Errorf("table %s not dropped", table)
+		}
+	}
+
+	// The reverted migrations can be applied again.
+	if applied, err := m.Up(ctx); err != nil || len(applied) != len(m.migrations) {
+		t.Errorf("Up: want all migrations applied, got %v, %v", applied, err)
+	}
+}
+
+func TestNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	gdb := test.NewDB(t)
+	m, err := New(gdb)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	future := &Applied{Version: m.migrations[len(m.migrations)-1].Version + 1, Name: "future", AppliedTime: time.Now()}
+	if err := gdb.Create(future).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	s, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if s.Version != future.Version || len(s.Unknown) != 1 {
+		t.Errorf("Status: want unknown migration %d, got %+v", future.Version, s)
+	}
+	if err := s.Check(); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("Check: want %v, got %v", ErrNewerSchema, err)
+	}
+	if _, err := m.Up(ctx); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("Up: want %v, got %v", ErrNewerSchema, err)
+	}
+	if _, err := m.Down(ctx); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("Down: want %v, got %v", ErrNewerSchema, err)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	ctx := context.Background()
+	gdb := test.NewDB(t)
+	m, err := newMigrator(gdb, fstest.MapFS{
+		"migrations/sqlite/0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id integer);\n")},
+		"migrations/sqlite/0001_create_a.down.sql": {Data: []byte("DROP TABLE a;\n")},
+		"migrations/sqlite/0002_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id integer);\nINSERT INTO missing VALUES (1);\n")},
+		"migrations/sqlite/0002_create_b.down.sql": {Data: []byte("DROP TABLE b;\n")},
+		"migrations/sqlite/0003_create_c.up.sql":   {Data: []byte(noTransaction + "\nCREATE TABLE c (id integer);\nINSERT INTO missing VALUES (1);\n")},
+		"migrations/sqlite/0003_create_c.down.sql": {Data: []byte("DROP TABLE c;\n")},
+	})
+	if err != nil {
+		t.Fatalf("newMigrator: %v", err)
+	}
+	if !m.migrations[2].NoTransaction || m.migrations[0].NoTransaction {
+		t.Errorf("want only migration 3 to run without a transaction, got %+v", m.migrations)
+	}
+
+	applied, err := m.Up(ctx)
+	if err == nil {
+		t.Fatal("Up: want error")
+	}
+	if diff := cmp.Diff([]int64{1}, versions(applied)); diff != "" {
+		t.Errorf("applied migrations (-want, +got): %s", diff)
+	}
+	// The failed migration is rolled back.
+	if !gdb.Migrator().HasTable("a") || gdb.Migrator().HasTable("b") {
+		t.Error("want table a created and table b rolled back")
+	}
+
+	// Migrations without a transaction are not rolled back.
+	m.migrations[1].Up = "CREATE TABLE b (id integer);\n"
+	if _, err := m.Up(ctx); err == nil {
+		t.Fatal("Up: want error")
+	}
+	if !gdb.Migrator().HasTable("c") {
+		t.Error("want table c created")
+	}
+	s, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if s.Version != 2 || len(s.Pending) != 1 {
+		t.Errorf("Status: want migration 3 pending, got %+v", s)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		fs      fstest.MapFS
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			fs: fstest.MapFS{
+				"m/0010_b.up.sql":   {Data: []byte("up")},
+				"m/0010_b.down.sql": {Data: []byte("down")},
+				"m/0002_a.up.sql":   {Data: []byte("up")},
+				"m/0002_a.down.sql": {Data: []byte("down")},
+			},
+			want: []string{"0002_a", "0010_b"},
+		},
+		{
+			name:    "missing directory",
+			fs:      fstest.MapFS{},
+			wantErr: true,
+		},
+		{
+			name: "missing down",
+			fs: fstest.MapFS{
+				"m/0001_a.up.sql": {Data: []byte("up")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid name",
+			fs: fstest.MapFS{
+				"m/a.up.sql":   {Data: []byte("up")},
+				"m/a.down.sql": {Data: []byte("down")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate version",
+			fs: fstest.MapFS{
+				"m/0001_a.up.sql":   {Data: []byte("up")},
+				"m/0001_a.down.sql": {Data: []byte("down")},
+				"m/0001_b.up.sql":   {Data: []byte("up")},
+				"m/0001_b.down.sql": {Data: []byte("down")},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := load(tc.fs, "m")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("load: want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, names(got)); err == nil && diff != "" {
+				t.Errorf("load (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	got, err := split(`-- A comment.
+CREATE TABLE a (
+	id integer
+);
+
+INSERT INTO a VALUES (1);
+`)
+	if err != nil {
+		t.Fatalf("split: %v", err)
+	}
+	want := []string{"CREATE TABLE a (\nid integer\n)", "INSERT INTO a VALUES (1)"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("split (-want, +got): %s", diff)
+	}
+
+	if _, err := split("SELECT 1"); err == nil {
+		t.Error("split: want error for unterminated statement")
+	}
+}
@@ -0,0 +1,35 @@
+-- Tables use a binary collation, so that strings are compared and sorted by
+-- code point, as on the other databases.
+CREATE TABLE results (
+	parent varchar(64),
+	id varchar(64),
+	name varchar(64),
+	annotations json,
+	created_time datetime(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+	updated_time datetime(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+	recordsummary_record varchar(256),
+	recordsummary_type varchar(768),
+	recordsummary_start_time datetime(3) NULL,
+	recordsummary_end_time datetime(3) NULL,
+	recordsummary_status int,
+	recordsummary_annotations json,
+	etag varchar(128),
+	PRIMARY KEY (parent, id),
+	UNIQUE INDEX results_by_name (parent, name)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE records (
+	parent varchar(64),
+	result_id varchar(64),
+	result_name varchar(64),
+	id varchar(64),
+	name varchar(64),
+	type varchar(768),
+	data json,
+	created_time datetime(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+	updated_time datetime(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+	etag varchar(128),
+	PRIMARY KEY (parent, result_id, id),
+	UNIQUE INDEX records_by_name (parent, result_name, name),
+	CONSTRAINT fk_records_result FOREIGN KEY (parent, result_id) REFERENCES results (parent, id) ON DELETE CASCADE ON UPDATE CASCADE
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,2 @@
+ALTER TABLE records DROP INDEX idx_records_delete_time, DROP COLUMN delete_time;
+ALTER TABLE results DROP INDEX idx_results_delete_time, DROP COLUMN delete_time;
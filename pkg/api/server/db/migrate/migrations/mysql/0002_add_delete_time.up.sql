@@ -0,0 +1,2 @@
+ALTER TABLE results ADD COLUMN delete_time datetime(3) NULL, ADD INDEX idx_results_delete_time (delete_time);
+ALTER TABLE records ADD COLUMN delete_time datetime(3) NULL, ADD INDEX idx_records_delete_time (delete_time);
@@ -0,0 +1,13 @@
+CREATE TABLE record_revisions (
+	parent varchar(64),
+	result_id varchar(64),
+	record_id varchar(64),
+	id varchar(64),
+	type varchar(768),
+	data json,
+	etag varchar(128),
+	updated_time datetime(3) NULL,
+	PRIMARY KEY (parent, id),
+	INDEX record_revisions_by_record (parent, record_id),
+	CONSTRAINT fk_record_revisions_record FOREIGN KEY (parent, result_id, record_id) REFERENCES records (parent, result_id, id) ON DELETE CASCADE ON UPDATE CASCADE
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
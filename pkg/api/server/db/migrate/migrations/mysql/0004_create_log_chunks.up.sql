@@ -0,0 +1,8 @@
+CREATE TABLE log_chunks (
+	path varchar(512),
+	chunk bigint,
+	byte_offset bigint,
+	size bigint,
+	data longblob,
+	PRIMARY KEY (path, chunk)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
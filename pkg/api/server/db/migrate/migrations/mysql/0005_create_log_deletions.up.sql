@@ -0,0 +1,11 @@
+CREATE TABLE log_deletions (
+	id varchar(64),
+	record varchar(256),
+	log json,
+	attempts bigint,
+	last_error text,
+	next_attempt_time datetime(3) NULL,
+	created_time datetime(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+	PRIMARY KEY (id),
+	INDEX log_deletions_by_next_attempt (next_attempt_time)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
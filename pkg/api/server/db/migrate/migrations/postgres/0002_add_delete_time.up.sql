@@ -0,0 +1,5 @@
+ALTER TABLE results ADD COLUMN IF NOT EXISTS delete_time timestamptz;
+CREATE INDEX IF NOT EXISTS idx_results_delete_time ON results (delete_time);
+
+ALTER TABLE records ADD COLUMN IF NOT EXISTS delete_time timestamptz;
+CREATE INDEX IF NOT EXISTS idx_records_delete_time ON records (delete_time);
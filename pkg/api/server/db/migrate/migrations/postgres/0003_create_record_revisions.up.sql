@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS record_revisions (
+	parent varchar(64),
+	result_id varchar(64),
+	record_id varchar(64),
+	id varchar(64),
+	type varchar(768),
+	data jsonb,
+	etag varchar(128),
+	updated_time timestamptz,
+	PRIMARY KEY (parent, id),
+	CONSTRAINT fk_record_revisions_record FOREIGN KEY (parent, result_id, record_id) REFERENCES records (parent, result_id, id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS record_revisions_by_record ON record_revisions (parent, record_id);
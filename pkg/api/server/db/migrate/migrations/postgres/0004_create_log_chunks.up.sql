@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS log_chunks (
+	path varchar(512),
+	chunk bigint,
+	byte_offset bigint,
+	size bigint,
+	data bytea,
+	PRIMARY KEY (path, chunk)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS log_deletions (
+	id varchar(64),
+	record varchar(256),
+	log jsonb,
+	attempts bigint,
+	last_error text,
+	next_attempt_time timestamptz,
+	created_time timestamptz DEFAULT current_timestamp,
+	PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS log_deletions_by_next_attempt ON log_deletions (next_attempt_time);
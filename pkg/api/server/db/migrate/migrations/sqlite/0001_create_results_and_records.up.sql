@@ -0,0 +1,33 @@
+CREATE TABLE results (
+	parent text,
+	id text,
+	name text,
+	annotations jsonb,
+	created_time datetime DEFAULT current_timestamp,
+	updated_time datetime DEFAULT current_timestamp,
+	recordsummary_record text,
+	recordsummary_type text,
+	recordsummary_start_time datetime,
+	recordsummary_end_time datetime,
+	recordsummary_status integer,
+	recordsummary_annotations jsonb,
+	etag text,
+	PRIMARY KEY (parent, id)
+);
+CREATE UNIQUE INDEX results_by_name ON results (parent, name);
+
+CREATE TABLE records (
+	parent text,
+	result_id text,
+	result_name text,
+	id text,
+	name text,
+	type text,
+	data jsonb,
+	created_time datetime DEFAULT current_timestamp,
+	updated_time datetime DEFAULT current_timestamp,
+	etag text,
+	PRIMARY KEY (parent, result_id, id),
+	CONSTRAINT fk_records_result FOREIGN KEY (parent, result_id) REFERENCES results (parent, id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX records_by_name ON records (parent, result_name, name);
@@ -0,0 +1,5 @@
+DROP INDEX idx_records_delete_time;
+ALTER TABLE records DROP COLUMN delete_time;
+
+DROP INDEX idx_results_delete_time;
+ALTER TABLE results DROP COLUMN delete_time;
@@ -0,0 +1,5 @@
+ALTER TABLE results ADD COLUMN delete_time datetime;
+CREATE INDEX idx_results_delete_time ON results (delete_time);
+
+ALTER TABLE records ADD COLUMN delete_time datetime;
+CREATE INDEX idx_records_delete_time ON records (delete_time);
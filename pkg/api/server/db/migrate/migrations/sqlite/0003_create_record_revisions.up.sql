@@ -0,0 +1,13 @@
+CREATE TABLE record_revisions (
+	parent text,
+	result_id text,
+	record_id text,
+	id text,
+	type text,
+	data jsonb,
+	etag text,
+	updated_time datetime,
+	PRIMARY KEY (parent, id),
+	CONSTRAINT fk_record_revisions_record FOREIGN KEY (parent, result_id, record_id) REFERENCES records (parent, result_id, id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX record_revisions_by_record ON record_revisions (parent, record_id);
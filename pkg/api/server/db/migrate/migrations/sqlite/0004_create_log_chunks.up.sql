@@ -0,0 +1,8 @@
+CREATE TABLE log_chunks (
+	path text,
+	chunk integer,
+	byte_offset integer,
+	size integer,
+	data blob,
+	PRIMARY KEY (path, chunk)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE log_deletions (
+	id text,
+	record text,
+	log jsonb,
+	attempts integer,
+	last_error text,
+	next_attempt_time datetime,
+	created_time datetime DEFAULT current_timestamp,
+	PRIMARY KEY (id)
+);
+CREATE INDEX log_deletions_by_next_attempt ON log_deletions (next_attempt_time);
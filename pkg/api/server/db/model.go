@@ -21,14 +21,17 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // Result is the database model of a Result.
 type Result struct {
-	Parent      string      `gorm:"primaryKey;uniqueIndex:results_by_name,priority:1;size:64;"`
-	ID          string      `gorm:"primaryKey;size:64;"`
-	Name        string      `gorm:"uniqueIndex:results_by_name,priority:2;size:64;"`
-	Annotations Annotations `gorm:"type:jsonb;"`
+	Parent      string `gorm:"primaryKey;uniqueIndex:results_by_name,priority:1;size:64;"`
+	ID          string `gorm:"primaryKey;size:64;"`
+	Name        string `gorm:"uniqueIndex:results_by_name,priority:2;size:64;"`
+	Annotations Annotations
 
 	CreatedTime time.Time `gorm:"default:current_timestamp;"`
 	UpdatedTime time.Time `gorm:"default:current_timestamp;"`
@@ -36,6 +39,10 @@ type Result struct {
 	Summary RecordSummary `gorm:"embedded;embeddedPrefix:recordsummary_;"`
 
 	Etag string `gorm:"size:128;"`
+
+	// DeleteTime is set when the Result is soft deleted. Queries exclude
+	// soft deleted rows unless they are made Unscoped.
+	DeleteTime gorm.DeletedAt `gorm:"index;"`
 }
 
 // RecordSummary is the database model of a Result.RecordSummary.
@@ -47,7 +54,7 @@ type RecordSummary struct {
 	StartTime   *time.Time
 	EndTime     *time.Time
 	Status      int32
-	Annotations Annotations `gorm:"type:jsonb;"`
+	Annotations Annotations
 }
 
 func (r Result) String() string {
@@ -70,12 +77,86 @@ type Record struct {
 	// Napkin Math (with a bit of buffer): 256 (DNS Subdomain) * 3 (Group +
 	// Version + Kind).
 	Type string `gorm:"size:768;"`
-	Data []byte `gorm:"type:jsonb;"`
+	Data JSON
 
 	CreatedTime time.Time `gorm:"default:current_timestamp;"`
 	UpdatedTime time.Time `gorm:"default:current_timestamp;"`
 
 	Etag string `gorm:"size:128;"`
+
+	// DeleteTime is set when the Record is soft deleted, either on its own
+	// or along with its Result.
+	DeleteTime gorm.DeletedAt `gorm:"index;"`
+}
+
+// RecordRevision is the database model of a previous revision of a Record,
+// saved when the Record is updated.
+type RecordRevision struct {
+	// Record is used to create the relationship between the Record and
+	// RecordRevisions tables, so that revisions are deleted with their Record.
+	Record   Record `gorm:"foreignKey:Parent,ResultID,RecordID;references:Parent,ResultID,ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Parent   string `gorm:"primaryKey;index:record_revisions_by_record,priority:1;size:64;"`
+	ResultID string `gorm:"size:64;"`
+	RecordID string `gorm:"index:record_revisions_by_record,priority:2;size:64;"`
+
+	ID string `gorm:"primaryKey;size:64;"`
+
+	// Type, Data, Etag and UpdatedTime are the values of the Record fields
+	// in the revision.
+	Type        string `gorm:"size:768;"`
+	Data        JSON
+	Etag        string `gorm:"size:128;"`
+	UpdatedTime time.Time
+}
+
+// LogChunk is the database model of a chunk of a log stored in the database.
+type LogChunk struct {
+	// Path identifies the log of the chunk, like the path of a log file.
+	Path string `gorm:"primaryKey;size:512;"`
+	// Chunk is the sequence number of the chunk in the log.
+	Chunk int64 `gorm:"primaryKey;autoIncrement:false;"`
+	// ByteOffset is the offset in the log of the first byte of the chunk,
+	// and Size its size in bytes.
+	ByteOffset int64
+	Size       int64
+	Data       []byte
+}
+
+// LogDeletion is the database model of the pending deletion of a stored log.
+// It is created in the transaction deleting the Log Record, so that the log
+// is deleted even if the log storage is unavailable at the time.
+type LogDeletion struct {
+	ID string `gorm:"primaryKey;size:64;"`
+	// Record is the full name of the deleted Log Record.
+	Record string `gorm:"size:256;"`
+	// Log is the Log of the deleted Record, which locates the stored log.
+	Log JSON
+
+	// Attempts is the number of failed attempts to delete the log, and
+	// LastError the error of the last one.
+	Attempts  int64
+	LastError string
+	// NextAttemptTime is the time after which deletion is attempted again.
+	NextAttemptTime time.Time `gorm:"index:log_deletions_by_next_attempt;"`
+	CreatedTime     time.Time `gorm:"default:current_timestamp;"`
+}
+
+// JSON is a JSON document stored in the database.
+type JSON []byte
+
+// GormDBDataType returns the column type of JSON documents for the database.
+// This implements the migrator.GormDataTypeInterface interface.
+func (JSON) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	return jsonType(db)
+}
+
+// jsonType returns the type of the columns storing JSON documents: jsonb,
+// except on MySQL, which only has a json type.
+func jsonType(db *gorm.DB) string {
+	if db.Dialector.Name() == MySQL {
+		return "json"
+	}
+	return "jsonb"
 }
 
 // Annotations is a custom-defined type of a gorm model field.
@@ -107,3 +188,9 @@ func (ann Annotations) Value() (driver.Value, error) {
 	}
 	return bytes, nil
 }
+
+// GormDBDataType returns the column type of Annotations for the database.
+// This implements the migrator.GormDataTypeInterface interface.
+func (Annotations) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	return jsonType(db)
+}
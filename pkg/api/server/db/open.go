@@ -0,0 +1,91 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	mysqlerrors "github.com/tektoncd/results/pkg/api/server/db/errors/mysql"
+	pgerrors "github.com/tektoncd/results/pkg/api/server/db/errors/postgres"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Database types supported by DB_TYPE. They are the names of the
+// corresponding gorm dialectors.
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	SQLite   = "sqlite"
+)
+
+// Open connects to the database of the type set by DB_TYPE, Postgres by
+// default, and registers the error space of its driver.
+func Open(cfg *config.Config, gormConfig *gorm.Config) (*gorm.DB, error) {
+	var (
+		dialector gorm.Dialector
+		err       error
+	)
+	switch cfg.DB_TYPE {
+	case "", Postgres:
+		// DSN derived from https://pkg.go.dev/gorm.io/driver/postgres
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s", cfg.DB_HOST, cfg.DB_USER, cfg.DB_PASSWORD, cfg.DB_NAME, cfg.DB_PORT, cfg.DB_SSLMODE)
+		dialector = postgres.Open(dsn)
+		errors.RegisterErrorSpace(pgerrors.Code)
+	case MySQL:
+		dialector = mysql.Open(mysqlDSN(cfg))
+		errors.RegisterErrorSpace(mysqlerrors.Code)
+	case SQLite:
+		// SQLite is only available in binaries built with cgo, see
+		// sqlite.go.
+		dialector, err = openSQLite(cfg.DB_NAME)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported DB_TYPE %q, must be one of %s, %s or %s", cfg.DB_TYPE, Postgres, MySQL, SQLite)
+	}
+	return gorm.Open(dialector, gormConfig)
+}
+
+// mysqlDSN returns the DSN of the MySQL database. Times are read and written
+// in UTC, and DB_SSLMODE is mapped to the closest TLS mode of the driver.
+func mysqlDSN(cfg *config.Config) string {
+	c := mysqldriver.NewConfig()
+	c.User = cfg.DB_USER
+	c.Passwd = cfg.DB_PASSWORD
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(cfg.DB_HOST, cfg.DB_PORT)
+	c.DBName = cfg.DB_NAME
+	c.ParseTime = true
+	c.Loc = time.UTC
+	c.Params = map[string]string{"charset": "utf8mb4"}
+	switch cfg.DB_SSLMODE {
+	case "", "disable":
+	case "allow", "prefer":
+		c.TLSConfig = "preferred"
+	case "require":
+		c.TLSConfig = "skip-verify"
+	default:
+		c.TLSConfig = "true"
+	}
+	return c.FormatDSN()
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"gorm.io/gorm"
+)
+
+func TestMySQLDSN(t *testing.T) {
+	cfg := &config.Config{
+		DB_USER:     "user",
+		DB_PASSWORD: "hunter2",
+		DB_HOST:     "mysql.example.com",
+		DB_PORT:     "3306",
+		DB_NAME:     "tekton_results",
+	}
+	for _, tc := range []struct {
+		sslmode string
+		want    string
+	}{
+		{
+			sslmode: "disable",
+			want:    "user:hunter2@tcp(mysql.example.com:3306)/tekton_results?parseTime=true&charset=utf8mb4",
+		},
+		{
+			sslmode: "require",
+			want:    "user:hunter2@tcp(mysql.example.com:3306)/tekton_results?parseTime=true&tls=skip-verify&charset=utf8mb4",
+		},
+		{
+			sslmode: "verify-full",
+			want:    "user:hunter2@tcp(mysql.example.com:3306)/tekton_results?parseTime=true&tls=true&charset=utf8mb4",
+		},
+	} {
+		t.Run(tc.sslmode, func(t *testing.T) {
+			cfg.DB_SSLMODE = tc.sslmode
+			if got := mysqlDSN(cfg); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	gdb, err := Open(&config.Config{DB_TYPE: SQLite, DB_NAME: filepath.Join(t.TempDir(), "results.db")}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if name := gdb.Dialector.Name(); name != SQLite {
+		t.Errorf("want %s dialector, got %s", SQLite, name)
+	}
+	var fk int
+	if err := gdb.Raw("PRAGMA foreign_keys").Scan(&fk).Error; err != nil || fk != 1 {
+		t.Errorf("foreign keys should be enabled, got %d, %v", fk, err)
+	}
+
+	if _, err := Open(&config.Config{DB_TYPE: "oracle"}, &gorm.Config{}); err == nil {
+		t.Error("Open: want error for unsupported DB_TYPE")
+	}
+}
@@ -17,19 +17,40 @@ package pagination
 import (
 	"encoding/base64"
 	"math"
+	"time"
 
 	pb "github.com/tektoncd/results/pkg/api/server/db/pagination/proto/internal_go_proto"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// EncodeToken encodes a name + filter to an opaque page token
-func EncodeToken(name, filter string) (token string, err error) {
+// Token is the decoded content of an opaque page token. It identifies the
+// position of the last item of the previous page in the sort order of a List
+// query.
+type Token struct {
+	// Name is the ID of the last item of the previous page.
+	Name string
+	// Filter is the filter of the List query.
+	Filter string
+	// OrderBy is the normalized order by clause of the List query.
+	OrderBy string
+	// SortValues are the values of the OrderBy fields for the last item of
+	// the previous page.
+	SortValues []time.Time
+}
+
+// Encode encodes the token to an opaque string.
+func (t *Token) Encode() (string, error) {
 	pi := &pb.ListPageIdentifier{
-		Name:   name,
-		Filter: filter,
+		Name:    t.Name,
+		Filter:  t.Filter,
+		OrderBy: t.OrderBy,
+	}
+	for _, v := range t.SortValues {
+		pi.SortValues = append(pi.SortValues, timestamppb.New(v))
 	}
-	var tokenByte []byte
-	if tokenByte, err = proto.Marshal(pi); err != nil {
+	tokenByte, err := proto.Marshal(pi)
+	if err != nil {
 		return "", err
 	}
 	encodedResult := make([]byte, base64.RawURLEncoding.EncodedLen(len(tokenByte)))
@@ -37,21 +58,46 @@ func EncodeToken(name, filter string) (token string, err error) {
 	return base64.RawURLEncoding.EncodeToString(encodedResult), nil
 }
 
-// DecodeToken decodes an opaque page token into its name and filter parts.
-func DecodeToken(token string) (name, filter string, err error) {
-	var encodedToken []byte
-	if encodedToken, err = base64.RawURLEncoding.DecodeString(token); err != nil {
-		return "", "", err
+// ParseToken decodes an opaque page token.
+func ParseToken(token string) (*Token, error) {
+	encodedToken, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
 	}
 	tokenByte := make([]byte, base64.RawURLEncoding.DecodedLen(len(encodedToken)))
 	if _, err = base64.RawURLEncoding.Decode(tokenByte, encodedToken); err != nil {
-		return "", "", err
+		return nil, err
 	}
 	pi := &pb.ListPageIdentifier{}
 	if err = proto.Unmarshal(tokenByte, pi); err != nil {
+		return nil, err
+	}
+	t := &Token{
+		Name:    pi.GetName(),
+		Filter:  pi.GetFilter(),
+		OrderBy: pi.GetOrderBy(),
+	}
+	for _, v := range pi.GetSortValues() {
+		if err := v.CheckValid(); err != nil {
+			return nil, err
+		}
+		t.SortValues = append(t.SortValues, v.AsTime())
+	}
+	return t, nil
+}
+
+// EncodeToken encodes a name + filter to an opaque page token
+func EncodeToken(name, filter string) (token string, err error) {
+	return (&Token{Name: name, Filter: filter}).Encode()
+}
+
+// DecodeToken decodes an opaque page token into its name and filter parts.
+func DecodeToken(token string) (name, filter string, err error) {
+	t, err := ParseToken(token)
+	if err != nil {
 		return "", "", err
 	}
-	return pi.GetName(), pi.GetFilter(), err
+	return t.Name, t.Filter, nil
 }
 
 // Batcher suggests dynamic batch sizes for list queries.
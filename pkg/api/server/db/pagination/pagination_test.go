@@ -17,6 +17,9 @@ package pagination
 import (
 	"strconv"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestEncodeDecodeToken(t *testing.T) {
@@ -41,6 +44,30 @@ func TestEncodeDecodeToken(t *testing.T) {
 	}
 }
 
+func TestEncodeParseToken(t *testing.T) {
+	want := &Token{
+		Name:       "foo",
+		Filter:     "bar",
+		OrderBy:    "created_time DESC,updated_time",
+		SortValues: []time.Time{time.Unix(1, 2).UTC(), time.Unix(3, 4).UTC()},
+	}
+	token, err := want.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	if _, err := ParseToken("tacocat"); err == nil {
+		t.Error("ParseToken: expected error for malformed token")
+	}
+}
+
 type batchSequence struct {
 	want    int // what number we expect from this call to Next()
 	fetched int // simulated number of returned results to feed into Update().
@@ -0,0 +1,32 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package db
+
+import (
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	sqliteerrors "github.com/tektoncd/results/pkg/api/server/db/errors/sqlite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// openSQLite returns the dialector of the SQLite database file at path.
+// Foreign keys are enforced on every connection, and concurrent writers wait
+// for each other instead of failing immediately.
+func openSQLite(path string) (gorm.Dialector, error) {
+	errors.RegisterErrorSpace(sqliteerrors.Code)
+	return sqlite.Open(path + "?_foreign_keys=on&_busy_timeout=5000"), nil
+}
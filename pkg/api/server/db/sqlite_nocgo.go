@@ -0,0 +1,28 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cgo
+
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// openSQLite fails since the SQLite driver requires cgo.
+func openSQLite(string) (gorm.Dialector, error) {
+	return nil, fmt.Errorf("%s requires the API server to be built with CGO_ENABLED=1", SQLite)
+}
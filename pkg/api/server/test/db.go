@@ -15,50 +15,100 @@
 package test
 
 import (
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
-	"gorm.io/driver/sqlite"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-
-	// Inject sqlite error checking.
-	_ "github.com/tektoncd/results/pkg/api/server/db/errors/sqlite"
 )
 
-// NewDB set up a temporary database for testing
+// NewDB set up a temporary database for testing.
+//
+// By default, the database is a temporary SQLite file. Setting TEST_DB_TYPE
+// to postgres or mysql runs the tests against the database server set by the
+// TEST_DB_HOST, TEST_DB_PORT, TEST_DB_USER, TEST_DB_PASSWORD and TEST_DB_NAME
+// environment variables instead. A new database is created on the server for
+// each test, and dropped once the test completes.
 func NewDB(t *testing.T) *gorm.DB {
 	t.Helper()
 
-	// Create a temporary file
-	tmpfile, err := os.CreateTemp("", "testdb")
-	if err != nil {
-		t.Fatalf("failed to create temp file for db: %v", err)
+	cfg := &config.Config{
+		DB_TYPE:     os.Getenv("TEST_DB_TYPE"),
+		DB_HOST:     os.Getenv("TEST_DB_HOST"),
+		DB_PORT:     os.Getenv("TEST_DB_PORT"),
+		DB_USER:     os.Getenv("TEST_DB_USER"),
+		DB_PASSWORD: os.Getenv("TEST_DB_PASSWORD"),
+		DB_NAME:     os.Getenv("TEST_DB_NAME"),
+		DB_SSLMODE:  "disable",
 	}
-	t.Log("test database: ", tmpfile.Name())
-	t.Cleanup(func() {
-		tmpfile.Close()
-		os.Remove(tmpfile.Name())
-	})
-
-	// Open DB using gorm to use all the nice gorm tools.
-	gdb, err := gorm.Open(sqlite.Open(tmpfile.Name()), &gorm.Config{
+	gormConfig := &gorm.Config{
 		// Configure verbose db logging to use testing logger.
 		// This will show all SQL statements made if the test fails.
 		Logger: logger.New(&testLogger{t: t}, logger.Config{
 			LogLevel: logger.Info,
 			Colorful: true,
 		}),
-	})
+	}
+
+	if cfg.DB_TYPE == "" || cfg.DB_TYPE == db.SQLite {
+		// Create a temporary file
+		tmpfile, err := os.CreateTemp("", "testdb")
+		if err != nil {
+			t.Fatalf("failed to create temp file for db: %v", err)
+		}
+		t.Log("test database: ", tmpfile.Name())
+		t.Cleanup(func() {
+			tmpfile.Close()
+			os.Remove(tmpfile.Name())
+		})
+		cfg.DB_TYPE = db.SQLite
+		cfg.DB_NAME = tmpfile.Name()
+	} else {
+		cfg.DB_NAME = createDatabase(t, cfg, gormConfig)
+	}
+
+	// Open DB using gorm to use all the nice gorm tools.
+	gdb, err := db.Open(cfg, gormConfig)
 	if err != nil {
 		t.Fatalf("failed to open the results.db: %v", err)
 	}
+	t.Cleanup(func() {
+		if sqlDB, err := gdb.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return gdb
+}
 
-	// Enable foreign key support. Only needed for sqlite instance we use for
-	// tests.
-	gdb.Exec("PRAGMA foreign_keys = ON;")
+// createDatabase creates a database for the test on the server and returns
+// its name. The database is dropped once the test completes.
+func createDatabase(t *testing.T, cfg *config.Config, gormConfig *gorm.Config) string {
+	t.Helper()
 
-	return gdb
+	server, err := db.Open(cfg, gormConfig)
+	if err != nil {
+		t.Fatalf("failed to connect to the %s server: %v", cfg.DB_TYPE, err)
+	}
+	name := fmt.Sprintf("results_test_%d", time.Now().UnixNano())
+	if err := server.Exec("CREATE DATABASE " + name).Error; err != nil {
+		t.Fatalf("failed to create database %s: %v", name, err)
+	}
+	t.Log("test database: ", name)
+	// Cleanups run in reverse order, so the database is dropped after the
+	// connections of the test are closed.
+	t.Cleanup(func() {
+		if err := server.Exec("DROP DATABASE " + name).Error; err != nil {
+			t.Errorf("failed to drop database %s: %v", name, err)
+		}
+		if sqlDB, err := server.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return name
 }
 
 type testLogger struct {
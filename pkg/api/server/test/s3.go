@@ -0,0 +1,71 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+)
+
+// NewS3 starts a local stand-in of S3 serving the given objects, by key, to
+// pre-signed GET requests, and configures the S3 logs of cfg to use it.
+// Requests without a pre-signature, or whose pre-signature expired, are
+// denied.
+func NewS3(t *testing.T, cfg *config.Config, objects map[string]string) *httptest.Server {
+	t.Helper()
+
+	if cfg.S3_BUCKET_NAME == "" {
+		cfg.S3_BUCKET_NAME = "test-bucket"
+	}
+	cfg.S3_REGION = "us-east-1"
+	cfg.S3_ACCESS_KEY_ID = "test-access-key"
+	cfg.S3_SECRET_ACCESS_KEY = "test-secret-key"
+	cfg.S3_HOSTNAME_IMMUTABLE = true
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || q.Get("X-Amz-Signature") == "" ||
+			!strings.HasPrefix(q.Get("X-Amz-Credential"), cfg.S3_ACCESS_KEY_ID+"/") {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+			return
+		}
+		signed, err := time.Parse("20060102T150405Z", q.Get("X-Amz-Date"))
+		if err != nil {
+			http.Error(w, "AuthorizationQueryParametersError", http.StatusBadRequest)
+			return
+		}
+		expires, err := strconv.Atoi(q.Get("X-Amz-Expires"))
+		if err != nil || time.Now().After(signed.Add(time.Duration(expires)*time.Second)) {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/"+cfg.S3_BUCKET_NAME+"/")
+		object, ok := objects[key]
+		if !ok || key == r.URL.Path {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(object))
+	}))
+	t.Cleanup(srv.Close)
+	cfg.S3_ENDPOINT = srv.URL
+	return srv
+}
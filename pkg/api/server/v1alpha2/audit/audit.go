@@ -0,0 +1,255 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records who accessed which resources of the API server, and
+// with which outcome, in audit events.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Level is the amount of detail recorded in the audit events of requests.
+type Level string
+
+const (
+	// LevelNone does not record events.
+	LevelNone Level = "None"
+	// LevelMetadata records who made the request, the resource it accessed,
+	// the auth decision and its outcome.
+	LevelMetadata Level = "Metadata"
+	// LevelRequest also records the request message of unary RPCs.
+	LevelRequest Level = "Request"
+)
+
+const (
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+)
+
+var (
+	events = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "results_audit_events_total",
+		Help: "Number of audit events recorded, by level.",
+	}, []string{"level"})
+	eventsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "results_audit_events_dropped_total",
+		Help: "Number of audit events that could not be written to the sink.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(events, eventsDropped)
+}
+
+// Event is the audit record of a request.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Level Level     `json:"level"`
+	// Method is the full gRPC method of the request, e.g.
+	// /tekton.results.v1alpha2.Results/GetResult.
+	Method string `json:"method"`
+	// Name is the name of the resource accessed by the request, or its
+	// parent for list and create requests.
+	Name          string `json:"name,omitempty"`
+	SourceAddress string `json:"sourceAddress,omitempty"`
+	// User is the user authenticated by the auth check of the request, and
+	// ImpersonatedUser the user it impersonates, if any.
+	User             string `json:"user,omitempty"`
+	ImpersonatedUser string `json:"impersonatedUser,omitempty"`
+	// Parent, Resource and Verb are the attributes of the auth check of the
+	// request, and Decision whether it was allowed. They are empty if the
+	// request failed before it was checked.
+	Parent   string `json:"parent,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Verb     string `json:"verb,omitempty"`
+	Decision string `json:"decision,omitempty"`
+	// Code is the gRPC status code the request returned, and DurationMS the
+	// time it took to handle, in milliseconds.
+	Code       string          `json:"code"`
+	DurationMS int64           `json:"durationMs"`
+	Request    json.RawMessage `json:"request,omitempty"`
+
+	mu sync.Mutex
+}
+
+type eventKey struct{}
+
+// NewContext returns a context carrying the audit event of a request.
+func NewContext(ctx context.Context, ev *Event) context.Context {
+	return context.WithValue(ctx, eventKey{}, ev)
+}
+
+// FromContext returns the audit event of the request of the context, or nil
+// if the request is not audited.
+func FromContext(ctx context.Context) *Event {
+	ev, _ := ctx.Value(eventKey{}).(*Event)
+	return ev
+}
+
+// SetUser records the user authenticated by the auth check of the request of
+// the context, and the user it impersonates, if any.
+func SetUser(ctx context.Context, user, impersonatedUser string) {
+	ev := FromContext(ctx)
+	if ev == nil {
+		return
+	}
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	ev.User, ev.ImpersonatedUser = user, impersonatedUser
+}
+
+// RecordCheck records an auth check of the request of the context. The first
+// check of a request is recorded, unless a later check is denied.
+func RecordCheck(ctx context.Context, parent, resource, verb string, allowed bool) {
+	ev := FromContext(ctx)
+	if ev == nil {
+		return
+	}
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	if ev.Decision == "" || (ev.Decision == DecisionAllow && !allowed) {
+		ev.Parent, ev.Resource, ev.Verb = parent, resource, verb
+		ev.Decision = DecisionAllow
+		if !allowed {
+			ev.Decision = DecisionDeny
+		}
+	}
+}
+
+// Auditor writes the audit events of the requests of a gRPC server to a sink,
+// at the levels of its policy.
+type Auditor struct {
+	sink   Sink
+	policy *Policy
+	logger *zap.SugaredLogger
+	// now returns the current time.
+	now func() time.Time
+}
+
+// New returns an Auditor writing events to the sink. A nil policy records all
+// events at LevelMetadata.
+func New(sink Sink, policy *Policy, logger *zap.SugaredLogger) *Auditor {
+	if policy == nil {
+		policy = &Policy{Rules: []*Rule{{Level: LevelMetadata}}}
+	}
+	return &Auditor{sink: sink, policy: policy, logger: logger, now: time.Now}
+}
+
+// UnaryServerInterceptor returns an interceptor recording the audit events of
+// unary RPCs.
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ev := a.newEvent(ctx, info.FullMethod)
+		ev.Name = resourceName(req)
+		resp, err := handler(NewContext(ctx, ev), req)
+		a.finish(ev, req, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor recording the audit events of
+// streaming RPCs. The name of the resource is that of the first message
+// received, and request messages are not recorded.
+func (a *Auditor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ev := a.newEvent(ss.Context(), info.FullMethod)
+		err := handler(srv, &stream{ServerStream: ss, ctx: NewContext(ss.Context(), ev), event: ev})
+		a.finish(ev, nil, err)
+		return err
+	}
+}
+
+func (a *Auditor) newEvent(ctx context.Context, method string) *Event {
+	ev := &Event{Time: a.now(), Method: method}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ev.SourceAddress = p.Addr.String()
+	}
+	return ev
+}
+
+// finish writes the event of a request once it is handled, at the level of
+// the policy.
+func (a *Auditor) finish(ev *Event, req interface{}, err error) {
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	ev.Level = a.policy.Level(ev.Parent, ev.Resource, ev.Verb)
+	if ev.Level == LevelNone {
+		return
+	}
+	ev.Code = status.Code(err).String()
+	ev.DurationMS = a.now().Sub(ev.Time).Milliseconds()
+	if m, ok := req.(proto.Message); ok && ev.Level == LevelRequest {
+		if b, err := protojson.Marshal(m); err == nil {
+			ev.Request = b
+		}
+	}
+	events.WithLabelValues(string(ev.Level)).Inc()
+	if err := a.sink.Write(ev); err != nil {
+		eventsDropped.Inc()
+		a.logger.Errorf("Failed to write audit event of %s: %v", ev.Method, err)
+	}
+}
+
+// stream records the name of the resource of the first message received.
+type stream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	event *Event
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *stream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.event.mu.Lock()
+	defer s.event.mu.Unlock()
+	if s.event.Name == "" {
+		s.event.Name = resourceName(m)
+	}
+	return nil
+}
+
+// resourceName returns the name of the resource of a request, or its parent.
+func resourceName(req interface{}) string {
+	if r, ok := req.(interface{ GetName() string }); ok && r.GetName() != "" {
+		return r.GetName()
+	}
+	if r, ok := req.(interface{ GetRecord() *pb.Record }); ok && r.GetRecord().GetName() != "" {
+		return r.GetRecord().GetName()
+	}
+	if r, ok := req.(interface{ GetResult() *pb.Result }); ok && r.GetResult().GetName() != "" {
+		return r.GetResult().GetName()
+	}
+	if r, ok := req.(interface{ GetParent() string }); ok {
+		return r.GetParent()
+	}
+	return ""
+}
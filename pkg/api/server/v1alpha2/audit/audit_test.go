@@ -0,0 +1,214 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// memorySink keeps the events written.
+type memorySink struct {
+	events []*Event
+}
+
+func (s *memorySink) Write(ev *Event) error {
+	s.events = append(s.events, ev)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+// recvStream is a server stream receiving a single Log message.
+type recvStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	name string
+}
+
+func (s *recvStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	m.(*pb.Log).Name = s.name
+	return nil
+}
+
+func TestAuditor(t *testing.T) {
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	sink := &memorySink{}
+	auditor := New(sink, &Policy{Rules: []*Rule{
+		{Level: LevelNone, Verbs: []string{"get"}, Resources: []string{"records"}},
+		{Level: LevelRequest, Verbs: []string{"delete"}},
+		{Level: LevelMetadata},
+	}}, logger.Get("info"))
+	auditor.now = func() time.Time { return now }
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}})
+	unary := auditor.UnaryServerInterceptor()
+	call := func(method string, req interface{}, handler grpc.UnaryHandler) {
+		t.Helper()
+		if _, err := unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil && status.Code(err) == codes.Unknown {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+
+	// Allowed, and impersonated, requests.
+	call("/tekton.results.v1alpha2.Results/GetResult", &pb.GetResultRequest{Name: "foo/results/bar"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		SetUser(ctx, "alice", "bob")
+		RecordCheck(ctx, "foo", "results", "get", true)
+		now = now.Add(5 * time.Millisecond)
+		return &pb.Result{}, nil
+	})
+	// Denied requests, recording their request message.
+	call("/tekton.results.v1alpha2.Results/DeleteRecord", &pb.DeleteRecordRequest{Name: "foo/results/bar/records/baz"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		SetUser(ctx, "alice", "")
+		RecordCheck(ctx, "foo", "records", "delete", false)
+		return nil, status.Error(codes.Unauthenticated, "permission denied")
+	})
+	// Requests of level None.
+	call("/tekton.results.v1alpha2.Results/GetRecord", &pb.GetRecordRequest{Name: "foo/results/bar/records/baz"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		RecordCheck(ctx, "foo", "records", "get", true)
+		return &pb.Record{}, nil
+	})
+	// Requests failing before they are checked, named by their parent. Later
+	// denied checks take precedence.
+	call("/tekton.results.v1alpha2.Results/CreateResult", &pb.CreateResultRequest{Parent: "foo"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "invalid name")
+	})
+	call("/tekton.results.v1alpha2.Results/UpdateRecord", &pb.UpdateRecordRequest{Record: &pb.Record{Name: "foo/results/bar/records/baz"}}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		RecordCheck(ctx, "foo", "records", "update", true)
+		RecordCheck(ctx, "foo", "results", "update", false)
+		RecordCheck(ctx, "foo", "records", "get", true)
+		return nil, status.Error(codes.Unauthenticated, "permission denied")
+	})
+
+	// Streams are named by their first message.
+	stream := auditor.StreamServerInterceptor()
+	if err := stream(nil, &recvStream{ctx: ctx, name: "foo/results/bar/logs/baz"}, &grpc.StreamServerInfo{FullMethod: "/tekton.results.v1alpha2.Logs/UpdateLog"}, func(_ interface{}, ss grpc.ServerStream) error {
+		if err := ss.RecvMsg(&pb.Log{}); err != nil {
+			return err
+		}
+		SetUser(ss.Context(), "watcher", "")
+		RecordCheck(ss.Context(), "foo", "logs", "update", true)
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateLog: %v", err)
+	}
+
+	start := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	want := []*Event{
+		{
+			Time:             start,
+			Level:            LevelMetadata,
+			Method:           "/tekton.results.v1alpha2.Results/GetResult",
+			Name:             "foo/results/bar",
+			SourceAddress:    "10.0.0.1:1234",
+			User:             "alice",
+			ImpersonatedUser: "bob",
+			Parent:           "foo",
+			Resource:         "results",
+			Verb:             "get",
+			Decision:         DecisionAllow,
+			Code:             "OK",
+			DurationMS:       5,
+		},
+		{
+			Time:          start.Add(5 * time.Millisecond),
+			Level:         LevelRequest,
+			Method:        "/tekton.results.v1alpha2.Results/DeleteRecord",
+			Name:          "foo/results/bar/records/baz",
+			SourceAddress: "10.0.0.1:1234",
+			User:          "alice",
+			Parent:        "foo",
+			Resource:      "records",
+			Verb:          "delete",
+			Decision:      DecisionDeny,
+			Code:          "Unauthenticated",
+			Request:       json.RawMessage(`{"name":"foo/results/bar/records/baz"}`),
+		},
+		{
+			Time:          start.Add(5 * time.Millisecond),
+			Level:         LevelMetadata,
+			Method:        "/tekton.results.v1alpha2.Results/CreateResult",
+			Name:          "foo",
+			SourceAddress: "10.0.0.1:1234",
+			Code:          "InvalidArgument",
+		},
+		{
+			Time:          start.Add(5 * time.Millisecond),
+			Level:         LevelMetadata,
+			Method:        "/tekton.results.v1alpha2.Results/UpdateRecord",
+			Name:          "foo/results/bar/records/baz",
+			SourceAddress: "10.0.0.1:1234",
+			Parent:        "foo",
+			Resource:      "results",
+			Verb:          "update",
+			Decision:      DecisionDeny,
+			Code:          "Unauthenticated",
+		},
+		{
+			Time:          start.Add(5 * time.Millisecond),
+			Level:         LevelMetadata,
+			Method:        "/tekton.results.v1alpha2.Logs/UpdateLog",
+			Name:          "foo/results/bar/logs/baz",
+			SourceAddress: "10.0.0.1:1234",
+			User:          "watcher",
+			Parent:        "foo",
+			Resource:      "logs",
+			Verb:          "update",
+			Decision:      DecisionAllow,
+			Code:          "OK",
+		},
+	}
+	if diff := cmp.Diff(want, sink.events, cmpopts.IgnoreUnexported(Event{}), cmp.Comparer(func(a, b json.RawMessage) bool {
+		return string(a) == string(b)
+	})); diff != "" {
+		t.Errorf("events (-want, +got):\n%s", diff)
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	for _, tc := range []struct {
+		req  interface{}
+		want string
+	}{
+		{req: &pb.GetRecordRequest{Name: "foo/results/bar/records/baz"}, want: "foo/results/bar/records/baz"},
+		{req: &pb.CreateResultRequest{Parent: "foo", Result: &pb.Result{Name: "foo/results/bar"}}, want: "foo/results/bar"},
+		{req: &pb.CreateRecordRequest{Parent: "foo/results/bar"}, want: "foo/results/bar"},
+		{req: &pb.UpdateRecordRequest{Record: &pb.Record{Name: "foo/results/bar/records/baz"}}, want: "foo/results/bar/records/baz"},
+		{req: &pb.ListResultsRequest{Parent: "-"}, want: "-"},
+		{req: nil, want: ""},
+	} {
+		if got := resourceName(tc.req); got != tc.want {
+			t.Errorf("resourceName(%v): %q, want %q", tc.req, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Rule sets the level of the events of the requests it matches.
+type Rule struct {
+	Level Level `json:"level"`
+	// Verbs, Resources and Parents of the auth checks of the requests the
+	// rule applies to. If empty, the rule applies to all of them, including
+	// requests that failed before they were checked.
+	Verbs     []string `json:"verbs,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+	Parents   []string `json:"parents,omitempty"`
+}
+
+func (r *Rule) matches(parent, resource, verb string) bool {
+	return matches(r.Parents, parent) && matches(r.Resources, resource) && matches(r.Verbs, verb)
+}
+
+func matches(values []string, v string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy is a list of rules. The level of an event is that of the first rule
+// matching its request, or LevelNone if none does.
+type Policy struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Validate checks that the rules of the policy have a known level.
+func (p *Policy) Validate() error {
+	for i, r := range p.Rules {
+		switch r.Level {
+		case LevelNone, LevelMetadata, LevelRequest:
+		default:
+			return fmt.Errorf("rule %d: unknown level %q", i, r.Level)
+		}
+	}
+	return nil
+}
+
+// Level returns the level of the events of the requests with the given auth
+// check attributes.
+func (p *Policy) Level(parent, resource, verb string) Level {
+	for _, r := range p.Rules {
+		if r.matches(parent, resource, verb) {
+			return r.Level
+		}
+	}
+	return LevelNone
+}
+
+// LoadPolicy reads a policy from a YAML or JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("error parsing audit policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid audit policy %s: %w", path, err)
+	}
+	return p, nil
+}
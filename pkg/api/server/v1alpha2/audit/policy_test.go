@@ -0,0 +1,70 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte(`
+rules:
+  - level: None
+    verbs: ["get", "list", "watch"]
+    resources: ["results", "records"]
+  - level: Request
+    verbs: ["delete"]
+    parents: ["prod"]
+  - level: Metadata
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy(valid)
+	if err != nil {
+		t.Fatalf("LoadPolicy: %v", err)
+	}
+	for _, tc := range []struct {
+		parent, resource, verb string
+		want                   Level
+	}{
+		{parent: "prod", resource: "records", verb: "list", want: LevelNone},
+		{parent: "prod", resource: "logs", verb: "get", want: LevelMetadata},
+		{parent: "prod", resource: "results", verb: "delete", want: LevelRequest},
+		{parent: "dev", resource: "results", verb: "delete", want: LevelMetadata},
+		// Requests failing before they are checked only match rules of all
+		// verbs.
+		{want: LevelMetadata},
+	} {
+		if got := p.Level(tc.parent, tc.resource, tc.verb); got != tc.want {
+			t.Errorf("Level(%q, %q, %q): %s, want %s", tc.parent, tc.resource, tc.verb, got, tc.want)
+		}
+	}
+	if got := (&Policy{}).Level("prod", "results", "get"); got != LevelNone {
+		t.Errorf("Level of empty policy: %s, want %s", got, LevelNone)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte(`rules: [{level: RequestResponse}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPolicy(invalid); err == nil || !strings.Contains(err.Error(), `unknown level "RequestResponse"`) {
+		t.Errorf("LoadPolicy: %v, want unknown level error", err)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookQueueSize is the number of events queued to be sent to a
+	// webhook. Events are dropped once the queue is full.
+	webhookQueueSize = 10000
+	// webhookBatchSize is the maximum number of events sent in a request to
+	// a webhook, and webhookBatchInterval the maximum time events are queued
+	// before they are sent.
+	webhookBatchSize     = 100
+	webhookBatchInterval = time.Second
+)
+
+var errSinkClosed = errors.New("audit sink closed")
+
+// Sink writes audit events.
+type Sink interface {
+	Write(ev *Event) error
+	// Close writes the pending events, and closes the sink.
+	Close() error
+}
+
+// NewSink returns the sink of the target: `stdout`, the http(s) URL of a
+// webhook, or the path of a file events are appended to.
+func NewSink(target string, logger *zap.SugaredLogger) (Sink, error) {
+	switch {
+	case target == "stdout":
+		return NewJSONLinesSink(os.Stdout), nil
+	case strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://"):
+		return NewWebhookSink(target, &http.Client{Timeout: 10 * time.Second}, logger), nil
+	default:
+		f, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		return NewJSONLinesSink(f), nil
+	}
+}
+
+// JSONLinesSink writes events to a writer, one JSON object per line.
+type JSONLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesSink returns a sink writing to w, which is closed with the sink
+// if it is an io.Closer other than stdout.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: w}
+}
+
+func (s *JSONLinesSink) Write(ev *Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+func (s *JSONLinesSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.w.(io.Closer); ok && s.w != os.Stdout {
+		return c.Close()
+	}
+	return nil
+}
+
+// WebhookSink posts events to a webhook in batches, as JSON lines. Events are
+// queued, so that requests do not wait for the webhook.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	logger *zap.SugaredLogger
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *Event
+	done   chan struct{}
+}
+
+// NewWebhookSink returns a sink posting events to the URL.
+func NewWebhookSink(url string, client *http.Client, logger *zap.SugaredLogger) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		client: client,
+		logger: logger,
+		queue:  make(chan *Event, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *WebhookSink) Write(ev *Event) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errSinkClosed
+	}
+	select {
+	case s.queue <- ev:
+		return nil
+	default:
+		return errors.New("audit webhook queue is full")
+	}
+}
+
+func (s *WebhookSink) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+	<-s.done
+	return nil
+}
+
+// run sends the queued events until the sink is closed.
+func (s *WebhookSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(webhookBatchInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Event, 0, webhookBatchSize)
+	send := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.send(batch); err != nil {
+			eventsDropped.Add(float64(len(batch)))
+			s.logger.Errorf("Failed to send %d audit events: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case ev, ok := <-s.queue:
+			if !ok {
+				send()
+				return
+			}
+			batch = append(batch, ev)
+			if len(batch) == webhookBatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		}
+	}
+}
+
+func (s *WebhookSink) send(batch []*Event) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, ev := range batch {
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+	resp, err := s.client.Post(s.url, "application/x-ndjson", &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/tektoncd/results/pkg/api/server/logger"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	// Events are appended to the file, across restarts.
+	for _, name := range []string{"foo/results/a", "foo/results/b"} {
+		sink, err := NewSink(path, logger.Get("info"))
+		if err != nil {
+			t.Fatalf("NewSink: %v", err)
+		}
+		if err := sink.Write(&Event{Method: "/tekton.results.v1alpha2.Results/GetResult", Name: name}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ev := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), ev); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		names = append(names, ev.Name)
+	}
+	if len(names) != 2 || names[0] != "foo/results/a" || names[1] != "foo/results/b" {
+		t.Errorf("got events of %v, want foo/results/a and foo/results/b", names)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		names    []string
+		requests int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-ndjson" {
+			http.Error(w, "unexpected content type", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		dec := json.NewDecoder(r.Body)
+		for dec.More() {
+			ev := &Event{}
+			if err := dec.Decode(ev); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			names = append(names, ev.Name)
+		}
+	}))
+	defer srv.Close()
+
+	sink, err := NewSink(srv.URL, logger.Get("info"))
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	n := webhookBatchSize + 1
+	for i := 0; i < n; i++ {
+		if err := sink.Write(&Event{Name: "foo/results/bar"}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	// Closing the sink sends the pending events.
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := sink.Write(&Event{}); err != errSinkClosed {
+		t.Errorf("Write after Close: %v, want %v", err, errSinkClosed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(names) != n {
+		t.Errorf("webhook received %d events, want %d", len(names), n)
+	}
+	if requests < 2 {
+		t.Errorf("webhook received %d requests, want batches of at most %d events", requests, webhookBatchSize)
+	}
+}
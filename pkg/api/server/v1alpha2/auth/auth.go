@@ -14,18 +14,25 @@
 
 package auth
 
-import "context"
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 const (
 	ResourceResults = "results"
 	ResourceRecords = "records"
 	ResourceLogs    = "logs"
 
-	PermissionCreate = "create"
-	PermissionGet    = "get"
-	PermissionList   = "list"
-	PermissionDelete = "delete"
-	PermissionUpdate = "update"
+	PermissionCreate   = "create"
+	PermissionGet      = "get"
+	PermissionList     = "list"
+	PermissionDelete   = "delete"
+	PermissionUpdate   = "update"
+	PermissionWatch    = "watch"
+	PermissionUndelete = "undelete"
 )
 
 // Checker handles authentication and authorization checks for an action on
@@ -33,3 +40,18 @@ const (
 type Checker interface {
 	Check(ctx context.Context, parent, resource, verb string) error
 }
+
+// Any is an auth check that allows the requests that any of its checks
+// allows, e.g. to accept both the tokens of Kubernetes and of an OIDC issuer.
+// The checks are run in order, until one allows the request.
+type Any []Checker
+
+func (a Any) Check(ctx context.Context, parent, resource, verb string) error {
+	err := status.Error(codes.Unauthenticated, "permission denied")
+	for _, c := range a {
+		if err = c.Check(ctx, parent, resource, verb); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authzclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// User is an authenticated user.
+type User struct {
+	Name   string
+	UID    string
+	Groups []string
+	Extra  map[string][]string
+}
+
+// Authorizer decides whether an authenticated user may perform the verb on
+// the resource in the namespace. The namespace is empty for all namespaces.
+type Authorizer interface {
+	Authorize(ctx context.Context, user *User, namespace, resource, verb string) (bool, error)
+}
+
+// SubjectAccessReview authorizes users with Kubernetes SubjectAccessReviews,
+// checking their RBAC permissions in the `results.tekton.dev` group.
+type SubjectAccessReview struct {
+	authz authzclient.AuthorizationV1Interface
+}
+
+// NewSubjectAccessReview returns an Authorizer creating SubjectAccessReviews
+// with the client.
+func NewSubjectAccessReview(authz authzclient.AuthorizationV1Interface) *SubjectAccessReview {
+	return &SubjectAccessReview{authz: authz}
+}
+
+func (s *SubjectAccessReview) Authorize(ctx context.Context, user *User, namespace, resource, verb string) (bool, error) {
+	sar, err := s.authz.SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   user.Name,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  convertExtra(user.Extra),
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace: namespace,
+				Group:     "results.tekton.dev",
+				Resource:  resource,
+				Verb:      verb,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/clock"
+)
+
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "results_auth_cache_requests_total",
+	Help: "Number of lookups of auth decisions in the cache, by cache and result (hit or miss).",
+}, []string{"cache", "result"})
+
+func init() {
+	prometheus.MustRegister(cacheRequests)
+}
+
+// decisionCache is a bounded cache of auth decisions. Positive decisions
+// expire after ttl, and negative ones after negativeTTL, so that granted
+// permissions are picked up quickly. A nil decisionCache caches nothing.
+type decisionCache struct {
+	name        string
+	cache       *cache.LRUExpireCache
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+func newDecisionCache(name string, size int, ttl, negativeTTL time.Duration, clock clock.PassiveClock) *decisionCache {
+	return &decisionCache{
+		name:        name,
+		cache:       cache.NewLRUExpireCacheWithClock(size, clock),
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+func (c *decisionCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	v, ok := c.cache.Get(key)
+	if ok {
+		cacheRequests.WithLabelValues(c.name, "hit").Inc()
+	} else {
+		cacheRequests.WithLabelValues(c.name, "miss").Inc()
+	}
+	return v, ok
+}
+
+func (c *decisionCache) add(key string, value interface{}, positive bool) {
+	if c == nil {
+		return
+	}
+	ttl := c.ttl
+	if !positive {
+		ttl = c.negativeTTL
+	}
+	if ttl > 0 {
+		c.cache.Add(key, value, ttl)
+	}
+}
+
+// tokenKey returns the key of a token in the authentication cache. Tokens are
+// hashed, so that they are not kept in memory.
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// authorizationKey returns the key of an authorization decision in the cache.
+func authorizationKey(user *User, namespace, resource, verb string) string {
+	// Marshaling a struct of the attributes keeps them apart, whatever
+	// characters they contain. Maps are marshaled in the order of their keys.
+	key, _ := json.Marshal(struct {
+		User      *User
+		Namespace string
+		Resource  string
+		Verb      string
+	}{user, namespace, resource, verb})
+	return string(key)
+}
+
+// cachedAuthorizer caches the decisions of an Authorizer. Errors are not
+// cached.
+type cachedAuthorizer struct {
+	authorizer Authorizer
+	cache      *decisionCache
+}
+
+// NewCachedAuthorizer returns an Authorizer caching the decisions of a, up to
+// size entries. Positive decisions expire after ttl, and negative ones after
+// negativeTTL. a is returned as is if size or both TTLs are 0.
+func NewCachedAuthorizer(a Authorizer, size int, ttl, negativeTTL time.Duration) Authorizer {
+	if size <= 0 || (ttl <= 0 && negativeTTL <= 0) {
+		return a
+	}
+	return &cachedAuthorizer{
+		authorizer: a,
+		cache:      newDecisionCache("authorization", size, ttl, negativeTTL, clock.RealClock{}),
+	}
+}
+
+func (c *cachedAuthorizer) Authorize(ctx context.Context, user *User, namespace, resource, verb string) (bool, error) {
+	key := authorizationKey(user, namespace, resource, verb)
+	if allowed, ok := c.cache.get(key); ok {
+		return allowed.(bool), nil
+	}
+	allowed, err := c.authorizer.Authorize(ctx, user, namespace, resource, verb)
+	if err != nil {
+		return false, err
+	}
+	c.cache.add(key, allowed, allowed)
+	return allowed, nil
+}
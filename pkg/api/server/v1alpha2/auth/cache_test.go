@@ -0,0 +1,99 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestDecisionCache(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	c := newDecisionCache("test", 2, time.Minute, 10*time.Second, clock)
+
+	c.add("allowed", true, true)
+	c.add("denied", false, false)
+	clock.SetTime(clock.Now().Add(30 * time.Second))
+	if _, ok := c.get("allowed"); !ok {
+		t.Error("positive decision expired before its TTL")
+	}
+	if _, ok := c.get("denied"); ok {
+		t.Error("negative decision not expired after its TTL")
+	}
+
+	// The least recently used entries are evicted past the size of the cache.
+	c.add("a", true, true)
+	c.add("b", true, true)
+	if _, ok := c.get("allowed"); ok {
+		t.Error("decision not evicted")
+	}
+
+	// A nil cache caches nothing.
+	var nilCache *decisionCache
+	nilCache.add("allowed", true, true)
+	if _, ok := nilCache.get("allowed"); ok {
+		t.Error("nil cache returned a decision")
+	}
+}
+
+func TestAuthorizationKey(t *testing.T) {
+	user := &User{Name: "alice", Groups: []string{"a", "b"}}
+	key := authorizationKey(user, "foo", "results", "get")
+	for _, other := range []string{
+		authorizationKey(&User{Name: "alice", Groups: []string{"a"}}, "foo", "results", "get"),
+		authorizationKey(&User{Name: "alice", Groups: []string{"a,b"}}, "foo", "results", "get"),
+		authorizationKey(user, "", "results", "get"),
+		authorizationKey(user, "foo", "records", "get"),
+		authorizationKey(user, "foo", "results", "list"),
+	} {
+		if other == key {
+			t.Errorf("authorizationKey: %s is the key of other attributes", key)
+		}
+	}
+}
+
+// countingAuthorizer allows alice, and counts its authorizations.
+type countingAuthorizer struct {
+	n int
+}
+
+func (a *countingAuthorizer) Authorize(_ context.Context, user *User, _, _, _ string) (bool, error) {
+	a.n++
+	return user.Name == "alice", nil
+}
+
+func TestCachedAuthorizer(t *testing.T) {
+	counting := &countingAuthorizer{}
+	a := NewCachedAuthorizer(counting, 10, time.Minute, time.Minute)
+	for _, user := range []*User{{Name: "alice"}, {Name: "bob"}, {Name: "alice"}, {Name: "bob"}} {
+		allowed, err := a.Authorize(context.Background(), user, "foo", ResourceResults, PermissionGet)
+		if err != nil {
+			t.Fatalf("Authorize: %v", err)
+		}
+		if allowed != (user.Name == "alice") {
+			t.Errorf("Authorize(%s): %t", user.Name, allowed)
+		}
+	}
+	if counting.n != 2 {
+		t.Errorf("got %d authorizations, want 2", counting.n)
+	}
+
+	if NewCachedAuthorizer(counting, 0, time.Minute, time.Minute) != Authorizer(counting) {
+		t.Error("NewCachedAuthorizer: want the authorizer for a disabled cache")
+	}
+}
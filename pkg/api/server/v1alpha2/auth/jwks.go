@@ -0,0 +1,193 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	// jwksMaxAge is the age after which the keys are loaded again, so that
+	// revoked keys are dropped.
+	jwksMaxAge = time.Hour
+	// jwksMinRefreshInterval is the minimum interval between two loads of
+	// the keys when a token is signed with an unknown key, e.g. once keys
+	// are rotated.
+	jwksMinRefreshInterval = time.Minute
+)
+
+// jwks is a JSON Web Key Set loaded from a file, or from an http(s) URL.
+type jwks struct {
+	source string
+	client *http.Client
+	// now returns the current time.
+	now func() time.Time
+
+	// loads runs one load of the keys at a time, outside of mu, so that
+	// requests are not blocked while the keys are loaded.
+	loads singleflight.Group
+	// refreshes tracks the loads of the keys past their max age, which are
+	// run in the background.
+	refreshes sync.WaitGroup
+
+	mu   sync.Mutex
+	keys *jose.JSONWebKeySet
+	// loadTime is the time the keys were loaded at, and attemptTime the
+	// time they were last loaded, or failed to.
+	loadTime    time.Time
+	attemptTime time.Time
+}
+
+func newJWKS(source string, client *http.Client) *jwks {
+	return &jwks{source: source, client: client, now: time.Now}
+}
+
+// key returns the public key of the given ID. Without an ID, the key set must
+// have a single key.
+func (k *jwks) key(ctx context.Context, kid string) (interface{}, error) {
+	now := k.now()
+	k.mu.Lock()
+	keys := k.keys
+	// The keys are loaded at most once per jwksMinRefreshInterval, and the
+	// keys loaded before are kept if they cannot be loaded again.
+	canLoad := now.Sub(k.attemptTime) > jwksMinRefreshInterval
+	stale := now.Sub(k.loadTime) > jwksMaxAge
+	k.mu.Unlock()
+
+	switch {
+	case keys == nil:
+		var err error
+		if keys, err = k.load(ctx); err != nil {
+			return nil, err
+		}
+		canLoad = false
+	case stale && canLoad:
+		// The keys are refreshed in the background, the current keys are
+		// used meanwhile.
+		k.refreshes.Add(1)
+		go func() {
+			defer k.refreshes.Done()
+			if _, err := k.load(context.Background()); err != nil {
+				log.Println(err)
+			}
+		}()
+		canLoad = false
+	}
+	key, ok := find(keys, kid)
+	if !ok && canLoad {
+		var err error
+		if keys, err = k.load(ctx); err != nil {
+			return nil, err
+		}
+		key, ok = find(keys, kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("no key %q in JWKS %s", kid, k.source)
+	}
+	return key, nil
+}
+
+func find(keys *jose.JSONWebKeySet, kid string) (interface{}, bool) {
+	if kid == "" {
+		if len(keys.Keys) != 1 {
+			return nil, false
+		}
+		return keys.Keys[0].Key, true
+	}
+	for _, key := range keys.Key(kid) {
+		// Skip encryption keys.
+		if key.Use == "" || key.Use == "sig" {
+			return key.Key, true
+		}
+	}
+	return nil, false
+}
+
+// load loads the keys from their source, or waits for the load in progress,
+// and returns them.
+func (k *jwks) load(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	ch := k.loads.DoChan("", func() (interface{}, error) {
+		// The load is shared by the callers, so it is not canceled with the
+		// context of the first one. The client has a timeout.
+		return k.loadKeys(context.Background())
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*jose.JSONWebKeySet), nil
+	}
+}
+
+func (k *jwks) loadKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	attemptTime := k.now()
+	k.mu.Lock()
+	k.attemptTime = attemptTime
+	k.mu.Unlock()
+
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasPrefix(k.source, "https://") || strings.HasPrefix(k.source, "http://") {
+		data, err = k.fetch(ctx)
+	} else {
+		data, err = os.ReadFile(k.source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWKS %s: %w", k.source, err)
+	}
+	keys := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(data, keys); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS %s: %w", k.source, err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.loadTime = attemptTime
+	return keys, nil
+}
+
+func (k *jwks) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
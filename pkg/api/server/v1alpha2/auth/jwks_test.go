@@ -0,0 +1,105 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestJWKS(t *testing.T) {
+	var (
+		keys     []jose.JSONWebKey
+		requests int32
+		fail     bool
+		// block blocks the requests until it is closed, if set.
+		block chan struct{}
+	)
+	addKey := func(kid string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Use: "sig"})
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if block != nil {
+			<-block
+		}
+		if fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: keys})
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	k := newJWKS(srv.URL, srv.Client())
+	k.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	addKey("key-1")
+	if _, err := k.key(ctx, "key-1"); err != nil {
+		t.Fatalf("key-1: %v", err)
+	}
+	// A key without ID is only found in a set of one key.
+	if _, err := k.key(ctx, ""); err != nil {
+		t.Fatalf("no key ID: %v", err)
+	}
+
+	// Rotated keys are not loaded again within the min refresh interval.
+	addKey("key-2")
+	if _, err := k.key(ctx, "key-2"); err == nil {
+		t.Error("key-2 found before the min refresh interval")
+	}
+	now = now.Add(2 * jwksMinRefreshInterval)
+	if _, err := k.key(ctx, "key-2"); err != nil {
+		t.Errorf("key-2: %v", err)
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+
+	// Keys past their max age are kept if they cannot be loaded again, and
+	// used while they are loaded in the background.
+	fail = true
+	block = make(chan struct{})
+	now = now.Add(2 * jwksMaxAge)
+	for i := 0; i < 2; i++ {
+		if _, err := k.key(ctx, "key-1"); err != nil {
+			t.Errorf("key-1 while loading: %v", err)
+		}
+	}
+	close(block)
+	k.refreshes.Wait()
+	if _, err := k.key(ctx, "key-1"); err != nil {
+		t.Errorf("key-1 after failed load: %v", err)
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// oidcSigningMethods are the algorithms of the JWTs accepted by OIDC. Tokens
+// signed with symmetric keys, or unsigned, are rejected.
+var oidcSigningMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+// OIDC is an auth checker for clients outside of the cluster, authenticating
+// OIDC ID tokens, or other JWTs, issued by a configured issuer. Tokens are
+// verified with the JSON Web Key Set of the issuer, and their claims mapped to
+// a user, which is then authorized by an Authorizer.
+// Users should pass in `token` metadata through the gRPC context.
+type OIDC struct {
+	issuer         string
+	audience       string
+	usernameClaim  string
+	usernamePrefix string
+	groupsClaim    string
+	groupsPrefix   string
+	keys           *jwks
+	authorizer     Authorizer
+}
+
+type OIDCOption func(*OIDC)
+
+// NewOIDC returns a checker of the JWTs of the issuer, verified with the JSON
+// Web Key Set of the given file or http(s) URL.
+func NewOIDC(issuer, jwksSource string, authorizer Authorizer, options ...OIDCOption) (*OIDC, error) {
+	if issuer == "" || jwksSource == "" {
+		return nil, errors.New("OIDC issuer and JWKS must be set")
+	}
+	o := &OIDC{
+		issuer:        issuer,
+		usernameClaim: "sub",
+		groupsClaim:   "groups",
+		keys:          newJWKS(jwksSource, &http.Client{Timeout: 10 * time.Second}),
+		authorizer:    authorizer,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	return o, nil
+}
+
+func (o *OIDC) Check(ctx context.Context, namespace, resource, verb string) error {
+	users, err := o.users(ctx)
+	if err != nil {
+		return err
+	}
+
+	if verb == PermissionList && namespace == "-" {
+		// In list operations `-` means that the caller wants to list
+		// resources across all parents.
+		namespace = corev1.NamespaceAll
+	}
+
+	for _, user := range users {
+		allowed, err := o.authorizer.Authorize(ctx, user, namespace, resource, verb)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if allowed {
+			return nil
+		}
+	}
+	// Return Unauthenticated - we don't know if we failed because of invalid
+	// token or unauthorized user, so this is safer to not leak any state.
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+// FilterParents authorizes the caller on each of the candidates, unless it is
+// allowed on all namespaces.
+func (o *OIDC) FilterParents(ctx context.Context, resource, verb string, candidates Candidates) ([]string, bool, error) {
+	users, err := o.users(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return filterParents(ctx, users, resource, verb, candidates, o.authorizer.Authorize)
+}
+
+// users returns the users of the verified tokens of the request.
+func (o *OIDC) users(ctx context.Context) ([]*User, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unable to get context metadata")
+	}
+	v := md.Get("authorization")
+	if len(v) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "unable to find token")
+	}
+
+	var users []*User
+	for _, raw := range v {
+		// We expect tokens to be in the form "Bearer <token>". Parse the token out.
+		s := strings.SplitN(raw, " ", 2)
+		if len(s) < 2 {
+			log.Println("unknown auth token format")
+			continue
+		}
+		user, err := o.Authenticate(ctx, s[1])
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		audit.SetUser(ctx, user.Name, "")
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// Authenticate verifies the token, and returns the user of its claims.
+func (o *OIDC) Authenticate(ctx context.Context, token string) (*User, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(oidcSigningMethods))
+	if _, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return o.keys.key(ctx, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	// The signature, and the times of the token if set, are verified when it
+	// is parsed.
+	if !claims.VerifyIssuer(o.issuer, true) {
+		return nil, fmt.Errorf("invalid token: issuer is not %s", o.issuer)
+	}
+	if o.audience != "" && !claims.VerifyAudience(o.audience, true) {
+		return nil, fmt.Errorf("invalid token: audience is not %s", o.audience)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.New("invalid token: no expiry")
+	}
+
+	name, ok := claims[o.usernameClaim].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid token: no %s claim", o.usernameClaim)
+	}
+	user := &User{Name: o.usernamePrefix + name}
+	switch groups := claims[o.groupsClaim].(type) {
+	case string:
+		user.Groups = []string{o.groupsPrefix + groups}
+	case []interface{}:
+		for _, g := range groups {
+			if g, ok := g.(string); ok {
+				user.Groups = append(user.Groups, o.groupsPrefix+g)
+			}
+		}
+	}
+	return user, nil
+}
+
+// WithAudience is an option function to only accept the tokens issued for
+// the audience, e.g. the client ID of the API server.
+func WithAudience(audience string) OIDCOption {
+	return func(o *OIDC) {
+		o.audience = audience
+	}
+}
+
+// WithUsernameClaim is an option function to map the claim to the name of
+// users, instead of `sub`. The prefix is prepended to the names, e.g. to tell
+// them apart from the names of Kubernetes users.
+func WithUsernameClaim(claim, prefix string) OIDCOption {
+	return func(o *OIDC) {
+		if claim != "" {
+			o.usernameClaim = claim
+		}
+		o.usernamePrefix = prefix
+	}
+}
+
+// WithGroupsClaim is an option function to map the claim to the groups of
+// users, instead of `groups`. The prefix is prepended to the groups.
+func WithGroupsClaim(claim, prefix string) OIDCOption {
+	return func(o *OIDC) {
+		if claim != "" {
+			o.groupsClaim = claim
+		}
+		o.groupsPrefix = prefix
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	server "github.com/tektoncd/results/pkg/api/server/v1alpha2"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	testclient "github.com/tektoncd/results/pkg/internal/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	test "k8s.io/client-go/testing"
+	"k8s.io/utils/strings/slices"
+)
+
+const issuer = "https://issuer.example.com"
+
+// newKey returns a signing key, and writes a JWKS file with its public key.
+func newKey(t *testing.T, kid string) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &key.PublicKey, KeyID: kid, Algorithm: "ES256", Use: "sig"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return key, path
+}
+
+func sign(t *testing.T, key interface{}, method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestOIDC(t *testing.T) {
+	key, jwksPath := newKey(t, "key-1")
+	otherKey, _ := newKey(t, "key-1")
+
+	k8s := fake.NewSimpleClientset()
+	k8s.PrependReactor("create", "tokenreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		tr := action.(test.CreateActionImpl).Object.(*authnv1.TokenReview)
+		tr.Status = authnv1.TokenReviewStatus{
+			Authenticated: tr.Spec.Token == "service-account",
+			User:          authnv1.UserInfo{Username: "system:serviceaccount:foo:watcher"},
+		}
+		return true, tr, nil
+	})
+	k8s.PrependReactor("create", "subjectaccessreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		sar := action.(test.CreateActionImpl).Object.(*authzv1.SubjectAccessReview)
+		sar.Status = authzv1.SubjectAccessReviewStatus{
+			Allowed: sar.Spec.User == "oidc:alice" || sar.Spec.User == "system:serviceaccount:foo:watcher" ||
+				slices.Contains(sar.Spec.Groups, "oidc:admins"),
+		}
+		return true, sar, nil
+	})
+	oidc, err := auth.NewOIDC(issuer, jwksPath, auth.NewSubjectAccessReview(k8s.AuthorizationV1()),
+		auth.WithAudience("tekton-results"),
+		auth.WithUsernameClaim("email", "oidc:"),
+		auth.WithGroupsClaim("", "oidc:"),
+	)
+	if err != nil {
+		t.Fatalf("NewOIDC: %v", err)
+	}
+	resultsClient, _ := testclient.NewResultsClient(t, &config.Config{}, server.WithAuth(auth.Any{oidc, auth.NewRBAC(k8s)}))
+
+	claims := func(user string, edit func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":   issuer,
+			"aud":   "tekton-results",
+			"sub":   "1234",
+			"email": user,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+		if edit != nil {
+			edit(c)
+		}
+		return c
+	}
+	for _, tc := range []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{
+		{
+			name:  "authorized user",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("alice", nil)),
+			want:  codes.OK,
+		},
+		{
+			name: "authorized group",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("bob", func(c jwt.MapClaims) {
+				c["groups"] = []string{"devs", "admins"}
+			})),
+			want: codes.OK,
+		},
+		{
+			name:  "unauthorized user",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("bob", nil)),
+			want:  codes.Unauthenticated,
+		},
+		{
+			name:  "service account",
+			token: "service-account",
+			want:  codes.OK,
+		},
+		{
+			name:  "unknown key",
+			token: sign(t, otherKey, jwt.SigningMethodES256, "key-1", claims("alice", nil)),
+			want:  codes.Unauthenticated,
+		},
+		{
+			name: "other issuer",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("alice", func(c jwt.MapClaims) {
+				c["iss"] = "https://other.example.com"
+			})),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "other audience",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("alice", func(c jwt.MapClaims) {
+				c["aud"] = "other"
+			})),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "expired",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("alice", func(c jwt.MapClaims) {
+				c["exp"] = time.Now().Add(-time.Minute).Unix()
+			})),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "no expiry",
+			token: sign(t, key, jwt.SigningMethodES256, "key-1", claims("alice", func(c jwt.MapClaims) {
+				delete(c, "exp")
+			})),
+			want: codes.Unauthenticated,
+		},
+		{
+			name:  "symmetric key",
+			token: sign(t, []byte("secret"), jwt.SigningMethodHS256, "key-1", claims("alice", nil)),
+			want:  codes.Unauthenticated,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tc.token)
+			if _, err := resultsClient.ListResults(ctx, &pb.ListResultsRequest{Parent: "foo"}); status.Code(err) != tc.want {
+				t.Errorf("ListResults: %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewOIDC(t *testing.T) {
+	if _, err := auth.NewOIDC(issuer, "", auth.NewSubjectAccessReview(fake.NewSimpleClientset().AuthorizationV1())); err == nil {
+		t.Error("want an error without JWKS")
+	}
+}
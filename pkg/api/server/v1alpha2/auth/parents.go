@@ -0,0 +1,125 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// filterConcurrency is the maximum number of parents authorized at once when
+// filtering parents.
+const filterConcurrency = 10
+
+// Candidates returns the parents a list across parents is filtered from.
+type Candidates func(ctx context.Context) ([]string, error)
+
+// ParentFilter is implemented by the auth checkers that can filter lists
+// across parents (`-`) to the parents the caller is allowed to list, instead
+// of denying the callers that are not allowed to list all parents.
+type ParentFilter interface {
+	// FilterParents checks whether the caller may perform the verb on the
+	// resource of all parents. If not, it returns the parents of the
+	// candidates it may perform the verb on, which may be none, with filtered
+	// set.
+	FilterParents(ctx context.Context, resource, verb string, candidates Candidates) (parents []string, filtered bool, err error)
+}
+
+// FilterParents returns the parents of the first check allowing the list. The
+// checks that are not ParentFilters must allow to list all parents.
+func (a Any) FilterParents(ctx context.Context, resource, verb string, candidates Candidates) ([]string, bool, error) {
+	err := status.Error(codes.Unauthenticated, "permission denied")
+	for _, c := range a {
+		f, ok := c.(ParentFilter)
+		if !ok {
+			if err = c.Check(ctx, "-", resource, verb); err == nil {
+				return nil, false, nil
+			}
+			continue
+		}
+		var (
+			parents  []string
+			filtered bool
+		)
+		if parents, filtered, err = f.FilterParents(ctx, resource, verb, candidates); err == nil {
+			return parents, filtered, nil
+		}
+	}
+	return nil, false, err
+}
+
+// filterParents returns the parents of the candidates that one of the users is
+// authorized to perform the verb on the resource of, unless one of them is
+// authorized on all parents. Authorization errors are logged, and deny the
+// parent.
+func filterParents(ctx context.Context, users []*User, resource, verb string, candidates Candidates, authorize func(context.Context, *User, string, string, string) (bool, error)) ([]string, bool, error) {
+	if len(users) == 0 {
+		return nil, false, status.Error(codes.Unauthenticated, "permission denied")
+	}
+	for _, user := range users {
+		allowed, err := authorize(ctx, user, corev1.NamespaceAll, resource, verb)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if allowed {
+			return nil, false, nil
+		}
+	}
+
+	parents, err := candidates(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	allowed := make([]bool, len(parents))
+	sem := make(chan struct{}, filterConcurrency)
+	var wg sync.WaitGroup
+	for i, parent := range parents {
+		i, parent := i, parent
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			for _, user := range users {
+				ok, err := authorize(ctx, user, parent, resource, verb)
+				if err != nil {
+					log.Println(err)
+					continue
+				}
+				if ok {
+					allowed[i] = true
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	filtered := make([]string, 0, len(parents))
+	for i, parent := range parents {
+		if allowed[i] {
+			filtered = append(filtered, parent)
+		}
+	}
+	return filtered, true, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFilterParents(t *testing.T) {
+	var candidates []string
+	for i := 0; i < 3*filterConcurrency; i++ {
+		candidates = append(candidates, fmt.Sprintf("ns-%d", i))
+	}
+	candidatesFn := func(context.Context) ([]string, error) {
+		return candidates, nil
+	}
+	// alice may list all namespaces, bob the even ones, and carol fails to
+	// be authorized.
+	authorize := func(_ context.Context, user *User, namespace, _, _ string) (bool, error) {
+		switch user.Name {
+		case "alice":
+			return true, nil
+		case "bob":
+			var i int
+			fmt.Sscanf(namespace, "ns-%d", &i)
+			return namespace != corev1.NamespaceAll && i%2 == 0, nil
+		default:
+			return false, errors.New("authorizer unavailable")
+		}
+	}
+
+	var even []string
+	for i := 0; i < len(candidates); i += 2 {
+		even = append(even, candidates[i])
+	}
+	for _, tc := range []struct {
+		name         string
+		users        []*User
+		want         []string
+		wantFiltered bool
+		wantCode     codes.Code
+	}{
+		{name: "all parents", users: []*User{{Name: "carol"}, {Name: "alice"}}},
+		{name: "some parents", users: []*User{{Name: "bob"}}, want: even, wantFiltered: true},
+		{name: "no parents", users: []*User{{Name: "carol"}}, want: []string{}, wantFiltered: true},
+		{name: "unauthenticated", wantCode: codes.Unauthenticated},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, filtered, err := filterParents(context.Background(), tc.users, ResourceResults, PermissionList, candidatesFn, authorize)
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("filterParents: %v, want %v", err, tc.wantCode)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" || filtered != tc.wantFiltered {
+				t.Errorf("filtered %t, want %t, parents (-want, +got):\n%s", filtered, tc.wantFiltered, diff)
+			}
+		})
+	}
+}
+
+// staticFilter filters parents to its parents.
+type staticFilter struct {
+	parents []string
+	err     error
+}
+
+func (f *staticFilter) Check(context.Context, string, string, string) error {
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+func (f *staticFilter) FilterParents(context.Context, string, string, Candidates) ([]string, bool, error) {
+	return f.parents, f.err == nil, f.err
+}
+
+func TestAny_FilterParents(t *testing.T) {
+	denied := &staticFilter{err: status.Error(codes.Unauthenticated, "permission denied")}
+	for _, tc := range []struct {
+		name         string
+		checkers     Any
+		want         []string
+		wantFiltered bool
+		wantCode     codes.Code
+	}{
+		{name: "checker", checkers: Any{denied, AllowAll{}}},
+		{name: "filter", checkers: Any{denied, &staticFilter{parents: []string{"foo"}}, AllowAll{}}, want: []string{"foo"}, wantFiltered: true},
+		{name: "denied", checkers: Any{denied}, wantCode: codes.Unauthenticated},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, filtered, err := tc.checkers.FilterParents(context.Background(), ResourceResults, PermissionList, nil)
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("FilterParents: %v, want %v", err, tc.wantCode)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" || filtered != tc.wantFiltered {
+				t.Errorf("filtered %t, want %t, parents (-want, +got):\n%s", filtered, tc.wantFiltered, diff)
+			}
+		})
+	}
+}
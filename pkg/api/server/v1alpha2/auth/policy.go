@@ -0,0 +1,342 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	policyResources = []string{ResourceResults, ResourceRecords, ResourceLogs}
+	policyVerbs     = []string{
+		PermissionCreate, PermissionGet, PermissionList, PermissionUpdate,
+		PermissionDelete, PermissionWatch, PermissionUndelete,
+	}
+)
+
+// Policy is a static authorization policy, allowing identities to perform
+// verbs on the resources of parents.
+type Policy struct {
+	Rules []*PolicyRule `json:"rules"`
+}
+
+// PolicyRule allows its subjects to perform the verbs on the resources of the
+// parents. `*` matches all parents, resources or verbs. Only rules matching
+// all parents allow to list resources across parents.
+type PolicyRule struct {
+	Subjects  []*PolicySubject `json:"subjects"`
+	Parents   []string         `json:"parents"`
+	Resources []string         `json:"resources"`
+	Verbs     []string         `json:"verbs"`
+}
+
+// PolicySubject is an identity, set by exactly one of its fields.
+type PolicySubject struct {
+	// Token is a static bearer token.
+	Token string `json:"token,omitempty"`
+	// TokenSHA256 is the hex encoded SHA-256 digest of a static bearer token,
+	// to keep the token out of the policy.
+	TokenSHA256 string `json:"tokenSHA256,omitempty"`
+	// Certificate is the subject of a verified TLS client certificate,
+	// formatted as an RFC 2253 distinguished name, e.g. "CN=watcher,O=Tekton".
+	Certificate string `json:"certificate,omitempty"`
+	// User is the name of a user authenticated by another check, e.g. the
+	// verified JWTs of an OIDC issuer.
+	User string `json:"user,omitempty"`
+	// Group is a group of a user authenticated by another check.
+	Group string `json:"group,omitempty"`
+
+	// digest is the digest of the token.
+	digest []byte
+}
+
+func (s *PolicySubject) String() string {
+	switch {
+	case s.Token != "" || s.TokenSHA256 != "":
+		return "token"
+	case s.Certificate != "":
+		return "certificate " + s.Certificate
+	case s.User != "":
+		return "user " + s.User
+	default:
+		return "group " + s.Group
+	}
+}
+
+// Validate checks that the rules of the policy are well formed, and computes
+// the digests of their tokens.
+func (p *Policy) Validate() error {
+	for i, r := range p.Rules {
+		if len(r.Subjects) == 0 || len(r.Parents) == 0 || len(r.Resources) == 0 || len(r.Verbs) == 0 {
+			return fmt.Errorf("rule %d: subjects, parents, resources and verbs must be set", i)
+		}
+		for _, s := range r.Subjects {
+			set := 0
+			for _, f := range []string{s.Token, s.TokenSHA256, s.Certificate, s.User, s.Group} {
+				if f != "" {
+					set++
+				}
+			}
+			if set != 1 {
+				return fmt.Errorf("rule %d: subjects must set exactly one of token, tokenSHA256, certificate, user or group", i)
+			}
+			switch {
+			case s.Token != "":
+				digest := sha256.Sum256([]byte(s.Token))
+				s.digest = digest[:]
+			case s.TokenSHA256 != "":
+				digest, err := hex.DecodeString(s.TokenSHA256)
+				if err != nil || len(digest) != sha256.Size {
+					return fmt.Errorf("rule %d: tokenSHA256 must be a hex encoded SHA-256 digest", i)
+				}
+				s.digest = digest
+			}
+		}
+		for _, parent := range r.Parents {
+			if parent == "" || parent == "-" {
+				return fmt.Errorf("rule %d: invalid parent %q", i, parent)
+			}
+		}
+		for _, resource := range r.Resources {
+			if resource != "*" && !contains(policyResources, resource) {
+				return fmt.Errorf("rule %d: unknown resource %q", i, resource)
+			}
+		}
+		for _, verb := range r.Verbs {
+			if verb != "*" && !contains(policyVerbs, verb) {
+				return fmt.Errorf("rule %d: unknown verb %q", i, verb)
+			}
+		}
+	}
+	return nil
+}
+
+// has returns whether the policy has a subject matching the given function.
+func (p *Policy) has(match func(*PolicySubject) bool) bool {
+	for _, r := range p.Rules {
+		for _, s := range r.Subjects {
+			if match(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allows returns whether a rule allows one of the subjects matching the given
+// function to perform the verb on the resource of the parent. The parent is
+// empty for all parents.
+func (p *Policy) allows(match func(*PolicySubject) bool, parent, resource, verb string) bool {
+	for _, r := range p.Rules {
+		if !contains(r.Parents, "*") && (parent == "" || !contains(r.Parents, parent)) {
+			continue
+		}
+		if !contains(r.Resources, "*") && !contains(r.Resources, resource) {
+			continue
+		}
+		if !contains(r.Verbs, "*") && !contains(r.Verbs, verb) {
+			continue
+		}
+		for _, s := range r.Subjects {
+			if match(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadPolicy reads a policy from a YAML or JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("error parsing authorization policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authorization policy %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// PolicyChecker is an auth checker for API servers running outside of a
+// cluster, authorizing requests with a static policy file instead of
+// Kubernetes RBAC. Callers are identified by the static bearer tokens they
+// pass in `authorization` metadata, or by their verified TLS client
+// certificates.
+//
+// PolicyChecker is also an Authorizer of the users authenticated by other
+// checks, e.g. OIDC.
+type PolicyChecker struct {
+	path string
+
+	mu     sync.RWMutex
+	policy *Policy
+}
+
+// NewPolicyChecker returns a checker of the policy of the given file. Call
+// Watch to reload the policy when the file changes.
+func NewPolicyChecker(path string) (*PolicyChecker, error) {
+	p, err := LoadPolicy(path)
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyChecker{path: path, policy: p}, nil
+}
+
+// Policy returns the policy currently enforced.
+func (c *PolicyChecker) Policy() *Policy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.policy
+}
+
+func (c *PolicyChecker) Check(ctx context.Context, parent, resource, verb string) error {
+	if verb == PermissionList && parent == "-" {
+		// In list operations `-` means that the caller wants to list
+		// resources across all parents.
+		parent = corev1.NamespaceAll
+	}
+	policy := c.Policy()
+
+	// Client certificates are verified by the TLS handshake, against the CAs
+	// of the server.
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			for _, chain := range info.State.VerifiedChains {
+				if len(chain) == 0 {
+					continue
+				}
+				subject := chain[0].Subject.String()
+				match := func(s *PolicySubject) bool {
+					return s.Certificate != "" && s.Certificate == subject
+				}
+				if policy.has(match) {
+					audit.SetUser(ctx, subject, "")
+				}
+				if policy.allows(match, parent, resource, verb) {
+					return nil
+				}
+			}
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, raw := range md.Get("authorization") {
+		// We expect tokens to be in the form "Bearer <token>". Parse the token out.
+		s := strings.SplitN(raw, " ", 2)
+		if len(s) < 2 {
+			log.Println("unknown auth token format")
+			continue
+		}
+		digest := sha256.Sum256([]byte(s[1]))
+		match := func(s *PolicySubject) bool {
+			return s.digest != nil && subtle.ConstantTimeCompare(s.digest, digest[:]) == 1
+		}
+		if policy.has(match) {
+			// Static tokens are identified by a prefix of their digest.
+			audit.SetUser(ctx, "token:"+hex.EncodeToString(digest[:6]), "")
+		}
+		if policy.allows(match, parent, resource, verb) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+// Authorize allows the users, or groups, that the policy allows to perform the
+// verb on the resource of the namespace.
+func (c *PolicyChecker) Authorize(_ context.Context, user *User, namespace, resource, verb string) (bool, error) {
+	return c.Policy().allows(func(s *PolicySubject) bool {
+		return (s.User != "" && s.User == user.Name) || (s.Group != "" && contains(user.Groups, s.Group))
+	}, namespace, resource, verb), nil
+}
+
+// Watch reloads the policy when its file changes, until the context is done.
+// A policy that fails to load is logged, and the previous policy kept.
+func (c *PolicyChecker) Watch(ctx context.Context) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	// The directory of the file is watched, so that files replaced by a
+	// rename, e.g. mounted ConfigMaps, are reloaded too.
+	if err := w.Add(filepath.Dir(c.path)); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.Events:
+			if !ok {
+				return errors.New("authorization policy watch closed")
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			c.reload()
+		case err, ok := <-w.Errors:
+			if !ok {
+				return errors.New("authorization policy watch closed")
+			}
+			log.Println(err)
+		}
+	}
+}
+
+func (c *PolicyChecker) reload() {
+	p, err := LoadPolicy(c.path)
+	if err != nil {
+		log.Printf("failed to reload authorization policy: %v", err)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.policy = p
+}
@@ -0,0 +1,307 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func writePolicy(t *testing.T, path, policy string) {
+	t.Helper()
+	// Replace the file, as Kubernetes does for mounted ConfigMaps.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(policy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPolicyChecker(t *testing.T) {
+	digest := sha256.Sum256([]byte("hashed-token"))
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy(t, path, `
+rules:
+  - subjects:
+      - token: watcher-token
+      - certificate: CN=watcher,O=Tekton
+    parents: ["*"]
+    resources: ["*"]
+    verbs: ["*"]
+  - subjects:
+      - tokenSHA256: `+hex.EncodeToString(digest[:])+`
+      - user: alice
+      - group: readers
+    parents: ["foo"]
+    resources: ["results", "records"]
+    verbs: ["get", "list"]
+`)
+	checker, err := auth.NewPolicyChecker(path)
+	if err != nil {
+		t.Fatalf("NewPolicyChecker: %v", err)
+	}
+
+	withCert := func(ctx context.Context, subject pkix.Name) context.Context {
+		return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: subject}}},
+		}}})
+	}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	for _, tc := range []struct {
+		name     string
+		ctx      context.Context
+		parent   string
+		resource string
+		verb     string
+		want     codes.Code
+	}{
+		{
+			name:     "token",
+			ctx:      withToken("watcher-token"),
+			parent:   "foo",
+			resource: auth.ResourceLogs,
+			verb:     auth.PermissionUpdate,
+			want:     codes.OK,
+		},
+		{
+			name:     "token list across parents",
+			ctx:      withToken("watcher-token"),
+			parent:   "-",
+			resource: auth.ResourceResults,
+			verb:     auth.PermissionList,
+			want:     codes.OK,
+		},
+		{
+			name:     "certificate",
+			ctx:      withCert(context.Background(), pkix.Name{CommonName: "watcher", Organization: []string{"Tekton"}}),
+			parent:   "bar",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionCreate,
+			want:     codes.OK,
+		},
+		{
+			name:     "other certificate",
+			ctx:      withCert(withToken("other"), pkix.Name{CommonName: "other", Organization: []string{"Tekton"}}),
+			parent:   "bar",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionCreate,
+			want:     codes.Unauthenticated,
+		},
+		{
+			name:     "hashed token",
+			ctx:      withToken("hashed-token"),
+			parent:   "foo",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionGet,
+			want:     codes.OK,
+		},
+		{
+			name:     "hashed token other parent",
+			ctx:      withToken("hashed-token"),
+			parent:   "bar",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionGet,
+			want:     codes.Unauthenticated,
+		},
+		{
+			name:     "hashed token list across parents",
+			ctx:      withToken("hashed-token"),
+			parent:   "-",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionList,
+			want:     codes.Unauthenticated,
+		},
+		{
+			name:     "hashed token other resource",
+			ctx:      withToken("hashed-token"),
+			parent:   "foo",
+			resource: auth.ResourceLogs,
+			verb:     auth.PermissionGet,
+			want:     codes.Unauthenticated,
+		},
+		{
+			name:     "hashed token other verb",
+			ctx:      withToken("hashed-token"),
+			parent:   "foo",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionDelete,
+			want:     codes.Unauthenticated,
+		},
+		{
+			name:     "unknown token",
+			ctx:      withToken("other"),
+			parent:   "foo",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionGet,
+			want:     codes.Unauthenticated,
+		},
+		{
+			name:     "no identity",
+			ctx:      context.Background(),
+			parent:   "foo",
+			resource: auth.ResourceRecords,
+			verb:     auth.PermissionGet,
+			want:     codes.Unauthenticated,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checker.Check(tc.ctx, tc.parent, tc.resource, tc.verb); status.Code(err) != tc.want {
+				t.Errorf("Check: %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	// Users of other checks, e.g. OIDC, are authorized by name or group.
+	for _, tc := range []struct {
+		user *auth.User
+		want bool
+	}{
+		{user: &auth.User{Name: "alice"}, want: true},
+		{user: &auth.User{Name: "bob", Groups: []string{"readers"}}, want: true},
+		{user: &auth.User{Name: "bob", Groups: []string{"writers"}}, want: false},
+		// Users do not match the subjects of tokens.
+		{user: &auth.User{Name: "watcher-token"}, want: false},
+	} {
+		if allowed, err := checker.Authorize(context.Background(), tc.user, "foo", auth.ResourceResults, auth.PermissionGet); err != nil || allowed != tc.want {
+			t.Errorf("Authorize(%+v): (%t, %v), want %t", tc.user, allowed, err, tc.want)
+		}
+	}
+}
+
+func TestPolicyChecker_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := func(token string) string {
+		return `
+rules:
+  - subjects:
+      - token: ` + token + `
+    parents: ["foo"]
+    resources: ["results"]
+    verbs: ["get"]
+`
+	}
+	writePolicy(t, path, policy("old-token"))
+	checker, err := auth.NewPolicyChecker(path)
+	if err != nil {
+		t.Fatalf("NewPolicyChecker: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Watch(ctx)
+
+	check := func(token string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		return checker.Check(ctx, "foo", auth.ResourceResults, auth.PermissionGet)
+	}
+	// waitFor retries until the check of the token returns the given code, as
+	// the policy is reloaded asynchronously.
+	waitFor := func(token string, want codes.Code) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			err := check(token)
+			if status.Code(err) == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Check(%s): %v, want %v", token, err, want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor("old-token", codes.OK)
+
+	// Give the watch time to start, so that the change is not missed.
+	time.Sleep(100 * time.Millisecond)
+	writePolicy(t, path, policy("new-token"))
+	waitFor("new-token", codes.OK)
+	if err := check("old-token"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Check(old-token): %v, want %v", err, codes.Unauthenticated)
+	}
+
+	// Invalid policies are not loaded.
+	writePolicy(t, path, "rules: [{subjects: [{token: other-token}]}]")
+	time.Sleep(100 * time.Millisecond)
+	if err := check("new-token"); err != nil {
+		t.Errorf("Check(new-token) after invalid policy: %v", err)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{
+			name:   "no subjects",
+			policy: `rules: [{parents: ["*"], resources: ["*"], verbs: ["*"]}]`,
+			err:    "subjects, parents, resources and verbs must be set",
+		},
+		{
+			name:   "subject of two identities",
+			policy: `rules: [{subjects: [{token: a, user: b}], parents: ["*"], resources: ["*"], verbs: ["*"]}]`,
+			err:    "exactly one of",
+		},
+		{
+			name:   "invalid digest",
+			policy: `rules: [{subjects: [{tokenSHA256: abc}], parents: ["*"], resources: ["*"], verbs: ["*"]}]`,
+			err:    "hex encoded SHA-256 digest",
+		},
+		{
+			name:   "unknown resource",
+			policy: `rules: [{subjects: [{user: a}], parents: ["*"], resources: ["pods"], verbs: ["*"]}]`,
+			err:    `unknown resource "pods"`,
+		},
+		{
+			name:   "unknown verb",
+			policy: `rules: [{subjects: [{user: a}], parents: ["*"], resources: ["*"], verbs: ["patch"]}]`,
+			err:    `unknown verb "patch"`,
+		},
+		{
+			name:   "unknown field",
+			policy: `rules: [{subjects: [{name: a}], parents: ["*"], resources: ["*"], verbs: ["*"]}]`,
+			err:    "unknown field",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			writePolicy(t, path, tc.policy)
+			if _, err := auth.LoadPolicy(path); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("LoadPolicy: %v, want error containing %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -16,9 +16,11 @@ package auth
 
 import (
 	"context"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth/impersonation"
 	"log"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	authnclient "k8s.io/client-go/kubernetes/typed/authentication/v1"
 	authzclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/utils/clock"
 )
 
 // RBAC is a Kubernetes RBAC based auth checker. This uses the Kubernetes
@@ -42,6 +45,10 @@ type RBAC struct {
 	allowImpersonation bool
 	authn              authnclient.AuthenticationV1Interface
 	authz              authzclient.AuthorizationV1Interface
+	// authnCache caches the users of tokens, and authzCache the decisions
+	// of SubjectAccessReviews. Both are nil unless enabled.
+	authnCache *decisionCache
+	authzCache *decisionCache
 }
 
 type Option func(*RBAC)
@@ -58,9 +65,50 @@ func NewRBAC(client kubernetes.Interface, options ...Option) *RBAC {
 }
 
 func (r *RBAC) Check(ctx context.Context, namespace, resource, verb string) error {
+	users, err := r.users(ctx)
+	if err != nil {
+		return err
+	}
+
+	if verb == PermissionList && namespace == "-" {
+		// In list operations `-` means that the caller wants to list
+		// resources across all parents. Thus, let's assume all
+		// namespaces here.
+		namespace = corev1.NamespaceAll
+	}
+
+	for _, user := range users {
+		// Authorize the request by checking the RBAC permissions for the resource.
+		allowed, err := r.authorize(ctx, user, namespace, resource, verb)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if allowed {
+			return nil
+		}
+	}
+	// Return Unauthenticated - we don't know if we failed because of invalid
+	// token or unauthorized user, so this is safer to not leak any state.
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+// FilterParents authorizes the caller on each of the candidates, unless it is
+// allowed on all namespaces. Decisions are cached as those of Check.
+func (r *RBAC) FilterParents(ctx context.Context, resource, verb string, candidates Candidates) ([]string, bool, error) {
+	users, err := r.users(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return filterParents(ctx, users, resource, verb, candidates, r.authorize)
+}
+
+// users returns the users of the authenticated tokens of the request, or the
+// user they impersonate.
+func (r *RBAC) users(ctx context.Context) ([]*User, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Error(codes.Unauthenticated, "unable to get context metadata")
+		return nil, status.Error(codes.Unauthenticated, "unable to get context metadata")
 	}
 
 	// Parse Impersonation header if the feature is enabled
@@ -72,22 +120,16 @@ func (r *RBAC) Check(ctx context.Context, namespace, resource, verb string) erro
 		// impersonation headers and should be processed normally.
 		if err != nil && err != impersonation.ErrorNoImpersonationData {
 			log.Println(err)
-			return status.Error(codes.Unauthenticated, "invalid impersonation data")
+			return nil, status.Error(codes.Unauthenticated, "invalid impersonation data")
 		}
 	}
 
 	v := md.Get("authorization")
 	if len(v) == 0 {
-		return status.Error(codes.Unauthenticated, "unable to find token")
-	}
-
-	if verb == PermissionList && namespace == "-" {
-		// In list operations `-` means that the caller wants to list
-		// resources across all parents. Thus, let's assume all
-		// namespaces here.
-		namespace = corev1.NamespaceAll
+		return nil, status.Error(codes.Unauthenticated, "unable to find token")
 	}
 
+	var users []*User
 	for _, raw := range v {
 		// We expect tokens to be in the form "Bearer <token>". Parse the token out.
 		s := strings.SplitN(raw, " ", 2)
@@ -95,72 +137,88 @@ func (r *RBAC) Check(ctx context.Context, namespace, resource, verb string) erro
 			log.Println("unknown auth token format")
 			continue
 		}
-		t := s[1]
-
-		// Authenticate the token by sending it to the API Server for review.
-		tr, err := r.authn.TokenReviews().Create(ctx, &authnv1.TokenReview{
-			Spec: authnv1.TokenReviewSpec{
-				Token: t,
-			},
-		}, metav1.CreateOptions{})
+
+		user, err := r.authenticate(ctx, s[1])
 		if err != nil {
 			log.Println(err)
 			continue
 		}
-		if !tr.Status.Authenticated {
+		if user == nil {
 			continue
 		}
-
-		user := tr.Status.User.Username
-		UID := tr.Status.User.UID
-		groups := []string{"tekton.dev"}
-		extra := map[string]authzv1.ExtraValue{}
+		audit.SetUser(ctx, user.Name, "")
 
 		// Check whether the authenticated user has permission to impersonate
 		if impersonator != nil {
-			if err := impersonator.Check(ctx, r.authz, user); err != nil {
+			audit.SetUser(ctx, user.Name, impersonator.GetUserInfo().GetName())
+			if err := impersonator.Check(ctx, r.authz, user.Name); err != nil {
 				log.Println(err)
-				return status.Error(codes.Unauthenticated, "permission denied")
+				return nil, status.Error(codes.Unauthenticated, "permission denied")
 			}
 			// Change user data to impersonated user
 			userInfo := impersonator.GetUserInfo()
-			user = userInfo.GetName()
-			UID = userInfo.GetUID()
-			groups = userInfo.GetGroups()
-			extra = convertExtra(userInfo.GetExtra())
+			user = &User{
+				Name:   userInfo.GetName(),
+				UID:    userInfo.GetUID(),
+				Groups: userInfo.GetGroups(),
+				Extra:  userInfo.GetExtra(),
+			}
 		}
+		users = append(users, user)
+	}
+	return users, nil
+}
 
-		// Authorize the request by checking the RBAC permissions for the resource.
-		sar, err := r.authz.SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
-			Spec: authzv1.SubjectAccessReviewSpec{
-				User:   user,
-				UID:    UID,
-				Groups: groups,
-				Extra:  extra,
-				ResourceAttributes: &authzv1.ResourceAttributes{
-					Namespace: namespace,
-					Group:     "results.tekton.dev",
-					Resource:  resource,
-					Verb:      verb,
-				},
-			},
-		}, metav1.CreateOptions{})
-		if err != nil {
-			log.Println(err)
-			continue
-		}
-		if sar.Status.Allowed {
-			return nil
+// authenticate returns the user of the token, or nil if the token is not
+// authenticated.
+func (r *RBAC) authenticate(ctx context.Context, token string) (*User, error) {
+	key := tokenKey(token)
+	if user, ok := r.authnCache.get(key); ok {
+		return user.(*User), nil
+	}
+
+	// Authenticate the token by sending it to the API Server for review.
+	tr, err := r.authn.TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var user *User
+	if tr.Status.Authenticated {
+		user = &User{
+			Name:   tr.Status.User.Username,
+			UID:    tr.Status.User.UID,
+			Groups: []string{"tekton.dev"},
 		}
 	}
-	// Return Unauthenticated - we don't know if we failed because of invalid
-	// token or unauthorized user, so this is safer to not leak any state.
-	return status.Error(codes.Unauthenticated, "permission denied")
+	r.authnCache.add(key, user, user != nil)
+	return user, nil
+}
+
+// authorize checks the RBAC permissions of the user for the resource. Errors
+// are not cached.
+func (r *RBAC) authorize(ctx context.Context, user *User, namespace, resource, verb string) (bool, error) {
+	key := authorizationKey(user, namespace, resource, verb)
+	if allowed, ok := r.authzCache.get(key); ok {
+		return allowed.(bool), nil
+	}
+	allowed, err := NewSubjectAccessReview(r.authz).Authorize(ctx, user, namespace, resource, verb)
+	if err != nil {
+		return false, err
+	}
+	r.authzCache.add(key, allowed, allowed)
+	return allowed, nil
 }
 
 // convertExtra converts the map[string][]string to map[string]ExtraValue for Subject Access Review.
 func convertExtra(extra map[string][]string) map[string]authzv1.ExtraValue {
-	var newExtra map[string]authzv1.ExtraValue
+	if len(extra) == 0 {
+		return nil
+	}
+	newExtra := make(map[string]authzv1.ExtraValue, len(extra))
 	for key, value := range extra {
 		newExtra[key] = value
 	}
@@ -173,3 +231,18 @@ func WithImpersonation(enabled bool) Option {
 		r.allowImpersonation = enabled
 	}
 }
+
+// WithCache is an option function to cache the results of TokenReviews and
+// SubjectAccessReviews, up to size entries each. Positive results expire after
+// ttl, and negative ones after negativeTTL. The cache is disabled if size or
+// both TTLs are 0.
+func WithCache(size int, ttl, negativeTTL time.Duration) Option {
+	return func(r *RBAC) {
+		if size <= 0 || (ttl <= 0 && negativeTTL <= 0) {
+			r.authnCache, r.authzCache = nil, nil
+			return
+		}
+		r.authnCache = newDecisionCache("authentication", size, ttl, negativeTTL, clock.RealClock{})
+		r.authzCache = newDecisionCache("authorization", size, ttl, negativeTTL, clock.RealClock{})
+	}
+}
@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"k8s.io/utils/strings/slices"
+	"sort"
 	"testing"
+	"time"
 
 	server "github.com/tektoncd/results/pkg/api/server/v1alpha2"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	testclient "github.com/tektoncd/results/pkg/internal/test"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
@@ -191,3 +194,185 @@ func TestRBAC(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACCache(t *testing.T) {
+	var tokenReviews, accessReviews int
+	k8s := fake.NewSimpleClientset()
+	k8s.PrependReactor("create", "tokenreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		tokenReviews++
+		tr := action.(test.CreateActionImpl).Object.(*authnv1.TokenReview)
+		tr.Status = authnv1.TokenReviewStatus{
+			Authenticated: tr.Spec.Token != "unauthenticated",
+			User:          authnv1.UserInfo{Username: tr.Spec.Token},
+		}
+		return true, tr, nil
+	})
+	k8s.PrependReactor("create", "subjectaccessreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		accessReviews++
+		sar := action.(test.CreateActionImpl).Object.(*authzv1.SubjectAccessReview)
+		sar.Status = authzv1.SubjectAccessReviewStatus{Allowed: sar.Spec.User == "authorized"}
+		return true, sar, nil
+	})
+	resultsClient, _ := testclient.NewResultsClient(t, &config.Config{}, server.WithAuth(auth.NewRBAC(k8s, auth.WithCache(10, time.Minute, time.Minute))))
+
+	for _, tc := range []struct {
+		token             string
+		want              codes.Code
+		wantTokenReviews  int
+		wantAccessReviews int
+	}{
+		{token: "authorized", want: codes.OK, wantTokenReviews: 1, wantAccessReviews: 1},
+		{token: "unauthorized", want: codes.Unauthenticated, wantTokenReviews: 1, wantAccessReviews: 1},
+		{token: "unauthenticated", want: codes.Unauthenticated, wantTokenReviews: 1, wantAccessReviews: 0},
+	} {
+		t.Run(tc.token, func(t *testing.T) {
+			tokenReviews, accessReviews = 0, 0
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tc.token)
+			for i := 0; i < 3; i++ {
+				if _, err := resultsClient.ListResults(ctx, &pb.ListResultsRequest{Parent: "foo"}); status.Code(err) != tc.want {
+					t.Fatalf("ListResults: %v, want %v", err, tc.want)
+				}
+			}
+			if tokenReviews != tc.wantTokenReviews || accessReviews != tc.wantAccessReviews {
+				t.Errorf("got %d TokenReviews and %d SubjectAccessReviews, want %d and %d", tokenReviews, accessReviews, tc.wantTokenReviews, tc.wantAccessReviews)
+			}
+
+			// Other resources, or namespaces, are authorized again.
+			if _, err := resultsClient.ListResults(ctx, &pb.ListResultsRequest{Parent: "bar"}); status.Code(err) != tc.want {
+				t.Fatalf("ListResults: %v, want %v", err, tc.want)
+			}
+			if tokenReviews != tc.wantTokenReviews || accessReviews != 2*tc.wantAccessReviews {
+				t.Errorf("got %d TokenReviews and %d SubjectAccessReviews, want %d and %d", tokenReviews, accessReviews, tc.wantTokenReviews, 2*tc.wantAccessReviews)
+			}
+		})
+	}
+}
+
+func TestRBAC_Audit(t *testing.T) {
+	k8s := fake.NewSimpleClientset()
+	k8s.PrependReactor("create", "tokenreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		tr := action.(test.CreateActionImpl).Object.(*authnv1.TokenReview)
+		tr.Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: "alice"}}
+		return true, tr, nil
+	})
+	k8s.PrependReactor("create", "subjectaccessreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		sar := action.(test.CreateActionImpl).Object.(*authzv1.SubjectAccessReview)
+		sar.Status = authzv1.SubjectAccessReviewStatus{Allowed: true}
+		return true, sar, nil
+	})
+	rbac := auth.NewRBAC(k8s, auth.WithImpersonation(true))
+
+	for _, tc := range []struct {
+		name             string
+		md               metadata.MD
+		wantUser         string
+		wantImpersonated string
+	}{
+		{
+			name:     "user",
+			md:       metadata.Pairs("authorization", "Bearer token"),
+			wantUser: "alice",
+		},
+		{
+			name:             "impersonated user",
+			md:               metadata.Pairs("authorization", "Bearer token", "Impersonate-User", "bob"),
+			wantUser:         "alice",
+			wantImpersonated: "bob",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ev := &audit.Event{}
+			ctx := audit.NewContext(metadata.NewIncomingContext(context.Background(), tc.md), ev)
+			if err := rbac.Check(ctx, "foo", auth.ResourceResults, auth.PermissionGet); err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			if ev.User != tc.wantUser || ev.ImpersonatedUser != tc.wantImpersonated {
+				t.Errorf("got user %q impersonating %q, want %q impersonating %q", ev.User, ev.ImpersonatedUser, tc.wantUser, tc.wantImpersonated)
+			}
+		})
+	}
+}
+
+func TestRBAC_FilterParents(t *testing.T) {
+	var accessReviews int
+	k8s := fake.NewSimpleClientset()
+	k8s.PrependReactor("create", "tokenreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		tr := action.(test.CreateActionImpl).Object.(*authnv1.TokenReview)
+		tr.Status = authnv1.TokenReviewStatus{
+			Authenticated: tr.Spec.Token != "unauthenticated",
+			User:          authnv1.UserInfo{Username: tr.Spec.Token},
+		}
+		return true, tr, nil
+	})
+	k8s.PrependReactor("create", "subjectaccessreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		accessReviews++
+		sar := action.(test.CreateActionImpl).Object.(*authzv1.SubjectAccessReview)
+		// admin may do anything, and dev may only read foo and baz.
+		allowed := sar.Spec.User == "admin" ||
+			(sar.Spec.User == "dev" && slices.Contains([]string{"foo", "baz"}, sar.Spec.ResourceAttributes.Namespace))
+		sar.Status = authzv1.SubjectAccessReviewStatus{Allowed: allowed}
+		return true, sar, nil
+	})
+	rbac := auth.NewRBAC(k8s, auth.WithCache(100, time.Minute, time.Minute))
+	filterClient, _ := testclient.NewResultsClient(t, &config.Config{AUTH_FILTER_LISTS: true}, server.WithAuth(rbac))
+
+	admin := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer admin")
+	for _, parent := range []string{"foo", "bar", "baz"} {
+		if _, err := filterClient.CreateResult(admin, &pb.CreateResultRequest{
+			Parent: parent,
+			Result: &pb.Result{Name: parent + "/results/run"},
+		}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		token        string
+		want         []string
+		wantFiltered bool
+		wantCode     codes.Code
+	}{
+		{token: "admin", want: []string{"bar/results/run", "baz/results/run", "foo/results/run"}},
+		{token: "dev", want: []string{"baz/results/run", "foo/results/run"}, wantFiltered: true},
+		{token: "nobody", wantFiltered: true},
+		{token: "unauthenticated", wantCode: codes.Unauthenticated},
+	} {
+		t.Run(tc.token, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tc.token)
+			for i := 0; i < 2; i++ {
+				accessReviews = 0
+				resp, err := filterClient.ListResults(ctx, &pb.ListResultsRequest{Parent: "-"})
+				if status.Code(err) != tc.wantCode {
+					t.Fatalf("ListResults: %v, want %v", err, tc.wantCode)
+				}
+				var got []string
+				for _, r := range resp.GetResults() {
+					got = append(got, r.GetName())
+				}
+				sort.Strings(got)
+				if fmt.Sprint(got) != fmt.Sprint(tc.want) || resp.GetParentsFiltered() != tc.wantFiltered {
+					t.Errorf("got %v filtered %t, want %v filtered %t", got, resp.GetParentsFiltered(), tc.want, tc.wantFiltered)
+				}
+				// Decisions on each parent are cached.
+				if i > 0 && accessReviews != 0 {
+					t.Errorf("got %d SubjectAccessReviews listing again, want 0", accessReviews)
+				}
+			}
+
+			records, err := filterClient.ListRecords(ctx, &pb.ListRecordsRequest{Parent: "-/results/-"})
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("ListRecords: %v, want %v", err, tc.wantCode)
+			}
+			if records.GetParentsFiltered() != tc.wantFiltered {
+				t.Errorf("ListRecords filtered %t, want %t", records.GetParentsFiltered(), tc.wantFiltered)
+			}
+		})
+	}
+
+	// Lists across parents are denied if filtering is disabled.
+	client, _ := testclient.NewResultsClient(t, &config.Config{}, server.WithAuth(rbac))
+	dev := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer dev")
+	if _, err := client.ListResults(dev, &pb.ListResultsRequest{Parent: "-"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("ListResults: %v, want %v", err, codes.Unauthenticated)
+	}
+}
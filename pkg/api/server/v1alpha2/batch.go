@@ -0,0 +1,242 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// maxBatchSize is the maximum number of requests in a batch.
+const maxBatchSize = 1000
+
+// batchItem is the outcome of a request of a batch. event is the type of the
+// event to publish for the Record once the batch is committed, if any.
+type batchItem struct {
+	record *pb.Record
+	err    error
+	event  pb.EventType
+}
+
+// BatchCreateRecords creates Records in a single transaction.
+func (s *Server) BatchCreateRecords(ctx context.Context, req *pb.BatchCreateRecordsRequest) (*pb.BatchCreateRecordsResponse, error) {
+	parent, resultName, err := s.parseBatch(ctx, req.GetParent(), len(req.GetRequests()), auth.PermissionCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.runBatch(ctx, len(req.GetRequests()), func(tx *gorm.DB, ids resultIDs, i int) (*pb.Record, pb.EventType, error) {
+		item := req.GetRequests()[i]
+		r := item.GetRecord()
+		recordParent, recordResult, name, err := batchRecordName(parent, resultName, r.GetName())
+		if err != nil {
+			return nil, 0, err
+		}
+		if item.GetParent() != "" && item.GetParent() != result.FormatName(recordParent, recordResult) {
+			return nil, 0, status.Error(codes.InvalidArgument, "requested parent does not match resource name")
+		}
+		resultID, err := ids.get(tx, recordParent, recordResult)
+		if err != nil {
+			return nil, 0, err
+		}
+		out, err := s.createRecord(tx, recordParent, recordResult, resultID, name, r)
+		return out, pb.EventType_ADDED, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchCreateRecordsResponse{Responses: s.batchResponses(items)}, nil
+}
+
+// BatchUpsertRecords creates or updates Records in a single transaction.
+// Upserts are idempotent: Records whose stored data, once the incoming data is
+// redacted, is unchanged are returned as-is, without bumping their update time
+// and etag, keeping a revision or publishing an event, so that the watcher can
+// upsert an object on every reconcile. UpdateRecord always writes the Record.
+func (s *Server) BatchUpsertRecords(ctx context.Context, req *pb.BatchUpsertRecordsRequest) (*pb.BatchUpsertRecordsResponse, error) {
+	parent, resultName, err := s.parseBatch(ctx, req.GetParent(), len(req.GetRequests()), auth.PermissionCreate, auth.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.runBatch(ctx, len(req.GetRequests()), func(tx *gorm.DB, ids resultIDs, i int) (*pb.Record, pb.EventType, error) {
+		item := req.GetRequests()[i]
+		in := item.GetRecord()
+		recordParent, recordResult, name, err := batchRecordName(parent, resultName, in.GetName())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Deleted Records are looked up too, so that they are not created
+		// again, which would fail on their name.
+		curr, err := getRecord(tx.Unscoped(), recordParent, recordResult, name)
+		if status.Code(err) == codes.NotFound {
+			if item.GetEtag() != "" {
+				return nil, 0, status.Errorf(codes.NotFound, "record %s not found", in.GetName())
+			}
+			resultID, err := ids.get(tx, recordParent, recordResult)
+			if err != nil {
+				return nil, 0, err
+			}
+			out, err := s.createRecord(tx, recordParent, recordResult, resultID, name, in)
+			return out, pb.EventType_ADDED, err
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if curr.DeleteTime.Valid {
+			return nil, 0, status.Errorf(codes.FailedPrecondition, "record %s is deleted; undelete it first", in.GetName())
+		}
+
+		// Records are stored with their secrets redacted, so the incoming
+		// data is redacted before it is compared.
+		same, err := s.sameData(curr, in.GetData())
+		if err != nil {
+			return nil, 0, err
+		}
+		if same {
+			if item.GetEtag() != "" && item.GetEtag() != curr.Etag {
+				return nil, 0, status.Error(codes.FailedPrecondition, "the etag mismatches")
+			}
+			out, err := record.ToAPI(curr)
+			return out, pb.EventType_EVENT_TYPE_UNSPECIFIED, err
+		}
+		out, err := s.updateRecord(tx, curr, &pb.Record{Data: in.GetData()}, []string{"data"}, item.GetEtag())
+		return out, pb.EventType_MODIFIED, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchUpsertRecordsResponse{Responses: s.batchResponses(items)}, nil
+}
+
+// parseBatch validates the parent and the size of a batch, and checks that
+// the caller has all the given permissions on the Records of the parent.
+func (s *Server) parseBatch(ctx context.Context, name string, size int, permissions ...string) (parent, resultName string, err error) {
+	parent, resultName, err = result.ParseName(name)
+	if err != nil {
+		return "", "", err
+	}
+	// Permissions are checked once for the whole batch, so all Records must
+	// belong to the same parent.
+	if parent == "-" {
+		return "", "", status.Error(codes.InvalidArgument, "batches must be within a single parent")
+	}
+	if size == 0 {
+		return "", "", status.Error(codes.InvalidArgument, "requests missing")
+	}
+	if size > maxBatchSize {
+		return "", "", status.Errorf(codes.InvalidArgument, "at most %d requests are allowed in a batch, got %d", maxBatchSize, size)
+	}
+	for _, p := range permissions {
+		if err := s.auth.Check(ctx, parent, auth.ResourceRecords, p); err != nil {
+			return "", "", err
+		}
+	}
+	return parent, resultName, nil
+}
+
+// batchRecordName parses the name of a Record of a batch, making sure that it
+// belongs to the Result of the batch.
+func batchRecordName(parent, resultName, raw string) (recordParent, recordResult, name string, err error) {
+	recordParent, recordResult, name, err = record.ParseName(raw)
+	if err != nil {
+		return "", "", "", err
+	}
+	if recordParent != parent || (resultName != "-" && recordResult != resultName) {
+		return "", "", "", status.Errorf(codes.InvalidArgument, "record %s is not in %s", raw, result.FormatName(parent, resultName))
+	}
+	return recordParent, recordResult, name, nil
+}
+
+// runBatch runs fn for each of the n requests of a batch in a single
+// transaction. Each request runs in its own savepoint, so that a failed
+// request is rolled back without affecting the others. Only errors of the
+// transaction itself fail the whole batch.
+func (s *Server) runBatch(ctx context.Context, n int, fn func(tx *gorm.DB, ids resultIDs, i int) (*pb.Record, pb.EventType, error)) ([]*batchItem, error) {
+	items := make([]*batchItem, n)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := resultIDs{}
+		for i := 0; i < n; i++ {
+			savepoint := fmt.Sprintf("batch_%d", i)
+			if err := errors.Wrap(tx.SavePoint(savepoint).Error); err != nil {
+				return err
+			}
+			r, event, err := fn(tx, ids, i)
+			if err != nil {
+				if err := errors.Wrap(tx.RollbackTo(savepoint).Error); err != nil {
+					return err
+				}
+			}
+			items[i] = &batchItem{record: r, err: err, event: event}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// batchResponses publishes the events of the committed requests of a batch,
+// and returns the outcome of each request.
+func (s *Server) batchResponses(items []*batchItem) []*pb.BatchRecordResponse {
+	out := make([]*pb.BatchRecordResponse, 0, len(items))
+	for _, item := range items {
+		if item.err != nil {
+			out = append(out, &pb.BatchRecordResponse{Status: status.Convert(item.err).Proto()})
+			continue
+		}
+		if item.event != pb.EventType_EVENT_TYPE_UNSPECIFIED {
+			s.events.PublishRecord(item.event, item.record)
+		}
+		out = append(out, &pb.BatchRecordResponse{
+			Record: item.record,
+			Status: status.New(codes.OK, "").Proto(),
+		})
+	}
+	return out
+}
+
+// resultIDs caches the IDs of the Results looked up during a batch, by name.
+type resultIDs map[string]string
+
+// get returns the ID of the Result, looking it up in the transaction if it
+// is not cached.
+func (ids resultIDs) get(tx *gorm.DB, parent, resultName string) (string, error) {
+	key := result.FormatName(parent, resultName)
+	if id, ok := ids[key]; ok {
+		return id, nil
+	}
+	id := new(resultID)
+	q := tx.Model(&db.Result{}).
+		Where(&db.Result{Parent: parent, Name: resultName}).
+		First(id)
+	if err := errors.Wrap(q.Error); err != nil {
+		return "", err
+	}
+	ids[key] = id.ID
+	return id.ID, nil
+}
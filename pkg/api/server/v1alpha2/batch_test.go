@@ -0,0 +1,221 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// batchCodes returns the status code of each response of a batch.
+func batchCodes(responses []*pb.BatchRecordResponse) []codes.Code {
+	out := make([]codes.Code, 0, len(responses))
+	for _, r := range responses {
+		out = append(out, codes.Code(r.GetStatus().GetCode()))
+	}
+	return out
+}
+
+func TestBatchCreateRecords(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	for _, name := range []string{"foo/results/a", "foo/results/b"} {
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{Parent: "foo", Result: &pb.Result{Name: name}}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+	}
+	newRequest := func(result, name string) *pb.CreateRecordRequest {
+		return &pb.CreateRecordRequest{Record: &pb.Record{
+			Name: record.FormatName(result, name),
+			Data: &pb.Any{Type: "test", Value: []byte(`{}`)},
+		}}
+	}
+
+	resp, err := srv.BatchCreateRecords(ctx, &pb.BatchCreateRecordsRequest{
+		Parent: "foo/results/-",
+		Requests: []*pb.CreateRecordRequest{
+			newRequest("foo/results/a", "1"),
+			newRequest("foo/results/b", "1"),
+			// Already created by the first request.
+			newRequest("foo/results/a", "1"),
+			newRequest("foo/results/missing", "1"),
+			newRequest("bar/results/a", "1"),
+			{Parent: "foo/results/b", Record: newRequest("foo/results/a", "2").GetRecord()},
+			newRequest("foo/results/a", "2"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+	want := []codes.Code{codes.OK, codes.OK, codes.AlreadyExists, codes.NotFound, codes.InvalidArgument, codes.InvalidArgument, codes.OK}
+	if diff := cmp.Diff(want, batchCodes(resp.GetResponses())); diff != "" {
+		t.Fatalf("BatchCreateRecords codes (-want, +got): %s", diff)
+	}
+
+	// The successful requests are committed despite the failed ones.
+	for _, i := range []int{0, 1, 6} {
+		created := resp.GetResponses()[i].GetRecord()
+		got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: created.GetName()})
+		if err != nil {
+			t.Fatalf("GetRecord: %v", err)
+		}
+		if diff := cmp.Diff(created, got, protocmp.Transform()); diff != "" {
+			t.Errorf("GetRecord (-want, +got): %s", diff)
+		}
+	}
+	for _, i := range []int{2, 3, 4, 5} {
+		if resp.GetResponses()[i].GetRecord() != nil {
+			t.Errorf("response %d: want no record, got %+v", i, resp.GetResponses()[i])
+		}
+	}
+}
+
+func TestBatchUpsertRecords(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, SOFT_DELETE_EXPIRY: time.Hour}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{Parent: "foo", Result: &pb.Result{Name: "foo/results/bar"}})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	existing, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "existing"),
+			Data: &pb.Any{Type: "test", Value: []byte(`{"n":0}`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	deleted, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "deleted"),
+			Data: &pb.Any{Type: "test", Value: []byte(`{"n":0}`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: deleted.GetName()}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	newRequest := func(name, value, etag string) *pb.UpsertRecordRequest {
+		return &pb.UpsertRecordRequest{
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), name),
+				Data: &pb.Any{Type: "test", Value: []byte(value)},
+			},
+			Etag: etag,
+		}
+	}
+
+	fakeClock.Advance(time.Second)
+	resp, err := srv.BatchUpsertRecords(ctx, &pb.BatchUpsertRecordsRequest{
+		Parent: res.GetName(),
+		Requests: []*pb.UpsertRecordRequest{
+			// Unchanged.
+			newRequest("existing", `{"n":0}`, ""),
+			newRequest("new", `{"n":0}`, ""),
+			newRequest("missing", `{"n":0}`, "etag"),
+			newRequest("existing", `{"n":1}`, "wrong"),
+			newRequest("existing", `{"n":1}`, existing.GetEtag()),
+			// Deleted Records must be undeleted first.
+			newRequest("deleted", `{"n":1}`, ""),
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpsertRecords: %v", err)
+	}
+	want := []codes.Code{codes.OK, codes.OK, codes.NotFound, codes.FailedPrecondition, codes.OK, codes.FailedPrecondition}
+	if diff := cmp.Diff(want, batchCodes(resp.GetResponses())); diff != "" {
+		t.Fatalf("BatchUpsertRecords codes (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(existing, resp.GetResponses()[0].GetRecord(), protocmp.Transform()); diff != "" {
+		t.Errorf("unchanged Record (-want, +got): %s", diff)
+	}
+
+	got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: existing.GetName()})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	if diff := cmp.Diff(resp.GetResponses()[4].GetRecord(), got, protocmp.Transform()); diff != "" {
+		t.Errorf("updated Record (-want, +got): %s", diff)
+	}
+	if string(got.GetData().GetValue()) != `{"n":1}` || got.GetEtag() == existing.GetEtag() {
+		t.Errorf("Record not updated: %+v", got)
+	}
+	if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: record.FormatName(res.GetName(), "new")}); err != nil {
+		t.Errorf("GetRecord: %v", err)
+	}
+	for _, name := range []string{"missing", "deleted"} {
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: record.FormatName(res.GetName(), name)}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetRecord(%s): expected NOT_FOUND, got %v", name, err)
+		}
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	item := &pb.UpsertRecordRequest{Record: &pb.Record{Name: "foo/results/bar/records/baz"}}
+	for _, tc := range []struct {
+		name string
+		req  *pb.BatchUpsertRecordsRequest
+	}{
+		{
+			name: "invalid parent",
+			req:  &pb.BatchUpsertRecordsRequest{Parent: "foo", Requests: []*pb.UpsertRecordRequest{item}},
+		},
+		{
+			name: "all parents",
+			req:  &pb.BatchUpsertRecordsRequest{Parent: "-/results/-", Requests: []*pb.UpsertRecordRequest{item}},
+		},
+		{
+			name: "no requests",
+			req:  &pb.BatchUpsertRecordsRequest{Parent: "foo/results/bar"},
+		},
+		{
+			name: "too many requests",
+			req:  &pb.BatchUpsertRecordsRequest{Parent: "foo/results/bar", Requests: make([]*pb.UpsertRecordRequest, maxBatchSize+1)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if resp, err := srv.BatchUpsertRecords(ctx, tc.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("BatchUpsertRecords: expected INVALID_ARGUMENT, got %+v, %v", resp, err)
+			}
+		})
+	}
+}
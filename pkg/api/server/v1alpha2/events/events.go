@@ -0,0 +1,215 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events provides an in-process broadcaster of change events for the
+// Watch RPCs.
+//
+// Events are kept in a bounded in-memory log so that subscribers can resume
+// from a cursor after reconnecting. The log is local to a single API server
+// replica and is lost on restart; cursors issued by another process are
+// rejected.
+package events
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+var (
+	// ErrCursorExpired is returned when subscribing from a cursor that is no
+	// longer in the event log.
+	ErrCursorExpired = errors.New("cursor expired")
+	// ErrInvalidCursor is returned when subscribing from a malformed cursor.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrOverflow is returned by a Subscription whose consumer fell too far
+	// behind the published events.
+	ErrOverflow = errors.New("subscriber too slow")
+)
+
+// Event is a change to a Result or Record.
+type Event struct {
+	// Cursor identifies the position of the event in the event log.
+	Cursor string
+	Type   pb.EventType
+	// Exactly one of Result and Record is set.
+	Result *pb.Result
+	Record *pb.Record
+
+	seq uint64
+}
+
+// Broadcaster fans out published events to subscribers.
+type Broadcaster struct {
+	mu sync.Mutex
+	// epoch identifies this Broadcaster in cursors.
+	epoch string
+	// seq is the sequence number of the last published event.
+	seq uint64
+	// log is a ring buffer holding the last events.
+	log  []*Event
+	subs map[*Subscription]struct{}
+}
+
+// NewBroadcaster returns a Broadcaster keeping the last size events for
+// subscribers resuming from a cursor.
+func NewBroadcaster(size int) *Broadcaster {
+	if size < 1 {
+		size = 1
+	}
+	return &Broadcaster{
+		epoch: uuid.New().String(),
+		log:   make([]*Event, size),
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// PublishResult records a change to a Result.
+func (b *Broadcaster) PublishResult(t pb.EventType, r *pb.Result) {
+	b.publish(&Event{Type: t, Result: r})
+}
+
+// PublishRecord records a change to a Record.
+func (b *Broadcaster) PublishRecord(t pb.EventType, r *pb.Record) {
+	b.publish(&Event{Type: t, Record: r})
+}
+
+func (b *Broadcaster) publish(e *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e.seq = b.seq
+	e.Cursor = b.cursor(e.seq)
+	b.log[e.seq%uint64(len(b.log))] = e
+	for s := range b.subs {
+		s.send(e)
+	}
+}
+
+// Subscribe returns a Subscription receiving the events published after the
+// given cursor, or after now if the cursor is empty.
+func (b *Broadcaster) Subscribe(cursor string) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	start := b.seq
+	if cursor != "" {
+		seq, err := b.parseCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		// The oldest event still in the log is b.seq-len(b.log)+1, and
+		// subscribing from a cursor requires the event following it.
+		if seq > b.seq || b.seq-seq > uint64(len(b.log)) {
+			return nil, ErrCursorExpired
+		}
+		start = seq
+	}
+
+	s := &Subscription{
+		b:  b,
+		ch: make(chan *Event, len(b.log)),
+	}
+	for seq := start + 1; seq <= b.seq; seq++ {
+		s.send(b.log[seq%uint64(len(b.log))])
+	}
+	b.subs[s] = struct{}{}
+	return s, nil
+}
+
+func (b *Broadcaster) cursor(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s/%d", b.epoch, seq)))
+}
+
+func (b *Broadcaster) parseCursor(cursor string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	epoch, seq, ok := strings.Cut(string(raw), "/")
+	if !ok {
+		return 0, ErrInvalidCursor
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	if epoch != b.epoch {
+		// The cursor was issued by another server, or before a restart.
+		return 0, ErrCursorExpired
+	}
+	return n, nil
+}
+
+// Subscription receives events from a Broadcaster.
+type Subscription struct {
+	b  *Broadcaster
+	ch chan *Event
+	// err is set, and ch closed, when the subscription is terminated by the
+	// Broadcaster. Guarded by b.mu.
+	err    error
+	closed bool
+}
+
+// Events returns the channel of events. The channel is closed if the
+// subscription is terminated, see Err.
+func (s *Subscription) Events() <-chan *Event {
+	return s.ch
+}
+
+// Err returns the reason the events channel was closed.
+func (s *Subscription) Err() error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	return s.err
+}
+
+// Close unsubscribes from the Broadcaster.
+func (s *Subscription) Close() {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	s.terminate(nil)
+}
+
+// send delivers an event without blocking publishers. Subscribers that do not
+// keep up are terminated with ErrOverflow, and may resume from the cursor of
+// the last event they received. Must be called with b.mu held.
+func (s *Subscription) send(e *Event) {
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- e:
+	default:
+		s.terminate(ErrOverflow)
+	}
+}
+
+// terminate must be called with b.mu held.
+func (s *Subscription) terminate(err error) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.err = err
+	delete(s.b.subs, s)
+	close(s.ch)
+}
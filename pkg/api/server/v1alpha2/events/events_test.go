@@ -0,0 +1,135 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+func publish(b *Broadcaster, names ...string) {
+	for _, n := range names {
+		b.PublishResult(pb.EventType_ADDED, &pb.Result{Name: n})
+	}
+}
+
+// receive reads n events from the subscription.
+func receive(t *testing.T, s *Subscription, n int) []*Event {
+	t.Helper()
+	var out []*Event
+	for i := 0; i < n; i++ {
+		select {
+		case e, ok := <-s.Events():
+			if !ok {
+				t.Fatalf("subscription closed: %v", s.Err())
+			}
+			out = append(out, e)
+		default:
+			t.Fatalf("want %d events, got %d", n, len(out))
+		}
+	}
+	return out
+}
+
+func names(events []*Event) string {
+	var s string
+	for _, e := range events {
+		s += e.Result.GetName()
+	}
+	return s
+}
+
+func TestSubscribe(t *testing.T) {
+	b := NewBroadcaster(3)
+	publish(b, "a")
+
+	// Subscribing without a cursor starts from now.
+	s, err := b.Subscribe("")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer s.Close()
+	publish(b, "b", "c")
+	got := receive(t, s, 2)
+	if names(got) != "bc" {
+		t.Errorf("want events bc, got %s", names(got))
+	}
+
+	// Resume after b.
+	r, err := b.Subscribe(got[0].Cursor)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer r.Close()
+	publish(b, "d")
+	if got := names(receive(t, r, 2)); got != "cd" {
+		t.Errorf("want events cd, got %s", got)
+	}
+}
+
+func TestSubscribeCursorErrors(t *testing.T) {
+	b := NewBroadcaster(2)
+	publish(b, "a")
+	s, err := b.Subscribe("")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	publish(b, "b")
+	cursor := receive(t, s, 1)[0].Cursor
+	s.Close()
+
+	// Still in the log.
+	publish(b, "c", "d")
+	if s, err := b.Subscribe(cursor); err != nil {
+		t.Errorf("Subscribe: %v", err)
+	} else {
+		s.Close()
+	}
+	// Evicted from the log.
+	publish(b, "e")
+	if _, err := b.Subscribe(cursor); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("want %v, got %v", ErrCursorExpired, err)
+	}
+	// Issued by another Broadcaster.
+	if _, err := NewBroadcaster(2).Subscribe(cursor); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("want %v, got %v", ErrCursorExpired, err)
+	}
+	if _, err := b.Subscribe("tacocat"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("want %v, got %v", ErrInvalidCursor, err)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	b := NewBroadcaster(2)
+	s, err := b.Subscribe("")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		publish(b, fmt.Sprint(i))
+	}
+	receive(t, s, 2)
+	if _, ok := <-s.Events(); ok {
+		t.Fatal("want closed subscription")
+	}
+	if err := s.Err(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("want %v, got %v", ErrOverflow, err)
+	}
+	// Closing a terminated subscription is a no-op.
+	s.Close()
+}
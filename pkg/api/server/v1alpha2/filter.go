@@ -0,0 +1,64 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/google/cel-go/cel"
+	celenv "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"gorm.io/gorm"
+)
+
+// filter is a parsed List filter. The clause, if set, is applied to the
+// database query. The program, if set, is evaluated against the returned
+// rows for the parts of the filter that could not be translated to SQL.
+type filter struct {
+	clause *cel2sql.Clause
+	prg    cel.Program
+}
+
+// parseFilter compiles the filter string and translates as much of it as
+// possible into a SQL clause over the given view.
+func (s *Server) parseFilter(env *cel.Env, f string, view *cel2sql.View) (*filter, error) {
+	if f == "" {
+		return &filter{}, nil
+	}
+	ast, err := celenv.CompileFilter(env, f)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := celenv.NewProgram(env, ast)
+	if err != nil {
+		return nil, err
+	}
+	clause, complete, err := cel2sql.Translate(ast, view, s.db.Dialector.Name())
+	if err != nil {
+		// Filtering in memory is always possible, so don't fail the request.
+		s.logger.Warnf("failed to translate filter %q to SQL: %v", f, err)
+		return &filter{prg: prg}, nil
+	}
+	if complete {
+		prg = nil
+	}
+	return &filter{clause: clause, prg: prg}, nil
+}
+
+// apply adds the SQL clause of the filter to the query.
+func (f *filter) apply(q *gorm.DB) *gorm.DB {
+	if f.clause == nil {
+		return q
+	}
+	return q.Where(f.clause.SQL, f.clause.Args...)
+}
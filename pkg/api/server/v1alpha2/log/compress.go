@@ -0,0 +1,286 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+)
+
+// compressor compresses the data written to it. Flush writes the data
+// compressed so far, so that it can be decompressed before Close.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+func newCompressor(codec v1alpha2.LogCompression, w io.Writer) (compressor, error) {
+	switch codec {
+	case v1alpha2.GzipLogCompression:
+		return gzip.NewWriter(w), nil
+	case v1alpha2.ZstdLogCompression:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	}
+	return nil, fmt.Errorf("log compression %s is not supported", codec)
+}
+
+func newDecompressor(codec v1alpha2.LogCompression, r io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case v1alpha2.GzipLogCompression:
+		return gzip.NewReader(r)
+	case v1alpha2.ZstdLogCompression:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("log compression %s is not supported", codec)
+}
+
+// ValidateCompression returns an error if the codec is not supported.
+func ValidateCompression(codec v1alpha2.LogCompression) error {
+	switch codec {
+	case "", v1alpha2.GzipLogCompression, v1alpha2.ZstdLogCompression:
+		return nil
+	}
+	return fmt.Errorf("log compression %s is not supported", codec)
+}
+
+// compressedStream compresses the log written to the Stream it wraps, and
+// decompresses the log it reads. Offsets and sizes are those of the
+// uncompressed log.
+type compressedStream struct {
+	Stream
+	codec v1alpha2.LogCompression
+	// size is the size of the uncompressed log once it is complete, or -1
+	// while it is being uploaded.
+	size int64
+	// compressor writes to the wrapped Stream, once data is written to the
+	// log.
+	compressor compressor
+}
+
+func newCompressedStream(s Stream, log *v1alpha2.Log) (Stream, error) {
+	if err := ValidateCompression(log.Status.Compression); err != nil {
+		return nil, err
+	}
+	size := log.Status.Size
+	if log.Status.InProgress {
+		size = -1
+	}
+	return &compressedStream{
+		Stream: s,
+		codec:  log.Status.Compression,
+		size:   size,
+	}, nil
+}
+
+// Compressed returns the Stream of the compressed data of a log, and its
+// codec, if the log is stored compressed.
+func Compressed(s Stream) (Stream, v1alpha2.LogCompression, bool) {
+	cs, ok := s.(*compressedStream)
+	if !ok {
+		return nil, "", false
+	}
+	return cs.Stream, cs.codec, true
+}
+
+// streamWriter writes to the wrapped Stream.
+type streamWriter struct {
+	Stream
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	if _, err := w.Stream.ReadFrom(bytes.NewReader(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ReadFrom compresses the data read from r to the wrapped Stream. The data is
+// flushed, so that the log uploaded so far can be read.
+func (cs *compressedStream) ReadFrom(r io.Reader) (int64, error) {
+	if cs.compressor == nil {
+		c, err := newCompressor(cs.codec, streamWriter{cs.Stream})
+		if err != nil {
+			return 0, err
+		}
+		cs.compressor = c
+	}
+	n, err := io.Copy(cs.compressor, r)
+	if err != nil {
+		return n, err
+	}
+	return n, cs.compressor.Flush()
+}
+
+// Flush ends the compressed data, and flushes the wrapped Stream.
+func (cs *compressedStream) Flush() error {
+	if cs.compressor != nil {
+		if err := cs.compressor.Close(); err != nil {
+			return err
+		}
+		cs.compressor = nil
+	}
+	return cs.Stream.Flush()
+}
+
+// reader returns a reader of the uncompressed log. The log uploaded so far
+// is read if it is not complete.
+func (cs *compressedStream) reader() (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := cs.Stream.WriteTo(pw)
+		pw.CloseWithError(err)
+	}()
+	d, err := newDecompressor(cs.codec, pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+	return &decompressReader{
+		ReadCloser: d,
+		pipe:       pr,
+		partial:    cs.size < 0,
+	}, nil
+}
+
+// decompressReader reads the decompressed log.
+type decompressReader struct {
+	io.ReadCloser
+	pipe *io.PipeReader
+	// partial is set if the compressed data may not be complete.
+	partial bool
+}
+
+func (r *decompressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if r.partial && errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *decompressReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.pipe.Close()
+	if r.partial && errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return err
+}
+
+func (cs *compressedStream) WriteTo(w io.Writer) (int64, error) {
+	return cs.WriteRangeTo(w, 0, -1)
+}
+
+// WriteRangeTo decompresses the log, and writes length bytes from offset to
+// the writer. A negative length writes the rest of the log.
+func (cs *compressedStream) WriteRangeTo(w io.Writer, offset, length int64) (n int64, err error) {
+	if length == 0 {
+		return 0, nil
+	}
+	r, err := cs.reader()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := r.Close()
+		if err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+	if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+		if err == io.EOF {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if length < 0 {
+		return io.Copy(w, r)
+	}
+	n, err = io.CopyN(w, r, length)
+	if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// Size returns the size of the uncompressed log. The log is decompressed to
+// find the size of the log uploaded so far if it is not complete.
+func (cs *compressedStream) Size() (int64, error) {
+	if cs.size >= 0 {
+		return cs.size, nil
+	}
+	stored, err := cs.Stream.Size()
+	if err != nil || stored == 0 {
+		return 0, err
+	}
+	return cs.WriteTo(io.Discard)
+}
+
+// tailOffset returns the same offset as TailOffset, reading the log forward
+// once, since a compressed log cannot be read from an offset without
+// decompressing the data before it.
+func (cs *compressedStream) tailOffset(size, lines, limit int64) (int64, error) {
+	start := int64(0)
+	if limit > 0 && limit < size {
+		start = size - limit
+	}
+	r, err := cs.reader()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	// ends holds the offsets of the last newlines read that end a line,
+	// in a ring from next.
+	var (
+		ends []int64
+		next int
+	)
+	reader := bufio.NewReaderSize(io.LimitReader(r, size), DefaultBufferSize)
+	for pos := int64(0); ; pos++ {
+		b, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		// The newline at the end of the log does not start a line.
+		if b != '\n' || pos < start || pos == size-1 {
+			continue
+		}
+		if int64(len(ends)) < lines {
+			ends = append(ends, pos)
+			continue
+		}
+		ends[next] = pos
+		next = (next + 1) % len(ends)
+	}
+	if int64(len(ends)) < lines {
+		return start, nil
+	}
+	return ends[next] + 1, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+)
+
+func TestCompressedStream(t *testing.T) {
+	data := strings.Repeat("one\ntwo\nthree\n", 100)
+	for _, codec := range []v1alpha2.LogCompression{v1alpha2.GzipLogCompression, v1alpha2.ZstdLogCompression} {
+		t.Run(string(codec), func(t *testing.T) {
+			ctx := context.Background()
+			cfg := &config.Config{LOGS_PATH: t.TempDir(), LOGS_COMPRESSION: string(codec)}
+			log := &v1alpha2.Log{
+				Spec: v1alpha2.LogSpec{
+					Type: v1alpha2.FileLogType,
+				},
+				Status: v1alpha2.LogStatus{
+					Path: "log",
+				},
+			}
+			stream, err := NewStream(ctx, log, cfg, nil)
+			if err != nil {
+				t.Fatalf("NewStream: %v", err)
+			}
+			if log.Status.Compression != codec {
+				t.Fatalf("want compression %s, got %q", codec, log.Status.Compression)
+			}
+
+			// The log uploaded so far can be read before the upload completes.
+			log.Status.InProgress = true
+			reader, err := NewStream(ctx, log, cfg, nil)
+			if err != nil {
+				t.Fatalf("NewStream: %v", err)
+			}
+			half := len(data) / 2
+			if _, err := stream.ReadFrom(strings.NewReader(data[:half])); err != nil {
+				t.Fatalf("ReadFrom: %v", err)
+			}
+			if size, err := reader.Size(); err != nil || size != int64(half) {
+				t.Errorf("Size: want %d, got %d, %v", half, size, err)
+			}
+			buffer := &bytes.Buffer{}
+			if _, err := reader.WriteTo(buffer); err != nil {
+				t.Fatalf("WriteTo: %v", err)
+			}
+			if got := buffer.String(); got != data[:half] {
+				t.Errorf("WriteTo: want %q, got %q", data[:half], got)
+			}
+
+			if n, err := stream.ReadFrom(strings.NewReader(data[half:])); err != nil || n != int64(len(data)-half) {
+				t.Fatalf("ReadFrom: want %d, got %d, %v", len(data)-half, n, err)
+			}
+			if err := stream.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+			stored, err := os.ReadFile(filepath.Join(cfg.LOGS_PATH, "log"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stored) >= len(data) {
+				t.Errorf("want compressed log, got %d bytes for %d bytes of data", len(stored), len(data))
+			}
+
+			log.Status.InProgress = false
+			log.Status.Size = int64(len(data))
+			complete, err := NewStream(ctx, log, cfg, nil)
+			if err != nil {
+				t.Fatalf("NewStream: %v", err)
+			}
+			for _, tc := range []struct {
+				offset, length int64
+			}{
+				{offset: 0, length: -1},
+				{offset: 5, length: -1},
+				{offset: 2, length: 10},
+				{offset: int64(len(data)) - 3, length: 100},
+				{offset: int64(len(data)), length: -1},
+			} {
+				buffer := &bytes.Buffer{}
+				if _, err := complete.WriteRangeTo(buffer, tc.offset, tc.length); err != nil {
+					t.Fatalf("WriteRangeTo: %v", err)
+				}
+				want := data[tc.offset:]
+				if tc.length >= 0 && int64(len(want)) > tc.length {
+					want = want[:tc.length]
+				}
+				if got := buffer.String(); got != want {
+					t.Errorf("WriteRangeTo(%d, %d): want %q, got %q", tc.offset, tc.length, want, got)
+				}
+			}
+
+			uncompressed := &fileStream{path: filepath.Join(t.TempDir(), "log"), size: DefaultBufferSize}
+			if _, err := uncompressed.ReadFrom(strings.NewReader(data)); err != nil {
+				t.Fatal(err)
+			}
+			for _, tc := range []struct {
+				lines, limit int64
+			}{
+				{lines: 1},
+				{lines: 2, limit: 10},
+				{lines: 100},
+				{lines: 1000},
+			} {
+				want, err := TailOffset(uncompressed, int64(len(data)), tc.lines, tc.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := TailOffset(complete, int64(len(data)), tc.lines, tc.limit)
+				if err != nil {
+					t.Fatalf("TailOffset: %v", err)
+				}
+				if got != want {
+					t.Errorf("TailOffset(%d, %d): want %d, got %d", tc.lines, tc.limit, want, got)
+				}
+			}
+
+			compressed, gotCodec, ok := Compressed(complete)
+			if !ok || gotCodec != codec {
+				t.Fatalf("Compressed: want %s, got %q, %t", codec, gotCodec, ok)
+			}
+			buffer.Reset()
+			if _, err := compressed.WriteTo(buffer); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buffer.Bytes(), stored) {
+				t.Error("Compressed: want the stored data")
+			}
+		})
+	}
+}
+
+func TestNewStream_Compression(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{LOGS_PATH: t.TempDir(), LOGS_COMPRESSION: string(v1alpha2.GzipLogCompression)}
+
+	// Logs stored before compression was configured are read as is.
+	log := &v1alpha2.Log{
+		Spec: v1alpha2.LogSpec{
+			Type: v1alpha2.FileLogType,
+		},
+		Status: v1alpha2.LogStatus{
+			Path: "log",
+			Size: 4,
+		},
+	}
+	stream, err := NewStream(ctx, log, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if _, _, ok := Compressed(stream); ok || log.Status.Compression != "" {
+		t.Errorf("want uncompressed log, got compression %q", log.Status.Compression)
+	}
+
+	log.Status.Compression = "lz4"
+	if _, err := NewStream(ctx, log, cfg, nil); err == nil {
+		t.Error("NewStream: want error for unsupported compression")
+	}
+}
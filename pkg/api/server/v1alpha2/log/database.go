@@ -0,0 +1,207 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"gorm.io/gorm"
+)
+
+// dbStreamBatchSize is the number of chunks read from the database at once.
+const dbStreamBatchSize = 32
+
+type dbStream struct {
+	db   *gorm.DB
+	path string
+	size int
+	// buffer holds the data written to the log that does not fill a chunk
+	// yet.
+	buffer bytes.Buffer
+	// next is the chunk written next, and offset its offset in the log. They
+	// are read from the database when the first chunk is written.
+	next   int64
+	offset int64
+	loaded bool
+}
+
+// NewDBStream returns a Stream that stores the log in chunks of
+// LOGS_BUFFER_SIZE bytes in the database.
+func NewDBStream(ctx context.Context, log *v1alpha2.Log, config *config.Config, gdb *gorm.DB) (Stream, error) {
+	if gdb == nil {
+		return nil, fmt.Errorf("log streamer type %s requires a database", v1alpha2.DBLogType)
+	}
+	if log.Status.Path == "" {
+		filePath, err := FilePath(log)
+		if err != nil {
+			return nil, err
+		}
+		log.Status.Path = filePath
+	}
+
+	size := config.LOGS_BUFFER_SIZE
+	if size < 1 {
+		size = DefaultBufferSize
+	}
+
+	return &dbStream{
+		db:   gdb.WithContext(ctx),
+		path: log.Status.Path,
+		size: size,
+	}, nil
+}
+
+func (*dbStream) Type() string {
+	return string(v1alpha2.DBLogType)
+}
+
+func (ds *dbStream) WriteTo(w io.Writer) (int64, error) {
+	return ds.WriteRangeTo(w, 0, -1)
+}
+
+// WriteRangeTo writes length bytes of the log from offset, or the rest of the
+// log if length is negative. Only the chunks holding the range are read.
+func (ds *dbStream) WriteRangeTo(w io.Writer, offset, length int64) (n int64, err error) {
+	if length == 0 {
+		return 0, nil
+	}
+	end := int64(-1)
+	if length > 0 {
+		end = offset + length
+	}
+	pos := offset
+	for last := int64(-1); ; {
+		q := ds.db.Where("path = ? AND chunk > ? AND byte_offset + size > ?", ds.path, last, pos)
+		if end >= 0 {
+			q = q.Where("byte_offset < ?", end)
+		}
+		var chunks []db.LogChunk
+		if err := q.Order("chunk").Limit(dbStreamBatchSize).Find(&chunks).Error; err != nil {
+			return n, fmt.Errorf("failed to read log chunks of %s: %w", ds.path, err)
+		}
+		for _, c := range chunks {
+			lo, hi := int64(0), int64(len(c.Data))
+			if pos > c.ByteOffset {
+				lo = pos - c.ByteOffset
+			}
+			if end >= 0 && end-c.ByteOffset < hi {
+				hi = end - c.ByteOffset
+			}
+			if lo < hi {
+				written, err := w.Write(c.Data[lo:hi])
+				n += int64(written)
+				if err != nil {
+					return n, err
+				}
+			}
+			pos = c.ByteOffset + hi
+			last = c.Chunk
+		}
+		if len(chunks) < dbStreamBatchSize {
+			return n, nil
+		}
+	}
+}
+
+// Size returns the size of the log written to the database.
+func (ds *dbStream) Size() (int64, error) {
+	var size int64
+	if err := ds.db.Model(&db.LogChunk{}).
+		Where("path = ?", ds.path).
+		Select("COALESCE(MAX(byte_offset + size), 0)").
+		Scan(&size).Error; err != nil {
+		return 0, fmt.Errorf("failed to read the size of log %s: %w", ds.path, err)
+	}
+	return size, nil
+}
+
+// ReadFrom reads the log contents from the provided io.Reader, and writes
+// them to the database once they fill a chunk. The rest is written on Flush.
+func (ds *dbStream) ReadFrom(r io.Reader) (int64, error) {
+	n, err := ds.buffer.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	for ds.buffer.Len() >= ds.size {
+		if err := ds.writeChunk(ds.buffer.Next(ds.size)); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// writeChunk appends a chunk of data to the log.
+func (ds *dbStream) writeChunk(data []byte) error {
+	if !ds.loaded {
+		last := &db.LogChunk{}
+		err := ds.db.Where("path = ?", ds.path).Order("chunk DESC").Limit(1).Find(last).Error
+		if err != nil {
+			return fmt.Errorf("failed to read log chunks of %s: %w", ds.path, err)
+		}
+		if last.Path != "" {
+			ds.next = last.Chunk + 1
+			ds.offset = last.ByteOffset + last.Size
+		}
+		ds.loaded = true
+	}
+	chunk := &db.LogChunk{
+		Path:       ds.path,
+		Chunk:      ds.next,
+		ByteOffset: ds.offset,
+		Size:       int64(len(data)),
+		// The buffer may reuse the data once it is written.
+		Data: append([]byte(nil), data...),
+	}
+	if err := ds.db.Create(chunk).Error; err != nil {
+		return fmt.Errorf("failed to write log chunk of %s: %w", ds.path, err)
+	}
+	ds.next++
+	ds.offset += chunk.Size
+	return nil
+}
+
+func (ds *dbStream) Delete() error {
+	return ds.db.Where("path = ?", ds.path).Delete(&db.LogChunk{}).Error
+}
+
+// listChunkPaths returns the logs stored in the database.
+func listChunkPaths(ctx context.Context, gdb *gorm.DB) ([]StoredLog, error) {
+	if gdb == nil {
+		return nil, fmt.Errorf("log streamer type %s requires a database", v1alpha2.DBLogType)
+	}
+	var paths []string
+	if err := gdb.WithContext(ctx).Model(&db.LogChunk{}).Distinct().Pluck("path", &paths).Error; err != nil {
+		return nil, fmt.Errorf("failed to list log chunks: %w", err)
+	}
+	logs := make([]StoredLog, 0, len(paths))
+	for _, p := range paths {
+		logs = append(logs, StoredLog{Path: p})
+	}
+	return logs, nil
+}
+
+// Flush writes the buffered data that does not fill a chunk.
+func (ds *dbStream) Flush() error {
+	if ds.buffer.Len() == 0 {
+		return nil
+	}
+	return ds.writeChunk(ds.buffer.Next(ds.buffer.Len()))
+}
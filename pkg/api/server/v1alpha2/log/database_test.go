@@ -0,0 +1,186 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/migrate"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+)
+
+func TestDBStream(t *testing.T) {
+	ctx := context.Background()
+	gdb := test.NewDB(t)
+	m, err := migrate.New(gdb)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	cfg := &config.Config{LOGS_BUFFER_SIZE: 4}
+	newLog := func(path string) *v1alpha2.Log {
+		return &v1alpha2.Log{
+			Spec: v1alpha2.LogSpec{
+				Type: v1alpha2.DBLogType,
+			},
+			Status: v1alpha2.LogStatus{
+				Path: path,
+			},
+		}
+	}
+
+	stream, err := NewStream(ctx, newLog("a"), cfg, gdb)
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if stream.Type() != string(v1alpha2.DBLogType) {
+		t.Errorf("want type %s, got %s", v1alpha2.DBLogType, stream.Type())
+	}
+	// Chunks of other logs are not read.
+	other, err := NewStream(ctx, newLog("b"), cfg, gdb)
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if _, err := other.ReadFrom(strings.NewReader("other log")); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if err := other.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	data := "one\ntwo\nthree\n"
+	if n, err := stream.ReadFrom(strings.NewReader(data[:5])); err != nil || n != 5 {
+		t.Fatalf("ReadFrom: want 5, got %d, %v", n, err)
+	}
+	if n, err := stream.ReadFrom(strings.NewReader(data[5:])); err != nil || n != int64(len(data)-5) {
+		t.Fatalf("ReadFrom: want %d, got %d, %v", len(data)-5, n, err)
+	}
+	// The data that does not fill a chunk is written on Flush.
+	if size, err := stream.Size(); err != nil || size != 12 {
+		t.Errorf("Size before Flush: want 12, got %d, %v", size, err)
+	}
+	if err := stream.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	// Data is appended to the log by new streams.
+	appended, err := NewStream(ctx, newLog("a"), cfg, gdb)
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if _, err := appended.ReadFrom(strings.NewReader("four\n")); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if err := appended.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	data += "four\n"
+
+	var chunks int64
+	if err := gdb.Model(&db.LogChunk{}).Where("path = ?", "a").Count(&chunks).Error; err != nil {
+		t.Fatal(err)
+	}
+	if chunks != 6 {
+		t.Errorf("want 6 chunks, got %d", chunks)
+	}
+	if size, err := stream.Size(); err != nil || size != int64(len(data)) {
+		t.Errorf("Size: want %d, got %d, %v", len(data), size, err)
+	}
+	for _, tc := range []struct {
+		offset, length int64
+	}{
+		{offset: 0, length: -1},
+		{offset: 5, length: -1},
+		{offset: 2, length: 10},
+		{offset: 13, length: 2},
+		{offset: int64(len(data)) - 3, length: 100},
+		{offset: int64(len(data)), length: -1},
+	} {
+		buffer := &bytes.Buffer{}
+		if _, err := stream.WriteRangeTo(buffer, tc.offset, tc.length); err != nil {
+			t.Fatalf("WriteRangeTo: %v", err)
+		}
+		want := data[tc.offset:]
+		if tc.length >= 0 && int64(len(want)) > tc.length {
+			want = want[:tc.length]
+		}
+		if got := buffer.String(); got != want {
+			t.Errorf("WriteRangeTo(%d, %d): want %q, got %q", tc.offset, tc.length, want, got)
+		}
+	}
+	if offset, err := TailOffset(stream, int64(len(data)), 2, 0); err != nil || offset != 8 {
+		t.Errorf("TailOffset: want 8, got %d, %v", offset, err)
+	}
+
+	stored, err := ListStored(ctx, v1alpha2.DBLogType, cfg, gdb)
+	if err != nil {
+		t.Fatalf("ListStored: %v", err)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].Path < stored[j].Path })
+	if diff := cmp.Diff([]StoredLog{{Path: "a"}, {Path: "b"}}, stored); diff != "" {
+		t.Errorf("ListStored (-want, +got): %s", diff)
+	}
+
+	if err := stream.Delete(); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if size, err := stream.Size(); err != nil || size != 0 {
+		t.Errorf("Size after Delete: want 0, got %d, %v", size, err)
+	}
+	if size, err := other.Size(); err != nil || size != int64(len("other log")) {
+		t.Errorf("Size of other log: want %d, got %d, %v", len("other log"), size, err)
+	}
+}
+
+func TestDBStream_Batches(t *testing.T) {
+	ctx := context.Background()
+	gdb := test.NewDB(t)
+	m, err := migrate.New(gdb)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	stream, err := NewStream(ctx, &v1alpha2.Log{
+		Spec:   v1alpha2.LogSpec{Type: v1alpha2.DBLogType},
+		Status: v1alpha2.LogStatus{Path: "a"},
+	}, &config.Config{LOGS_BUFFER_SIZE: 1}, gdb)
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	// The log has more chunks than are read at once.
+	data := strings.Repeat("x", dbStreamBatchSize*2+3)
+	if _, err := stream.ReadFrom(strings.NewReader(data)); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	buffer := &bytes.Buffer{}
+	if n, err := stream.WriteTo(buffer); err != nil || n != int64(len(data)) {
+		t.Fatalf("WriteTo: want %d, got %d, %v", len(data), n, err)
+	}
+	if buffer.String() != data {
+		t.Errorf("WriteTo: want %q, got %q", data, buffer.String())
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 )
@@ -47,6 +49,12 @@ func (*fileStream) Type() string {
 // WriteTo reads the contents of the TaskRun log file and writes them to the provided writer, such
 // as os.Stdout.
 func (fs *fileStream) WriteTo(w io.Writer) (n int64, err error) {
+	return fs.WriteRangeTo(w, 0, -1)
+}
+
+// WriteRangeTo reads length bytes of the TaskRun log file from offset, or the rest of the file if
+// length is negative, and writes them to the provided writer.
+func (fs *fileStream) WriteRangeTo(w io.Writer, offset, length int64) (n int64, err error) {
 	_, err = os.Stat(fs.path)
 	if err != nil {
 		return 0, fmt.Errorf("failed to stat %s: %v", fs.path, err)
@@ -61,12 +69,32 @@ func (fs *fileStream) WriteTo(w io.Writer) (n int64, err error) {
 			err = closeErr
 		}
 	}()
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek file %s: %v", fs.path, err)
+	}
+	var r io.Reader = file
+	if length >= 0 {
+		r = io.LimitReader(file, length)
+	}
 	// Use the buffered reader to ensure file contents are not read entirely into memory
-	reader := bufio.NewReaderSize(file, fs.size)
+	reader := bufio.NewReaderSize(r, fs.size)
 	n, err = reader.WriteTo(w)
 	return
 }
 
+// Size returns the size of the TaskRun log file.
+func (fs *fileStream) Size() (int64, error) {
+	info, err := os.Stat(fs.path)
+	// The file is only created once data is written to the log.
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %v", fs.path, err)
+	}
+	return info.Size(), nil
+}
+
 // ReadFrom reads the log contents from the provided io.Reader, and writes them to the TaskRun log
 // file on disk.
 func (fs *fileStream) ReadFrom(r io.Reader) (n int64, err error) {
@@ -102,6 +130,33 @@ func (fs *fileStream) Delete() error {
 	return os.RemoveAll(fs.path)
 }
 
+// listFiles returns the log files under LOGS_PATH.
+func listFiles(config *config.Config) ([]StoredLog, error) {
+	prefix := logsPrefix(config.LOGS_PATH)
+	var logs []StoredLog
+	err := filepath.WalkDir(config.LOGS_PATH, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		logs = append(logs, StoredLog{
+			Path:    strings.TrimPrefix(path, prefix),
+			ModTime: info.ModTime(),
+		})
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files under %s: %w", config.LOGS_PATH, err)
+	}
+	return logs, nil
+}
+
 func (fs *fileStream) Flush() error {
 	return nil
 }
@@ -2,10 +2,16 @@ package log
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 )
 
 func TestFileStream_WriteTo(t *testing.T) {
@@ -35,6 +41,51 @@ func TestFileStream_WriteTo(t *testing.T) {
 	}
 }
 
+func TestFileStream_WriteRangeTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte("test data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stream := fileStream{
+		path: path,
+	}
+
+	size, err := stream.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 9 {
+		t.Errorf("want size 9, got %d", size)
+	}
+	for _, tc := range []struct {
+		offset, length int64
+		want           string
+	}{
+		{offset: 0, length: -1, want: "test data"},
+		{offset: 5, length: -1, want: "data"},
+		{offset: 0, length: 4, want: "test"},
+		{offset: 2, length: 4, want: "st d"},
+		{offset: 5, length: 100, want: "data"},
+		{offset: 9, length: -1, want: ""},
+	} {
+		buffer := &bytes.Buffer{}
+		if _, err := stream.WriteRangeTo(buffer, tc.offset, tc.length); err != nil {
+			t.Fatal(err)
+		}
+		if got := buffer.String(); got != tc.want {
+			t.Errorf("WriteRangeTo(%d, %d): want: %s, got: %s", tc.offset, tc.length, tc.want, got)
+		}
+	}
+
+	// Logs are empty until data is written to them.
+	missing := fileStream{
+		path: filepath.Join(t.TempDir(), "missing"),
+	}
+	if size, err := missing.Size(); size != 0 || err != nil {
+		t.Errorf("Size of missing file: want 0, nil, got %d, %v", size, err)
+	}
+}
+
 func TestFileStream_ReadFrom(t *testing.T) {
 	want := []byte("test data")
 	buffer := &bytes.Buffer{}
@@ -77,3 +128,59 @@ func TestFileStream_Delete(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestListStored_File(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg := &config.Config{LOGS_PATH: filepath.Join(dir, "logs")}
+
+	// Logs are not listed before any is stored.
+	if got, err := ListStored(ctx, v1alpha2.FileLogType, cfg, nil); err != nil || len(got) != 0 {
+		t.Errorf("ListStored: want no logs, got %v, %v", got, err)
+	}
+
+	var want []string
+	for _, p := range []string{"foo/uid-a/log", "foo/uid-b/log", "bar/uid-c/log"} {
+		l := &v1alpha2.Log{
+			Spec:   v1alpha2.LogSpec{Type: v1alpha2.FileLogType},
+			Status: v1alpha2.LogStatus{Path: p},
+		}
+		stream, err := NewStream(ctx, l, cfg, nil)
+		if err != nil {
+			t.Fatalf("NewStream: %v", err)
+		}
+		if _, err := stream.ReadFrom(bytes.NewBufferString("log")); err != nil {
+			t.Fatalf("ReadFrom: %v", err)
+		}
+		path, err := StoredPath(l, cfg)
+		if err != nil {
+			t.Fatalf("StoredPath: %v", err)
+		}
+		want = append(want, path)
+	}
+	// Files outside of LOGS_PATH are not listed.
+	if err := os.WriteFile(filepath.Join(dir, "other"), []byte("other"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := ListStored(ctx, v1alpha2.FileLogType, cfg, nil)
+	if err != nil {
+		t.Fatalf("ListStored: %v", err)
+	}
+	var got []string
+	for _, l := range stored {
+		if l.ModTime.IsZero() {
+			t.Errorf("%s: want modification time", l.Path)
+		}
+		got = append(got, l.Path)
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListStored (-want, +got): %s", diff)
+	}
+
+	if _, err := ListStored(ctx, v1alpha2.FileLogType, &config.Config{}, nil); err == nil {
+		t.Error("ListStored: want error without LOGS_PATH")
+	}
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,9 +11,12 @@ import (
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 	"io"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"time"
 )
 
 const (
@@ -46,6 +50,11 @@ func FormatName(parent, name string) string {
 type Stream interface {
 	io.ReaderFrom
 	io.WriterTo
+	// WriteRangeTo writes length bytes of the stored log, starting at offset,
+	// to the writer. A negative length writes the rest of the log.
+	WriteRangeTo(w io.Writer, offset, length int64) (int64, error)
+	// Size returns the size of the stored log in bytes.
+	Size() (int64, error)
 	Type() string
 	Delete() error
 	Flush() error
@@ -61,15 +70,111 @@ type Stream interface {
 // LogStreamers do not need to receive and store data from the provided source.
 //
 // NewStream may mutate the Log object's status, to provide implementation information
-// for reading and writing files.
-func NewStream(ctx context.Context, log *v1alpha2.Log, config *config.Config) (Stream, error) {
+// for reading and writing files. The database is used by logs of DBLogType.
+//
+// Logs are compressed with the codec of LOGS_COMPRESSION when they are first
+// written. The codec is recorded in the Log object's status, so that logs are
+// read with the codec they were stored with.
+func NewStream(ctx context.Context, log *v1alpha2.Log, config *config.Config, gdb *gorm.DB) (Stream, error) {
+	var (
+		stream Stream
+		err    error
+	)
 	switch log.Spec.Type {
 	case v1alpha2.FileLogType:
-		return NewFileStream(ctx, log, config)
+		stream, err = NewFileStream(ctx, log, config)
 	case v1alpha2.S3LogType:
-		return NewS3Stream(ctx, log, config)
+		stream, err = NewS3Stream(ctx, log, config)
+	case v1alpha2.DBLogType:
+		stream, err = NewDBStream(ctx, log, config, gdb)
+	default:
+		return nil, fmt.Errorf("log streamer type %s is not supported", log.Spec.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if log.Status.Size == 0 && !log.Status.InProgress && log.Status.Compression == "" {
+		log.Status.Compression = v1alpha2.LogCompression(config.LOGS_COMPRESSION)
+	}
+	if log.Status.Compression == "" {
+		return stream, nil
+	}
+	return newCompressedStream(stream, log)
+}
+
+// TailOffset returns the offset of the last lines of the stored log of the
+// given size. If limit is positive, at most limit bytes are read from the end
+// of the log: if they do not hold all the lines, the offset is limit bytes
+// before the end of the log.
+func TailOffset(s Stream, size, lines, limit int64) (int64, error) {
+	if cs, ok := s.(*compressedStream); ok {
+		return cs.tailOffset(size, lines, limit)
+	}
+	start := int64(0)
+	if limit > 0 && limit < size {
+		start = size - limit
+	}
+	var (
+		buffer bytes.Buffer
+		found  int64
+	)
+	// Read the log backwards, counting the newlines that end the lines before
+	// the last ones.
+	for end := size; end > start; {
+		n := end - start
+		if n > DefaultBufferSize {
+			n = DefaultBufferSize
+		}
+		buffer.Reset()
+		if _, err := s.WriteRangeTo(&buffer, end-n, n); err != nil {
+			return 0, err
+		}
+		b := buffer.Bytes()
+		for i := len(b) - 1; i >= 0; i-- {
+			pos := end - n + int64(i)
+			// The newline at the end of the log does not start a line.
+			if b[i] != '\n' || pos == size-1 {
+				continue
+			}
+			if found++; found == lines {
+				return pos + 1, nil
+			}
+		}
+		end -= n
+	}
+	return start, nil
+}
+
+// Resume prepares the Stream for an upload of the log starting at offset.
+// An offset of 0 replaces the stored log, and the size of the stored log
+// resumes its upload. The stored log before offset is written to w, e.g. to
+// compute its checksum.
+func Resume(s Stream, offset int64, w io.Writer) error {
+	size, err := s.Size()
+	if err != nil {
+		return err
+	}
+	if offset == 0 {
+		if size == 0 {
+			return nil
+		}
+		return s.Delete()
+	}
+	if offset != size {
+		return status.Errorf(codes.FailedPrecondition, "cannot resume the upload of the log at offset %d: %d bytes are stored", offset, size)
+	}
+	stored := s
+	if cs, ok := s.(*compressedStream); ok {
+		stored = cs.Stream
 	}
-	return nil, fmt.Errorf("log streamer type %s is not supported", log.Spec.Type)
+	if _, ok := stored.(*s3Stream); ok {
+		// The object is replaced once the upload completes, so the stored
+		// log is uploaded again first.
+		_, err = s.WriteRangeTo(io.MultiWriter(w, streamWriter{s}), 0, size)
+		return err
+	}
+	_, err = s.WriteRangeTo(w, 0, size)
+	return err
 }
 
 func ToStorage(record *pb.Record, config *config.Config) ([]byte, error) {
@@ -91,7 +196,7 @@ func ToStorage(record *pb.Record, config *config.Config) ([]byte, error) {
 	return json.Marshal(log)
 }
 
-func ToStream(ctx context.Context, record *db.Record, config *config.Config) (Stream, *v1alpha2.Log, error) {
+func ToStream(ctx context.Context, record *db.Record, config *config.Config, gdb *gorm.DB) (Stream, *v1alpha2.Log, error) {
 	if record.Type != v1alpha2.LogRecordType {
 		return nil, nil, fmt.Errorf("record type %s cannot stream logs", record.Type)
 	}
@@ -100,7 +205,7 @@ func ToStream(ctx context.Context, record *db.Record, config *config.Config) (St
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not decode Log record: %v", err)
 	}
-	stream, err := NewStream(ctx, log, config)
+	stream, err := NewStream(ctx, log, config, gdb)
 	return stream, log, err
 }
 
@@ -111,3 +216,62 @@ func FilePath(log *v1alpha2.Log) (string, error) {
 	}
 	return filePath, nil
 }
+
+// StoredLog is a log found in the log storage.
+type StoredLog struct {
+	// Path locates the log like the path in the status of its Log.
+	Path string
+	// ModTime is the time the log was last written, or zero if the storage
+	// does not record it.
+	ModTime time.Time
+}
+
+// ListStored returns the logs of the given type found in the log storage.
+// Logs stored in files or S3 are only listed under LOGS_PATH, which must be
+// set, so that other data of the storage is never mistaken for logs.
+func ListStored(ctx context.Context, logType v1alpha2.LogType, config *config.Config, gdb *gorm.DB) ([]StoredLog, error) {
+	switch logType {
+	case v1alpha2.FileLogType, v1alpha2.S3LogType:
+		if config.LOGS_PATH == "" {
+			return nil, fmt.Errorf("LOGS_PATH must be set to list logs of type %s", logType)
+		}
+	}
+	switch logType {
+	case v1alpha2.FileLogType:
+		return listFiles(config)
+	case v1alpha2.S3LogType:
+		client, err := initConfig(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		return listObjects(ctx, client, config)
+	case v1alpha2.DBLogType:
+		return listChunkPaths(ctx, gdb)
+	}
+	return nil, fmt.Errorf("log streamer type %s is not supported", logType)
+}
+
+// StoredPath returns the path that ListStored returns for the stored log of
+// the Log.
+func StoredPath(log *v1alpha2.Log, config *config.Config) (string, error) {
+	path := log.Status.Path
+	if path == "" {
+		var err error
+		if path, err = FilePath(log); err != nil {
+			return "", err
+		}
+	}
+	if log.Spec.Type == v1alpha2.DBLogType {
+		return path, nil
+	}
+	return strings.TrimPrefix(filepath.Join(config.LOGS_PATH, path), logsPrefix(config.LOGS_PATH)), nil
+}
+
+// logsPrefix returns the prefix of the paths of the files, or the keys of the
+// objects, stored under LOGS_PATH.
+func logsPrefix(logsPath string) string {
+	if logsPath == "" {
+		return ""
+	}
+	return strings.TrimSuffix(filepath.Clean(logsPath), "/") + "/"
+}
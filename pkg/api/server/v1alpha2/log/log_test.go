@@ -10,8 +10,13 @@ import (
 	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	"github.com/tektoncd/results/pkg/internal/jsonutil"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -152,6 +157,14 @@ func (s *mockStream) ReadFrom(io.Reader) (int64, error) {
 	return 0, fmt.Errorf("not implemented")
 }
 
+func (s *mockStream) WriteRangeTo(io.Writer, int64, int64) (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
+
+func (s *mockStream) Size() (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
+
 func (s *mockStream) Type() string {
 	return s.streamType
 }
@@ -226,7 +239,7 @@ func TestToStream(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			streamer, _, err := ToStream(context.TODO(), tc.in, &config.Config{})
+			streamer, _, err := ToStream(context.TODO(), tc.in, &config.Config{}, nil)
 			if err != nil {
 				if !tc.expectErr {
 					t.Errorf("unexpected error: %v", err)
@@ -239,3 +252,159 @@ func TestToStream(t *testing.T) {
 		})
 	}
 }
+
+func TestTailOffset(t *testing.T) {
+	// A log larger than the buffer, so that it is read in several chunks.
+	var long strings.Builder
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&long, "line %d\n", i)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		log   string
+		lines int64
+		limit int64
+		want  string
+	}{
+		{
+			name:  "trailing newline",
+			log:   "a\nb\nc\n",
+			lines: 2,
+			want:  "b\nc\n",
+		},
+		{
+			name:  "no trailing newline",
+			log:   "a\nb\nc",
+			lines: 2,
+			want:  "b\nc",
+		},
+		{
+			name:  "more lines than the log",
+			log:   "a\nb\nc\n",
+			lines: 10,
+			want:  "a\nb\nc\n",
+		},
+		{
+			name:  "empty lines",
+			log:   "a\n\n\n",
+			lines: 2,
+			want:  "\n\n",
+		},
+		{
+			name:  "limit",
+			log:   "aaaa\nbbbb\ncccc\n",
+			lines: 2,
+			limit: 8,
+			want:  "bb\ncccc\n",
+		},
+		{
+			name:  "limit larger than the lines",
+			log:   "aaaa\nbbbb\ncccc\n",
+			lines: 1,
+			limit: 8,
+			want:  "cccc\n",
+		},
+		{
+			name:  "several chunks",
+			log:   long.String(),
+			lines: 5000,
+			want:  long.String()[strings.Index(long.String(), "line 5000\n"):],
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "log")
+			if err := os.WriteFile(path, []byte(tc.log), 0644); err != nil {
+				t.Fatal(err)
+			}
+			stream := &fileStream{path: path}
+
+			offset, err := TailOffset(stream, int64(len(tc.log)), tc.lines, tc.limit)
+			if err != nil {
+				t.Fatalf("TailOffset: %v", err)
+			}
+			if got := tc.log[offset:]; got != tc.want {
+				t.Errorf("want: %q, got: %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	const stored = "hello "
+	newFileStream := func(t *testing.T) *fileStream {
+		t.Helper()
+		s := &fileStream{path: filepath.Join(t.TempDir(), "log")}
+		if err := os.WriteFile(s.path, []byte(stored), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		s := newFileStream(t)
+		var read bytes.Buffer
+		if err := Resume(s, 0, &read); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+		if _, err := os.Stat(s.path); !os.IsNotExist(err) {
+			t.Errorf("want the stored log deleted, got %v", err)
+		}
+		if read.Len() != 0 {
+			t.Errorf("want no stored log read, got %q", read.String())
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		s := newFileStream(t)
+		var read bytes.Buffer
+		if err := Resume(s, int64(len(stored)), &read); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+		if read.String() != stored {
+			t.Errorf("want the stored log read, got %q", read.String())
+		}
+		if _, err := s.ReadFrom(strings.NewReader("world")); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := os.ReadFile(s.path); string(got) != stored+"world" {
+			t.Errorf("want the log appended to, got %q", got)
+		}
+	})
+
+	t.Run("offset mismatch", func(t *testing.T) {
+		s := newFileStream(t)
+		if err := Resume(s, 3, io.Discard); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("want FailedPrecondition, got %v", err)
+		}
+		if got, _ := os.ReadFile(s.path); string(got) != stored {
+			t.Errorf("want the stored log kept, got %q", got)
+		}
+	})
+
+	t.Run("S3", func(t *testing.T) {
+		c := &config.Config{S3_BUCKET_NAME: "test-bucket"}
+		s := &s3Stream{
+			config:        c,
+			bucket:        c.S3_BUCKET_NAME,
+			key:           "log",
+			partNumber:    1,
+			multiPartSize: 1024,
+			client: &mockS3Client{
+				t:      t,
+				bucket: c.S3_BUCKET_NAME,
+				key:    "log",
+				body:   []byte(stored),
+			},
+		}
+		var read bytes.Buffer
+		if err := Resume(s, int64(len(stored)), &read); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+		// Objects are replaced by uploads, so the stored log is uploaded
+		// again.
+		if s.buffer.String() != stored || read.String() != stored {
+			t.Errorf("want the stored log uploaded again, got %q", s.buffer.String())
+		}
+	})
+}
@@ -5,8 +5,12 @@ import (
 	"bytes"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -17,6 +21,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	server "github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -29,15 +35,23 @@ type s3Client interface {
 	CreateMultipartUpload(context.Context, *s3.CreateMultipartUploadInput, ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
 	DeleteObject(context.Context, *s3.DeleteObjectInput, ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
 	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	ListObjectsV2(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 	UploadPart(context.Context, *s3.UploadPartInput, ...func(*s3.Options)) (*s3.UploadPartOutput, error)
 }
 
+// s3Presigner pre-signs requests to S3, as s3.PresignClient does.
+type s3Presigner interface {
+	PresignGetObject(context.Context, *s3.GetObjectInput, ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
 type s3Stream struct {
 	config        *server.Config
 	ctx           context.Context
 	size          int
 	buffer        bytes.Buffer
 	client        s3Client
+	presigner     s3Presigner
 	bucket        string
 	key           string
 	partNumber    int32
@@ -63,16 +77,6 @@ func NewS3Stream(ctx context.Context, log *v1alpha2.Log, config *server.Config)
 		return nil, err
 	}
 
-	multipartUpload, err := client.CreateMultipartUpload(ctx,
-		&s3.CreateMultipartUploadInput{
-			Bucket: &config.S3_BUCKET_NAME,
-			Key:    &filePath,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	multiPartSize := config.S3_MULTI_PART_SIZE
 	if multiPartSize == 0 {
 		multiPartSize = DefaultS3MultiPartSize
@@ -90,8 +94,8 @@ func NewS3Stream(ctx context.Context, log *v1alpha2.Log, config *server.Config)
 		bucket:        config.S3_BUCKET_NAME,
 		key:           filePath,
 		buffer:        bytes.Buffer{},
-		uploadId:      *multipartUpload.UploadId,
 		client:        client,
+		presigner:     s3.NewPresignClient(client),
 		partNumber:    1,
 		multiPartSize: multiPartSize,
 	}
@@ -132,10 +136,28 @@ func (*s3Stream) Type() string {
 }
 
 func (s3s *s3Stream) WriteTo(w io.Writer) (n int64, err error) {
-	outPut, err := s3s.client.GetObject(s3s.ctx, &s3.GetObjectInput{
+	return s3s.WriteRangeTo(w, 0, -1)
+}
+
+// WriteRangeTo writes length bytes of the object from offset, or the rest of
+// the object if length is negative, using a ranged GetObject request.
+func (s3s *s3Stream) WriteRangeTo(w io.Writer, offset, length int64) (n int64, err error) {
+	if length == 0 {
+		return 0, nil
+	}
+	input := &s3.GetObjectInput{
 		Bucket: &s3s.bucket,
 		Key:    &s3s.key,
-	})
+	}
+	if offset > 0 || length > 0 {
+		// See https://www.rfc-editor.org/rfc/rfc9110.html#name-byte-ranges
+		byteRange := fmt.Sprintf("bytes=%d-", offset)
+		if length > 0 {
+			byteRange += strconv.FormatInt(offset+length-1, 10)
+		}
+		input.Range = &byteRange
+	}
+	outPut, err := s3s.client.GetObject(s3s.ctx, input)
 	if err != nil {
 		return 0, fmt.Errorf(err.Error())
 	}
@@ -150,6 +172,23 @@ func (s3s *s3Stream) WriteTo(w io.Writer) (n int64, err error) {
 	return
 }
 
+// Size returns the size of the object.
+func (s3s *s3Stream) Size() (int64, error) {
+	head, err := s3s.client.HeadObject(s3s.ctx, &s3.HeadObjectInput{
+		Bucket: &s3s.bucket,
+		Key:    &s3s.key,
+	})
+	// The object is only visible once its multipart upload completes.
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return head.ContentLength, nil
+}
+
 func (s3s *s3Stream) ReadFrom(r io.Reader) (int64, error) {
 	n, err := s3s.buffer.ReadFrom(r)
 	if err != nil {
@@ -172,6 +211,20 @@ func (s3s *s3Stream) ReadFrom(r io.Reader) (int64, error) {
 }
 
 func (s3s *s3Stream) uploadMultiPart(reader io.Reader, partNumber int32, partSize int64) error {
+	// The multipart upload is created when the first part is uploaded, so
+	// that streams that only read or delete the log do not leave incomplete
+	// uploads behind.
+	if s3s.uploadId == "" {
+		multipartUpload, err := s3s.client.CreateMultipartUpload(s3s.ctx, &s3.CreateMultipartUploadInput{
+			Bucket: &s3s.bucket,
+			Key:    &s3s.key,
+		})
+		if err != nil {
+			return err
+		}
+		s3s.uploadId = *multipartUpload.UploadId
+	}
+
 	part, err := s3s.client.UploadPart(s3s.ctx, &s3.UploadPartInput{
 		UploadId:      &s3s.uploadId,
 		Bucket:        &s3s.bucket,
@@ -214,6 +267,51 @@ func (s3s *s3Stream) Flush() error {
 	return err
 }
 
+// listObjects returns the objects stored under LOGS_PATH in the bucket.
+func listObjects(ctx context.Context, client s3Client, config *server.Config) ([]StoredLog, error) {
+	prefix := logsPrefix(config.LOGS_PATH)
+	input := &s3.ListObjectsV2Input{
+		Bucket: &config.S3_BUCKET_NAME,
+		Prefix: &prefix,
+	}
+	var logs []StoredLog
+	for p := s3.NewListObjectsV2Paginator(client, input); p.HasMorePages(); {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+		for _, o := range page.Contents {
+			l := StoredLog{Path: strings.TrimPrefix(aws.ToString(o.Key), prefix)}
+			if o.LastModified != nil {
+				l.ModTime = *o.LastModified
+			}
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+// DownloadURL returns a pre-signed URL to download the stored log of the
+// Stream, which expires after the given duration. Only logs stored in S3 can
+// be downloaded. Compressed logs are downloaded as they are stored.
+func DownloadURL(s Stream, expires time.Duration) (string, error) {
+	if cs, ok := s.(*compressedStream); ok {
+		s = cs.Stream
+	}
+	s3s, ok := s.(*s3Stream)
+	if !ok {
+		return "", status.Errorf(codes.FailedPrecondition, "logs of type %s cannot be downloaded", s.Type())
+	}
+	req, err := s3s.presigner.PresignGetObject(s3s.ctx, &s3.GetObjectInput{
+		Bucket: &s3s.bucket,
+		Key:    &s3s.key,
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
 func (s3s *s3Stream) Delete() error {
 	_, err := s3s.client.DeleteObject(s3s.ctx, &s3.DeleteObjectInput{
 		Bucket: &s3s.bucket,
@@ -3,11 +3,22 @@ package log
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/google/go-cmp/cmp"
 	server "github.com/tektoncd/results/pkg/api/server/config"
+	servertest "github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 type mockS3Client struct {
@@ -16,6 +27,7 @@ type mockS3Client struct {
 	body       []byte
 	uploadId   string
 	partNumber int32
+	objects    []types.Object
 	t          *testing.T
 }
 
@@ -41,11 +53,41 @@ func (m *mockS3Client) DeleteObject(ctx context.Context, params *s3.DeleteObject
 
 func (m *mockS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
 	m.checkParams(params.Bucket, params.Key)
+	body := m.body
+	if params.Range != nil {
+		var start, end int
+		n, _ := fmt.Sscanf(*params.Range, "bytes=%d-%d", &start, &end)
+		if n == 0 {
+			m.t.Fatalf("invalid range %q", *params.Range)
+		}
+		if n == 1 || end >= len(body) {
+			end = len(body) - 1
+		}
+		body = body[start : end+1]
+	}
 	return &s3.GetObjectOutput{
-		Body: io.NopCloser(bytes.NewReader(m.body)),
+		Body: io.NopCloser(bytes.NewReader(body)),
 	}, nil
 }
 
+func (m *mockS3Client) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	m.checkParams(params.Bucket, params.Key)
+	return &s3.HeadObjectOutput{ContentLength: int64(len(m.body))}, nil
+}
+
+func (m *mockS3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	if params.Bucket == nil || *params.Bucket != m.bucket {
+		m.t.Fatalf("bucket not found! want: %s, got: %v", m.bucket, params.Bucket)
+	}
+	out := &s3.ListObjectsV2Output{}
+	for _, o := range m.objects {
+		if strings.HasPrefix(*o.Key, aws.ToString(params.Prefix)) {
+			out.Contents = append(out.Contents, o)
+		}
+	}
+	return out, nil
+}
+
 func (m *mockS3Client) UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
 	buffer := bytes.Buffer{}
 	_, err := buffer.ReadFrom(params.Body)
@@ -101,6 +143,50 @@ func TestS3Stream_WriteTo(t *testing.T) {
 	}
 }
 
+func TestS3Stream_WriteRangeTo(t *testing.T) {
+	c := &server.Config{
+		S3_BUCKET_NAME: "test-bucket",
+	}
+	filePath := "test"
+	s := &s3Stream{
+		config: c,
+		bucket: c.S3_BUCKET_NAME,
+		key:    filePath,
+		client: &mockS3Client{
+			t:      t,
+			bucket: c.S3_BUCKET_NAME,
+			key:    filePath,
+			body:   []byte("test data"),
+		},
+	}
+
+	size, err := s.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 9 {
+		t.Errorf("want size 9, got %d", size)
+	}
+	for _, tc := range []struct {
+		offset, length int64
+		want           string
+	}{
+		{offset: 0, length: -1, want: "test data"},
+		{offset: 5, length: -1, want: "data"},
+		{offset: 0, length: 4, want: "test"},
+		{offset: 2, length: 4, want: "st d"},
+		{offset: 2, length: 0, want: ""},
+	} {
+		buffer := &bytes.Buffer{}
+		if _, err := s.WriteRangeTo(buffer, tc.offset, tc.length); err != nil {
+			t.Fatal(err)
+		}
+		if got := buffer.String(); got != tc.want {
+			t.Errorf("WriteRangeTo(%d, %d): want: %s, got: %s", tc.offset, tc.length, tc.want, got)
+		}
+	}
+}
+
 func TestS3Stream_ReadFrom(t *testing.T) {
 	want := "test body of multi-part upload"
 	const DefaultBufferSize = 10
@@ -184,3 +270,76 @@ func TestS3Stream_Delete(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestS3Stream_ListObjects(t *testing.T) {
+	c := &server.Config{
+		S3_BUCKET_NAME: "test-bucket",
+		LOGS_PATH:      "/logs",
+	}
+	modTime := time.Unix(1000, 0)
+	client := &mockS3Client{
+		t:      t,
+		bucket: c.S3_BUCKET_NAME,
+		objects: []types.Object{
+			{Key: aws.String("/logs/foo/uid/log"), LastModified: &modTime},
+			{Key: aws.String("/logs-backup/foo/uid/log"), LastModified: &modTime},
+			{Key: aws.String("other"), LastModified: &modTime},
+		},
+	}
+
+	got, err := listObjects(context.Background(), client, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StoredLog{{Path: "foo/uid/log", ModTime: modTime}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listObjects (-want, +got): %s", diff)
+	}
+	// The path addresses the same object as the Log.
+	path, err := StoredPath(&v1alpha2.Log{
+		Spec:   v1alpha2.LogSpec{Type: v1alpha2.S3LogType},
+		Status: v1alpha2.LogStatus{Path: "foo/uid/log"},
+	}, c)
+	if err != nil || path != got[0].Path {
+		t.Errorf("StoredPath: want %s, got %s, %v", got[0].Path, path, err)
+	}
+}
+
+func TestDownloadURL(t *testing.T) {
+	c := &server.Config{LOGS_PATH: "/logs", LOGS_COMPRESSION: "gzip"}
+	servertest.NewS3(t, c, map[string]string{"/logs/ns/uid/log": "compressed log"})
+	log := &v1alpha2.Log{
+		Spec:   v1alpha2.LogSpec{Type: v1alpha2.S3LogType},
+		Status: v1alpha2.LogStatus{Path: "ns/uid/log", Size: 10, Compression: v1alpha2.GzipLogCompression},
+	}
+	s, err := NewStream(context.Background(), log, c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := DownloadURL(s, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("DownloadURL: %v", err)
+	}
+	if !strings.Contains(url, "X-Amz-Expires=300") {
+		t.Errorf("want a URL expiring in 5 minutes, got %s", url)
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The log is downloaded as it is stored.
+	if resp.StatusCode != http.StatusOK || string(body) != "compressed log" {
+		t.Errorf("want the stored log downloaded, got %s: %s", resp.Status, body)
+	}
+
+	// Only logs stored in S3 can be downloaded.
+	if _, err := DownloadURL(&fileStream{}, time.Minute); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("want FailedPrecondition for file logs, got %v", err)
+	}
+}
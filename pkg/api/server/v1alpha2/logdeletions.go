@@ -0,0 +1,279 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLogDeletionInterval = time.Minute
+	logDeletionBatchSize       = 100
+
+	// The delay before retrying the deletion of a log doubles after each
+	// failed attempt, from logDeletionMinBackoff up to logDeletionMaxBackoff.
+	logDeletionMinBackoff = 10 * time.Second
+	logDeletionMaxBackoff = time.Hour
+
+	// orphanMinAge is the minimum age of the stored logs deleted because no
+	// Record references them, so that logs are not deleted while their Record
+	// is being created.
+	orphanMinAge = time.Hour
+)
+
+var (
+	logDeletions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "results_log_deletions_total",
+		Help: "Number of attempts to delete the stored logs of deleted Records, by outcome: deleted or failed.",
+	}, []string{"outcome"})
+	orphanedLogsDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "results_orphaned_logs_deleted_total",
+		Help: "Number of stored logs deleted because no Record references them.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(logDeletions, orphanedLogsDeleted)
+}
+
+// deleteWithLogs runs del, which deletes the Records, in a transaction that
+// queues the deletion of the stored logs of the Log Records. The logs are
+// then deleted, and the deletions that fail are retried by RunLogDeletions.
+// It returns the number of logs queued for deletion.
+func (s *Server) deleteWithLogs(ctx context.Context, records []*db.Record, del func(tx *gorm.DB) error) (int, error) {
+	var queued []*db.LogDeletion
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if queued, err = queueLogDeletions(tx, records); err != nil {
+			return err
+		}
+		return del(tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.deleteLogs(ctx, queued)
+	return len(queued), nil
+}
+
+// queueLogDeletions queues the deletion of the stored logs of the Log
+// Records.
+func queueLogDeletions(tx *gorm.DB, records []*db.Record) ([]*db.LogDeletion, error) {
+	var queued []*db.LogDeletion
+	now := clock.Now()
+	for _, rec := range records {
+		if rec.Type != v1alpha2.LogRecordType {
+			continue
+		}
+		queued = append(queued, &db.LogDeletion{
+			ID:              uid(),
+			Record:          record.FormatName(result.FormatName(rec.Parent, rec.ResultName), rec.Name),
+			Log:             rec.Data,
+			NextAttemptTime: now,
+			CreatedTime:     now,
+		})
+	}
+	if len(queued) == 0 {
+		return nil, nil
+	}
+	if err := tx.Create(queued).Error; err != nil {
+		return nil, err
+	}
+	return queued, nil
+}
+
+// deleteLogs attempts the queued deletions of stored logs. The deletions that
+// fail are attempted again after a backoff.
+func (s *Server) deleteLogs(ctx context.Context, deletions []*db.LogDeletion) {
+	for _, d := range deletions {
+		err := s.deleteStoredLog(ctx, d)
+		if err == nil {
+			if err = s.db.WithContext(ctx).Delete(d).Error; err == nil {
+				logDeletions.WithLabelValues("deleted").Inc()
+				continue
+			}
+		}
+		logDeletions.WithLabelValues("failed").Inc()
+		d.Attempts++
+		d.LastError = err.Error()
+		d.NextAttemptTime = clock.Now().Add(logDeletionBackoff(d.Attempts))
+		s.logger.Warnf("Error deleting log of Record %s, attempt %d, retrying at %s: %v", d.Record, d.Attempts, d.NextAttemptTime, err)
+		if err := s.db.WithContext(ctx).Model(d).Select("attempts", "last_error", "next_attempt_time").Updates(d).Error; err != nil {
+			s.logger.Errorf("Error updating deletion of log of Record %s: %v", d.Record, err)
+		}
+	}
+}
+
+func (s *Server) deleteStoredLog(ctx context.Context, d *db.LogDeletion) error {
+	object := &v1alpha2.Log{}
+	if err := json.Unmarshal(d.Log, object); err != nil {
+		return fmt.Errorf("could not decode Log: %w", err)
+	}
+	stream, err := log.NewStream(ctx, object, s.config, s.db)
+	if err != nil {
+		return err
+	}
+	return stream.Delete()
+}
+
+// logDeletionBackoff returns the delay before retrying a deletion that failed
+// the given number of times.
+func logDeletionBackoff(attempts int64) time.Duration {
+	backoff := logDeletionMinBackoff
+	for i := int64(1); i < attempts && backoff < logDeletionMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > logDeletionMaxBackoff {
+		return logDeletionMaxBackoff
+	}
+	return backoff
+}
+
+// RunLogDeletions retries the queued deletions of stored logs every
+// LOGS_DELETION_INTERVAL, and deletes the stored logs that no Record
+// references every LOGS_RECONCILE_INTERVAL if it is set, until the context is
+// cancelled.
+func (s *Server) RunLogDeletions(ctx context.Context) {
+	interval := s.config.LOGS_DELETION_INTERVAL
+	if interval <= 0 {
+		interval = defaultLogDeletionInterval
+	}
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+	var reconcile <-chan time.Time
+	if s.config.LOGS_RECONCILE_INTERVAL > 0 {
+		reconcileTicker := clock.NewTicker(s.config.LOGS_RECONCILE_INTERVAL)
+		defer reconcileTicker.Stop()
+		reconcile = reconcileTicker.Chan()
+	}
+	for {
+		if err := s.processLogDeletions(ctx); err != nil {
+			s.logger.Errorf("Error deleting stored logs: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		case <-reconcile:
+			n, err := s.reconcileLogs(ctx)
+			if err != nil {
+				s.logger.Errorf("Error deleting orphaned logs: %v", err)
+			}
+			if n > 0 {
+				s.logger.Infof("Deleted %d orphaned logs", n)
+			}
+		}
+	}
+}
+
+// processLogDeletions attempts the queued deletions of stored logs that are
+// due, in batches.
+func (s *Server) processLogDeletions(ctx context.Context) error {
+	due, err := cel2sql.TimeRange(s.db.Dialector.Name(), "next_attempt_time", time.Time{}, clock.Now())
+	if err != nil {
+		return err
+	}
+	for {
+		var deletions []*db.LogDeletion
+		// The deletions that fail are attempted again later, and are not
+		// selected by the next batch.
+		if err := s.db.WithContext(ctx).Where(due.SQL, due.Args...).Order("next_attempt_time, id").Limit(logDeletionBatchSize).Find(&deletions).Error; err != nil {
+			return err
+		}
+		s.deleteLogs(ctx, deletions)
+		if len(deletions) < logDeletionBatchSize {
+			return nil
+		}
+	}
+}
+
+// reconcileLogs deletes the stored logs of LOGS_TYPE that no Log Record
+// references, soft deleted or not, like the logs of Records deleted by
+// earlier releases, and returns the number of logs deleted. The storage is
+// listed before the Records are read, and logs written in the last
+// orphanMinAge are kept, so that logs are not deleted while their Record is
+// being created.
+func (s *Server) reconcileLogs(ctx context.Context) (int, error) {
+	logType := v1alpha2.LogType(s.config.LOGS_TYPE)
+	stored, err := log.ListStored(ctx, logType, s.config, s.db)
+	if err != nil || len(stored) == 0 {
+		return 0, err
+	}
+
+	referenced := make(map[string]bool)
+	var records []*db.Record
+	err = s.db.WithContext(ctx).Unscoped().Where("type = ?", v1alpha2.LogRecordType).FindInBatches(&records, logDeletionBatchSize, func(*gorm.DB, int) error {
+		for _, rec := range records {
+			object := &v1alpha2.Log{}
+			if err := json.Unmarshal(rec.Data, object); err != nil {
+				s.logger.Warnf("Skipping undecodable Log record %s: %v", rec.Name, err)
+				continue
+			}
+			// Logs without a type are stored with LOGS_TYPE.
+			if object.Spec.Type != "" && object.Spec.Type != logType {
+				continue
+			}
+			path, err := log.StoredPath(object, s.config)
+			if err != nil {
+				return err
+			}
+			referenced[path] = true
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := clock.Now().Add(-orphanMinAge)
+	var (
+		deleted int
+		first   error
+	)
+	for _, l := range stored {
+		if referenced[l.Path] || l.ModTime.After(cutoff) {
+			continue
+		}
+		stream, err := log.NewStream(ctx, &v1alpha2.Log{
+			Spec:   v1alpha2.LogSpec{Type: logType},
+			Status: v1alpha2.LogStatus{Path: l.Path},
+		}, s.config, s.db)
+		if err == nil {
+			err = stream.Delete()
+		}
+		if err != nil {
+			if first == nil {
+				first = fmt.Errorf("error deleting orphaned log %s: %w", l.Path, err)
+			}
+			continue
+		}
+		s.logger.Debugf("Deleted orphaned log %s", l.Path)
+		orphanedLogsDeleted.Inc()
+		deleted++
+	}
+	return deleted, first
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"github.com/tektoncd/results/pkg/internal/jsonutil"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+// createLogFile creates a Log Record, along with its log file under
+// LOGS_PATH, and returns the path of the file.
+func createLogFile(t *testing.T, srv *Server, parent, name string) string {
+	t.Helper()
+	ctx := context.Background()
+	logPath := filepath.Join(parent, name)
+	if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: parent,
+		Record: &pb.Record{
+			Name: record.FormatName(parent, name),
+			Data: &pb.Any{
+				Type: v1alpha2.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+					Spec:   v1alpha2.LogSpec{Type: v1alpha2.FileLogType},
+					Status: v1alpha2.LogStatus{Path: logPath},
+				}),
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	return writeLogFile(t, srv, logPath)
+}
+
+func writeLogFile(t *testing.T, srv *Server, logPath string) string {
+	t.Helper()
+	path := filepath.Join(srv.config.LOGS_PATH, logPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("log"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLogDeletions(t *testing.T) {
+	logsPath := filepath.Join(t.TempDir(), "logs")
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		LOGS_PATH:                logsPath,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	var results []string
+	for _, name := range []string{"a", "b"} {
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: "deletions",
+			Result: &pb.Result{Name: result.FormatName("deletions", name)},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		results = append(results, res.GetName())
+	}
+
+	// The logs of a deleted Result are deleted along with it.
+	path := createLogFile(t, srv, results[0], "log")
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: results[0]}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected log to be deleted: %v", err)
+	}
+
+	// The deletion of a log is retried until the storage is available.
+	path = createLogFile(t, srv, results[1], "log")
+	moved := logsPath + "-moved"
+	if err := os.Rename(logsPath, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(logsPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: record.FormatName(results[1], "log")}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	queued := func() []*db.LogDeletion {
+		t.Helper()
+		var deletions []*db.LogDeletion
+		if err := srv.db.Find(&deletions).Error; err != nil {
+			t.Fatal(err)
+		}
+		return deletions
+	}
+	deletions := queued()
+	if len(deletions) != 1 || deletions[0].Record != record.FormatName(results[1], "log") || deletions[0].Attempts != 1 || deletions[0].LastError == "" {
+		t.Fatalf("want a failed deletion of the log, got %+v", deletions)
+	}
+
+	// The deletion is not attempted again before its backoff expires.
+	if err := srv.processLogDeletions(ctx); err != nil {
+		t.Fatalf("processLogDeletions: %v", err)
+	}
+	if deletions := queued(); len(deletions) != 1 || deletions[0].Attempts != 1 {
+		t.Fatalf("want the deletion not to be attempted, got %+v", deletions)
+	}
+
+	if err := os.Remove(logsPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(moved, logsPath); err != nil {
+		t.Fatal(err)
+	}
+	fakeClock.Advance(logDeletionMinBackoff + time.Second)
+	if err := srv.processLogDeletions(ctx); err != nil {
+		t.Fatalf("processLogDeletions: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected log to be deleted: %v", err)
+	}
+	if deletions := queued(); len(deletions) != 0 {
+		t.Errorf("want no queued deletions, got %+v", deletions)
+	}
+}
+
+func TestLogDeletionBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		attempts int64
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 5, want: 160 * time.Second},
+		{attempts: 10, want: time.Hour},
+		{attempts: 1000, want: time.Hour},
+	} {
+		if got := logDeletionBackoff(tc.attempts); got != tc.want {
+			t.Errorf("logDeletionBackoff(%d): want %v, got %v", tc.attempts, tc.want, got)
+		}
+	}
+}
+
+func TestReconcileLogs(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		LOGS_PATH:                filepath.Join(t.TempDir(), "logs"),
+		DB_ENABLE_AUTO_MIGRATION: true,
+		SOFT_DELETE_EXPIRY:       time.Hour,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "reconcile",
+		Result: &pb.Result{Name: result.FormatName("reconcile", "a")},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	referenced := createLogFile(t, srv, res.GetName(), "referenced")
+	// The log of a soft deleted Record is kept, so that the Record can be
+	// undeleted.
+	deleted := createLogFile(t, srv, res.GetName(), "deleted")
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: record.FormatName(res.GetName(), "deleted")}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	// Log records that cannot be decoded are skipped.
+	if err := srv.db.Create(&db.Record{
+		Parent:     "reconcile",
+		ResultID:   res.GetUid(),
+		ResultName: "a",
+		ID:         "invalid",
+		Name:       "invalid",
+		Type:       v1alpha2.LogRecordType,
+		Data:       db.JSON(`{"spec": "invalid"}`),
+	}).Error; err != nil {
+		t.Fatalf("failed to create invalid Log record: %v", err)
+	}
+	orphan := writeLogFile(t, srv, "reconcile/orphan/log")
+	recent := writeLogFile(t, srv, "reconcile/recent/log")
+
+	old := fakeClock.Now().Add(-2 * orphanMinAge)
+	for _, path := range []string{referenced, deleted, orphan} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chtimes(recent, fakeClock.Now(), fakeClock.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := srv.reconcileLogs(ctx)
+	if err != nil {
+		t.Fatalf("reconcileLogs: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("want 1 log deleted, got %d", n)
+	}
+	for _, tc := range []struct {
+		path string
+		kept bool
+	}{
+		{path: referenced, kept: true},
+		{path: deleted, kept: true},
+		{path: orphan, kept: false},
+		{path: recent, kept: true},
+	} {
+		_, err := os.Stat(tc.path)
+		if kept := err == nil; kept != tc.kept {
+			t.Errorf("%s: want kept %t, got %v", tc.path, tc.kept, err)
+		}
+	}
+}
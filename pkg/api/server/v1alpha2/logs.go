@@ -3,83 +3,238 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/google/cel-go/cel"
-	celenv "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
 	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"hash"
 	"io"
+	"time"
 
 	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/redact"
 	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	"github.com/tektoncd/results/pkg/logs"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// logFollowInterval is the interval between two reads of a followed log.
+var logFollowInterval = time.Second
+
 func (s *Server) GetLog(req *pb.GetLogRequest, srv pb.Logs_GetLogServer) error {
 	parent, res, name, err := log.ParseName(req.GetName())
 	if err != nil {
 		s.logger.Error(err)
 		return status.Error(codes.InvalidArgument, "Invalid Name")
 	}
+	if req.GetOffset() < 0 || req.GetLimitBytes() < 0 || req.GetTailLines() < 0 {
+		return status.Error(codes.InvalidArgument, "offset, limit_bytes and tail_lines must not be negative")
+	}
+	if req.GetOffset() > 0 && req.GetTailLines() > 0 {
+		return status.Error(codes.InvalidArgument, "offset cannot be set with tail_lines")
+	}
 
 	if err := s.auth.Check(srv.Context(), parent, auth.ResourceLogs, auth.PermissionGet); err != nil {
 		s.logger.Error(err)
 		return status.Error(codes.Unauthenticated, "Permission denied")
 	}
 
-	rec, err := getRecord(s.db, parent, res, name)
+	rec, err := findLogRecord(s.db, parent, res, name, req.GetStep())
 	if err != nil {
 		s.logger.Error(err)
 		return err
 	}
-	// Check if the input record is referenced in any logs record in the result
-	if rec.Type != v1alpha2.LogRecordType {
-		rec, err = getLogRecord(s.db, parent, res, name)
-		if err != nil {
-			s.logger.Error(err)
-			return err
-		}
-	}
 
-	stream, object, err := log.ToStream(srv.Context(), rec, s.config)
+	stream, object, err := log.ToStream(srv.Context(), rec, s.config, s.db)
 	if err != nil {
 		s.logger.Error(err)
 		return status.Error(codes.Internal, "Error streaming log")
 	}
-	if object.Status.Size == 0 {
+	if object.Status.Size == 0 && !object.Status.InProgress {
 		s.logger.Errorf("no logs exist for %s", req.GetName())
 		return status.Error(codes.NotFound, "Log doesn't exist")
 	}
 
-	writer := logs.NewBufferedWriter(srv, req.GetName(), s.config.LOGS_BUFFER_SIZE)
-	if _, err = stream.WriteTo(writer); err != nil {
+	// Stream the whole compressed log as is, if the client supports its
+	// codec.
+	if compressed, codec, ok := log.Compressed(stream); ok && !object.Status.InProgress &&
+		req.GetOffset() == 0 && req.GetLimitBytes() == 0 && req.GetTailLines() == 0 &&
+		acceptsCompression(req, codec) {
+		sender := &firstLogSender{sender: srv, size: object.Status.Size, compression: string(codec)}
+		writer := logs.NewBufferedWriter(sender, req.GetName(), s.config.LOGS_BUFFER_SIZE)
+		if _, err := compressed.WriteTo(writer); err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		if _, err := writer.Flush(); err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		return nil
+	}
+
+	size, err := stream.Size()
+	if err != nil {
 		s.logger.Error(err)
 		return status.Error(codes.Internal, "Error streaming log")
 	}
+	offset, length := req.GetOffset(), req.GetLimitBytes()
+	if length == 0 {
+		length = -1
+	}
+	if req.GetTailLines() > 0 {
+		offset, err = log.TailOffset(stream, size, req.GetTailLines(), req.GetLimitBytes())
+		if err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		length = -1
+	}
+	if offset > size {
+		return status.Errorf(codes.OutOfRange, "offset %d is past the end of the log of %d bytes", offset, size)
+	}
+
+	sender := &firstLogSender{sender: srv, size: size, offset: offset}
+	writer := logs.NewBufferedWriter(sender, req.GetName(), s.config.LOGS_BUFFER_SIZE)
+	if offset < size {
+		written, err := stream.WriteRangeTo(writer, offset, length)
+		if err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		offset += written
+		if length > 0 {
+			length -= written
+		}
+	}
 	_, err = writer.Flush()
 	if err != nil {
 		s.logger.Error(err)
 		return status.Error(codes.Internal, "Error streaming log")
 	}
+	// Always send a message with the size of the log, even when there is no
+	// data in the requested range.
+	if !sender.sent {
+		if err := sender.Send(&pb.Log{Name: req.GetName()}); err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+	}
+
+	if req.GetFollow() && object.Status.InProgress {
+		return s.followLog(srv.Context(), writer, stream, rec, offset, length)
+	}
+	return nil
+}
+
+// followLog streams the data appended to the log from offset, at most length
+// bytes unless length is negative, until the upload of the log completes.
+// The log is polled, since it may be uploaded through another API server.
+func (s *Server) followLog(ctx context.Context, writer *logs.BufferedLog, stream log.Stream, rec *db.Record, offset, length int64) error {
+	for inProgress := true; inProgress && length != 0; {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-clock.After(logFollowInterval):
+		}
+
+		// Check whether the upload is in progress before reading the size of
+		// the log, so that the data uploaded before it completed is streamed.
+		current, err := getRecord(s.db.WithContext(ctx), rec.Parent, rec.ResultName, rec.Name)
+		if err != nil {
+			return err
+		}
+		object := &v1alpha2.Log{}
+		if err := json.Unmarshal(current.Data, object); err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		inProgress = object.Status.InProgress
+
+		size, err := stream.Size()
+		if err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		if size <= offset {
+			continue
+		}
+		n := size - offset
+		if length > 0 && n > length {
+			n = length
+		}
+		written, err := stream.WriteRangeTo(writer, offset, n)
+		if err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+		offset += written
+		if length > 0 {
+			length -= written
+		}
+		if _, err := writer.Flush(); err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+	}
 	return nil
 }
 
+// firstLogSender sets the size of the log, the offset of the streamed data and
+// its compression in the first message sent by GetLog.
+type firstLogSender struct {
+	sender      logs.Sender
+	size        int64
+	offset      int64
+	compression string
+	sent        bool
+}
+
+func (f *firstLogSender) Send(l *pb.Log) error {
+	if !f.sent {
+		l.Size = f.size
+		l.Offset = f.offset
+		l.Compression = f.compression
+		f.sent = true
+	}
+	return f.sender.Send(l)
+}
+
+// acceptsCompression returns whether the client of the request supports the
+// compression codec.
+func acceptsCompression(req *pb.GetLogRequest, codec v1alpha2.LogCompression) bool {
+	for _, c := range req.GetAcceptCompression() {
+		if v1alpha2.LogCompression(c) == codec {
+			return true
+		}
+	}
+	return false
+}
+
+// getLogRecord returns the Log Record of the resource with the given UID,
+// which combines the output of all its steps.
 func getLogRecord(txn *gorm.DB, parent, result, name string) (*db.Record, error) {
+	uid, step, err := logColumns(txn)
+	if err != nil {
+		return nil, err
+	}
 	store := &db.Record{}
 	q := txn.
 		Where(&db.Record{Result: db.Result{Parent: parent, Name: result}}).
-		Where("data -> 'spec' -> 'resource' ->> 'uid' =  ?", name).
+		Where(uid+" = ?", name).
+		Where(step + " IS NULL").
 		First(store)
 	if err := errors.Wrap(q.Error); err != nil {
 		return nil, err
@@ -87,24 +242,125 @@ func getLogRecord(txn *gorm.DB, parent, result, name string) (*db.Record, error)
 	return store, nil
 }
 
+// findLogRecord returns the Log Record of the given name, or of the step of
+// the TaskRun it is the Log or Record of. Given another Record, its Log
+// Record is returned.
+func findLogRecord(txn *gorm.DB, parent, result, name, step string) (*db.Record, error) {
+	rec, err := getRecord(txn, parent, result, name)
+	if err != nil {
+		return nil, err
+	}
+	// Check if the input record is referenced in any logs record in the result
+	if step != "" {
+		return getStepLogRecord(txn, rec, step)
+	}
+	if rec.Type != v1alpha2.LogRecordType {
+		return getLogRecord(txn, parent, result, name)
+	}
+	return rec, nil
+}
+
+// getStepLogRecord returns the Log Record of a step of a TaskRun, given a Log
+// Record of the TaskRun, or its Record.
+func getStepLogRecord(txn *gorm.DB, rec *db.Record, name string) (*db.Record, error) {
+	resource := rec.Name
+	if rec.Type == v1alpha2.LogRecordType {
+		object := &v1alpha2.Log{}
+		if err := json.Unmarshal(rec.Data, object); err != nil {
+			return nil, status.Errorf(codes.Internal, "could not decode Log record: %v", err)
+		}
+		resource = string(object.Spec.Resource.UID)
+	}
+	uid, step, err := logColumns(txn)
+	if err != nil {
+		return nil, err
+	}
+	store := &db.Record{}
+	q := txn.
+		Where(&db.Record{Parent: rec.Parent, ResultName: rec.ResultName, Type: v1alpha2.LogRecordType}).
+		Where(uid+" = ?", resource).
+		Where(step+" = ?", name).
+		First(store)
+	if err := errors.Wrap(q.Error); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// logColumns returns the SQL expressions of the UID of the resource of Log
+// Records, and of the name of their step.
+func logColumns(txn *gorm.DB) (uid, step string, err error) {
+	dialect := txn.Dialector.Name()
+	if uid, err = cel2sql.JSONText(dialect, "data", "spec", "resource", "uid"); err != nil {
+		return "", "", status.Error(codes.Unimplemented, err.Error())
+	}
+	if step, err = cel2sql.JSONText(dialect, "data", "spec", "step", "name"); err != nil {
+		return "", "", status.Error(codes.Unimplemented, err.Error())
+	}
+	return uid, step, nil
+}
+
 func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 	var name string
 	var bytesWritten int64
+	// offset is the offset in the stored log the upload starts at, and
+	// received the size of the log received from the client, which differs
+	// from the size of the stored log once its secrets are redacted.
+	var offset, received int64
+	// checksum hashes the log, including the log stored before the upload
+	// resumed.
+	checksum := sha256.New()
 	var rec *db.Record
 	var object *v1alpha2.Log
 	var stream log.Stream
-	defer func() {
+	// writer writes the log received to the stream, redacting its secrets
+	// if redaction is enabled.
+	var (
+		writer   io.Writer
+		redactor *redact.Writer
+		counts   = redact.Counts{}
+	)
+	// finish flushes the stream before the Record is updated, so that the
+	// log is stored once its upload is marked as complete.
+	finish := func(err error) error {
+		if redactor != nil {
+			if err := redactor.Flush(); err != nil {
+				s.logger.Error(err)
+			}
+		}
 		if stream != nil {
 			if err := stream.Flush(); err != nil {
 				s.logger.Error(err)
 			}
 		}
-	}()
+		if object != nil && stream != nil {
+			// The log is complete once the client ends the upload.
+			object.Status.Size = offset + bytesWritten
+			if s.redactor != nil {
+				object.Status.Received = received
+			}
+			object.Status.Upload = v1alpha2.LogUploadIncomplete
+			if err == io.EOF {
+				object.Status.Upload = v1alpha2.LogUploadComplete
+				object.Status.Checksum = "sha256:" + hex.EncodeToString(checksum.Sum(nil))
+			}
+		}
+		if object != nil && len(counts) > 0 {
+			if object.Status.Redactions == nil {
+				object.Status.Redactions = map[string]int64{}
+			}
+			for pattern, n := range counts {
+				object.Status.Redactions[pattern] += n
+			}
+			countRedactions("log", counts)
+		}
+		return s.handleReturn(srv, rec, object, bytesWritten, err)
+	}
 	for {
 		recv, err := srv.Recv()
 		// If we reach the end of the srv, we receive an io.EOF error
 		if err != nil {
-			return s.handleReturn(srv, rec, object, bytesWritten, err)
+			return finish(err)
 		}
 		// Ensure that we are receiving logs for the same record
 		if name == "" {
@@ -113,85 +369,177 @@ func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 		}
 		if name != recv.GetName() {
 			err := fmt.Errorf("cannot put logs for multiple records in the same server")
-			return s.handleReturn(srv,
-				rec,
-				object,
-				bytesWritten,
-				err)
-		}
-
-		parent, resultName, recordName, err := log.ParseName(name)
-		if err != nil {
-			return s.handleReturn(srv, rec, object, bytesWritten, err)
-		}
-
-		if err := s.auth.Check(srv.Context(), parent, auth.ResourceLogs, auth.PermissionUpdate); err != nil {
-			return s.handleReturn(srv, rec, object, bytesWritten, err)
+			return finish(err)
 		}
 
 		if rec == nil {
+			parent, resultName, recordName, err := log.ParseName(name)
+			if err != nil {
+				return finish(err)
+			}
+			// All the messages of the stream are for the same Record, so the
+			// stream is authorized once.
+			if err := s.auth.Check(srv.Context(), parent, auth.ResourceLogs, auth.PermissionUpdate); err != nil {
+				return finish(err)
+			}
 			rec, err = getRecord(s.db.WithContext(srv.Context()), parent, resultName, recordName)
 			if err != nil {
-				return s.handleReturn(srv, rec, object, bytesWritten, err)
+				return finish(err)
 			}
-
 		}
 
 		if stream == nil {
-			stream, object, err = log.ToStream(srv.Context(), rec, s.config)
+			stream, object, err = log.ToStream(srv.Context(), rec, s.config, s.db)
+			if err != nil {
+				return finish(err)
+			}
+			// The first message sets the offset the upload starts at: 0
+			// replaces the stored log, and its size resumes its upload.
+			offset = recv.GetOffset()
+			received = offset
+			if s.redactor != nil && offset > 0 {
+				// The client resumes at the size of the log it sent,
+				// which is stored with its secrets redacted.
+				if offset != object.Status.Received {
+					err := status.Errorf(codes.FailedPrecondition, "cannot resume the upload of the log at offset %d: %d bytes were received", offset, object.Status.Received)
+					stream, object = nil, nil
+					return finish(err)
+				}
+				offset = object.Status.Size
+			}
+			if err := log.Resume(stream, offset, checksum); err != nil {
+				// The stored log is left as is.
+				stream, object = nil, nil
+				return finish(err)
+			}
+			if offset == 0 {
+				object.Status.Redactions = nil
+			}
+			// Mark the upload as in progress, so that the log can be read and
+			// followed before it completes.
+			object.Status.InProgress = true
+			object.Status.Upload = v1alpha2.LogUploadInProgress
+			object.Status.Checksum = ""
+			rec, err = s.updateLogRecord(srv.Context(), rec, object)
 			if err != nil {
-				return s.handleReturn(srv, rec, object, bytesWritten, err)
+				return finish(err)
+			}
+			writer = &streamWriter{stream: stream, written: &bytesWritten, hash: checksum}
+			if s.redactor != nil {
+				redactor = s.redactor.NewWriter(writer, counts)
+				writer = redactor
 			}
 		}
 
-		buffer := bytes.NewBuffer(recv.GetData())
-		written, err := stream.ReadFrom(buffer)
-		bytesWritten += written
-
-		if err != nil {
-			return s.handleReturn(srv, rec, object, bytesWritten, err)
+		if _, err := writer.Write(recv.GetData()); err != nil {
+			return finish(err)
 		}
+		received += int64(len(recv.GetData()))
 	}
 }
 
-func (s *Server) handleReturn(srv pb.Logs_UpdateLogServer, rec *db.Record, log *v1alpha2.Log, written int64, returnErr error) error {
-	// When the srv reaches the end, srv.Recv() returns an io.EOF error
-	// Therefore we should not return io.EOF if it is received in this function.
-	// Otherwise, we should return the original error and not mask any subsequent errors handling cleanup/return.
+// GetLogDownloadURL returns a pre-signed URL to download a log stored in S3,
+// so that large logs are downloaded from S3 rather than streamed through the
+// API server.
+func (s *Server) GetLogDownloadURL(ctx context.Context, req *pb.GetLogDownloadURLRequest) (*pb.GetLogDownloadURLResponse, error) {
+	parent, res, name, err := log.ParseName(req.GetName())
+	if err != nil {
+		s.logger.Error(err)
+		return nil, status.Error(codes.InvalidArgument, "Invalid Name")
+	}
+	if s.config.S3_DOWNLOAD_URL_EXPIRY <= 0 {
+		return nil, status.Error(codes.Unimplemented, "log download URLs are disabled")
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceLogs, auth.PermissionGet); err != nil {
+		s.logger.Error(err)
+		return nil, status.Error(codes.Unauthenticated, "Permission denied")
+	}
 
-	// If no database record or Log, return the original error
-	if rec == nil || log == nil {
-		return returnErr
+	rec, err := findLogRecord(s.db.WithContext(ctx), parent, res, name, req.GetStep())
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
 	}
-	apiRec, err := record.ToAPI(rec)
+	stream, object, err := log.ToStream(ctx, rec, s.config, s.db)
 	if err != nil {
-		if !isNilOrEOF(returnErr) {
-			return returnErr
-		}
-		return err
+		s.logger.Error(err)
+		return nil, status.Error(codes.Internal, "Error getting log")
+	}
+	if object.Status.Size == 0 && !object.Status.InProgress {
+		return nil, status.Error(codes.NotFound, "Log doesn't exist")
 	}
-	apiRec.UpdateTime = timestamppb.Now()
-	if written > 0 {
-		log.Status.Size = written
+	// S3 objects are stored once their upload completes.
+	if object.Status.InProgress {
+		return nil, status.Error(codes.FailedPrecondition, "Log is being uploaded")
+	}
+	expireTime := clock.Now().Add(s.config.S3_DOWNLOAD_URL_EXPIRY)
+	url, err := log.DownloadURL(stream, s.config.S3_DOWNLOAD_URL_EXPIRY)
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, err
 	}
-	data, err := json.Marshal(log)
 	if err != nil {
-		if !isNilOrEOF(returnErr) {
-			return returnErr
+		s.logger.Error(err)
+		return nil, status.Error(codes.Internal, "Error getting log download URL")
+	}
+	return &pb.GetLogDownloadURLResponse{
+		Url:         url,
+		ExpireTime:  timestamppb.New(expireTime),
+		Size:        object.Status.Size,
+		Compression: string(object.Status.Compression),
+	}, nil
+}
+
+// streamWriter writes to the Stream of a log, and counts and hashes the
+// bytes written.
+type streamWriter struct {
+	stream  log.Stream
+	written *int64
+	hash    hash.Hash
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	n, err := w.stream.ReadFrom(bytes.NewReader(p))
+	*w.written += n
+	w.hash.Write(p[:n])
+	return int(n), err
+}
+
+// updateLogRecord writes the Log to its Record, and returns the updated
+// Record.
+func (s *Server) updateLogRecord(ctx context.Context, rec *db.Record, object *v1alpha2.Log) (*db.Record, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var updated *pb.Record
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updated, err = s.updateRecord(tx, rec, &pb.Record{Data: &pb.Any{Type: rec.Type, Value: data}}, []string{"data"}, rec.Etag)
+		if err != nil {
+			return err
 		}
+		rec, err = getRecord(tx, rec.Parent, rec.ResultName, rec.Name)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	apiRec.Data = &pb.Any{
-		Type:  rec.Type,
-		Value: data,
-	}
+	s.events.PublishRecord(pb.EventType_MODIFIED, updated)
+	return rec, nil
+}
 
-	_, err = s.UpdateRecord(srv.Context(), &pb.UpdateRecordRequest{
-		Record: apiRec,
-		Etag:   rec.Etag,
-	})
+func (s *Server) handleReturn(srv pb.Logs_UpdateLogServer, rec *db.Record, log *v1alpha2.Log, written int64, returnErr error) error {
+	// When the srv reaches the end, srv.Recv() returns an io.EOF error
+	// Therefore we should not return io.EOF if it is received in this function.
+	// Otherwise, we should return the original error and not mask any subsequent errors handling cleanup/return.
 
-	if err != nil {
+	// If no database record or Log, return the original error
+	if rec == nil || log == nil {
+		return returnErr
+	}
+	// The stream was authorized with its first message, so the Record is
+	// updated without checking permissions again.
+	log.Status.InProgress = false
+	if _, err := s.updateLogRecord(srv.Context(), rec, log); err != nil {
 		if !isNilOrEOF(returnErr) {
 			return returnErr
 		}
@@ -199,9 +547,10 @@ func (s *Server) handleReturn(srv pb.Logs_UpdateLogServer, rec *db.Record, log *
 	}
 
 	if returnErr == io.EOF {
-		s.logger.Debugf("received %d bytes for %s", written, apiRec.GetName())
+		name := record.FormatName(result.FormatName(rec.Parent, rec.ResultName), rec.Name)
+		s.logger.Debugf("received %d bytes for %s", written, name)
 		return srv.SendAndClose(&pb.LogSummary{
-			Record:        apiRec.Name,
+			Record:        name,
 			BytesReceived: written,
 		})
 	}
@@ -221,23 +570,27 @@ func (s *Server) ListLogs(ctx context.Context, req *pb.ListRecordsRequest) (*pb.
 		s.logger.Error(err)
 		return nil, status.Error(codes.InvalidArgument, "Invalid Name")
 	}
-	if err := s.auth.Check(ctx, parent, auth.ResourceLogs, auth.PermissionList); err != nil {
+	parents, filtered, err := s.checkList(ctx, parent, auth.ResourceLogs)
+	if err != nil {
 		s.logger.Debug(err)
 		return nil, status.Error(codes.Unauthenticated, "permission denied")
 
 	}
+	if filtered && len(parents) == 0 {
+		return &pb.ListRecordsResponse{ParentsFiltered: true}, nil
+	}
 
 	userPageSize, err := pageSize(int(req.GetPageSize()))
 	if err != nil {
 		return nil, err
 	}
 
-	start, err := pageStart(req.GetPageToken(), req.GetFilter())
+	sortOrder, err := orderBy(req.GetOrderBy())
 	if err != nil {
 		return nil, err
 	}
 
-	sortOrder, err := orderBy(req.GetOrderBy())
+	start, err := pageStart(req.GetPageToken(), req.GetFilter(), sortOrder)
 	if err != nil {
 		return nil, err
 	}
@@ -246,38 +599,39 @@ func (s *Server) ListLogs(ctx context.Context, req *pb.ListRecordsRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
-	prg, err := celenv.ParseFilter(env, req.GetFilter())
+	filter, err := s.parseFilter(env, req.GetFilter(), cel2sql.RecordsView)
 	if err != nil {
 		return nil, err
 	}
 	// Fetch n+1 items to get the next token.
-	rec, err := s.getFilteredPaginatedSortedLogRecords(ctx, req.GetParent(), start, userPageSize+1, prg, sortOrder)
+	rec, err := s.getFilteredPaginatedSortedLogRecords(ctx, req.GetParent(), parents, start, userPageSize+1, filter, sortOrder, req.GetShowDeleted())
 	if err != nil {
 		return nil, err
 	}
 
-	// If we returned the full n+1 items, use the last element as the next page
-	// token.
+	// If we returned the full n+1 items, there is a next page starting after
+	// the last item of this one.
 	var nextToken string
 	if len(rec) > userPageSize {
-		next := rec[len(rec)-1]
+		rec = rec[:userPageSize]
 		var err error
-		nextToken, err = pagination.EncodeToken(next.GetUid(), req.GetFilter())
+		nextToken, err = nextPageToken(rec[len(rec)-1], req.GetFilter(), sortOrder)
 		if err != nil {
 			return nil, err
 		}
-		rec = rec[:len(rec)-1]
 	}
 
 	return &pb.ListRecordsResponse{
-		Records:       rec,
-		NextPageToken: nextToken,
+		Records:         rec,
+		NextPageToken:   nextToken,
+		ParentsFiltered: filtered,
 	}, nil
 }
 
 // getFilteredPaginatedSortedLogRecords returns the specified number of results that
-// match the given CEL program.
-func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, parent, start string, pageSize int, prg cel.Program, sortOrder string) ([]*pb.Record, error) {
+// match the given filter, including the deleted ones if showDeleted is set.
+// Lists across parents are restricted to the given parents, if not nil.
+func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, parent string, parents []string, start *pagination.Token, pageSize int, filter *filter, sortOrder string, showDeleted bool) ([]*pb.Record, error) {
 	parent, resultName, err := result.ParseName(parent)
 	if err != nil {
 		return nil, err
@@ -289,18 +643,22 @@ func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, paren
 		batchSize := batcher.Next()
 		dbrecords := make([]*db.Record, 0, batchSize)
 		q := s.db.WithContext(ctx).Where("type = ?", v1alpha2.LogRecordType)
-		q = q.Where("id > ?", start)
+		if showDeleted {
+			q = q.Unscoped()
+		}
 		// Specifying `-` allows users to read Records across Results.
 		// See https://google.aip.dev/159 for more details.
 		if parent != "-" {
 			q = q.Where("parent = ?", parent)
+		} else if parents != nil {
+			q = q.Where("parent IN ?", parents)
 		}
 		if resultName != "-" {
 			q = q.Where("result_name = ?", resultName)
 		}
-		if sortOrder != "" {
-			q = q.Order(sortOrder)
-		}
+		q = filter.apply(q)
+
+		q = pageQuery(q, sortOrder, start)
 		q = q.Limit(batchSize).Find(&dbrecords)
 		if err := errors.Wrap(q.Error); err != nil {
 			return nil, err
@@ -312,7 +670,7 @@ func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, paren
 			if err != nil {
 				return nil, err
 			}
-			ok, err := record.Match(api, prg)
+			ok, err := record.Match(api, filter.prg)
 			if err != nil {
 				return nil, err
 			}
@@ -339,7 +697,8 @@ func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, paren
 		}
 
 		// Set params for next batch.
-		start = dbrecords[len(dbrecords)-1].ID
+		last := dbrecords[len(dbrecords)-1]
+		start = pageCursor(sortOrder, last.ID, last.CreatedTime, last.UpdatedTime)
 		batcher.Update(len(dbrecords), batchSize)
 	}
 	return rec, nil
@@ -368,11 +727,11 @@ func (s *Server) DeleteLog(ctx context.Context, req *pb.DeleteLogRequest) (*empt
 		}
 	}
 
-	streamer, _, err := log.ToStream(ctx, rec, s.config)
-	err = streamer.Delete()
-	if err != nil {
-		return nil, err
+	// The stored log of a soft deleted record is kept, so that the record can
+	// be undeleted. It is deleted when the record expires.
+	if err := s.deleteRecord(ctx, rec); err != nil {
+		return &empty.Empty{}, err
 	}
-
-	return &empty.Empty{}, errors.Wrap(s.db.WithContext(ctx).Delete(&db.Record{}, rec).Error)
+	s.publishRecordDeleted(rec)
+	return &empty.Empty{}, nil
 }
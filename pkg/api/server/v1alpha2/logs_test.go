@@ -2,14 +2,21 @@ package server
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/logger"
 	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
@@ -21,6 +28,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 	"io"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -34,12 +42,14 @@ type mockGetLogServer struct {
 	grpc.ServerStream
 	ctx          context.Context
 	receivedData *bytes.Buffer
+	messages     []*pb.Log
 }
 
 func (m *mockGetLogServer) Send(chunk *pb.Log) error {
 	if m.receivedData == nil {
 		m.receivedData = &bytes.Buffer{}
 	}
+	m.messages = append(m.messages, chunk)
 	_, err := m.receivedData.Write(chunk.GetData())
 	return err
 }
@@ -54,10 +64,18 @@ type mockUpdateLogServer struct {
 	record        *pb.Record
 	logStream     []string
 	bytesReceived int64
+	// offset is sent in the first message.
+	offset int64
+	// err is returned once the log is streamed, to interrupt the upload.
+	err  error
+	sent bool
 }
 
 func (m *mockUpdateLogServer) Recv() (*pb.Log, error) {
 	if len(m.logStream) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
 		return nil, io.EOF
 	}
 
@@ -69,6 +87,10 @@ func (m *mockUpdateLogServer) Recv() (*pb.Log, error) {
 		Name: log.FormatName(result.FormatName(parent, resultName), recordName),
 		Data: []byte(m.logStream[0]),
 	}
+	if !m.sent {
+		chunk.Offset = m.offset
+		m.sent = true
+	}
 	m.logStream = m.logStream[1:]
 	return chunk, nil
 }
@@ -82,6 +104,15 @@ func (m *mockUpdateLogServer) Context() context.Context {
 	return m.ctx
 }
 
+// countingChecker allows all requests, and counts the checks of each
+// resource and verb.
+type countingChecker map[string]int
+
+func (c countingChecker) Check(_ context.Context, _, resource, verb string) error {
+	c[resource+"/"+verb]++
+	return nil
+}
+
 func TestGetLog(t *testing.T) {
 	srv, err := New(&config.Config{
 		LOGS_API:                 true,
@@ -159,6 +190,148 @@ func TestGetLog(t *testing.T) {
 	}
 }
 
+func TestGetLog_Range(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		LOGS_BUFFER_SIZE:         4,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+
+	data := "one\ntwo\nthree\n"
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	_, err = srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz"),
+			Data: &pb.Any{
+				Type: v1alpha2.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+					Spec: v1alpha2.LogSpec{
+						Resource: v1alpha2.Resource{
+							Namespace: "foo",
+							Name:      "baz",
+						},
+						Type: v1alpha2.FileLogType,
+					},
+					Status: v1alpha2.LogStatus{
+						Path: path,
+						Size: int64(len(data)),
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	name := log.FormatName(res.GetName(), "baz")
+	for _, tc := range []struct {
+		name       string
+		req        *pb.GetLogRequest
+		want       string
+		wantOffset int64
+		wantCode   codes.Code
+	}{
+		{
+			name: "whole log",
+			req:  &pb.GetLogRequest{Name: name},
+			want: data,
+		},
+		{
+			name:       "offset",
+			req:        &pb.GetLogRequest{Name: name, Offset: 4},
+			want:       "two\nthree\n",
+			wantOffset: 4,
+		},
+		{
+			name:       "offset and limit",
+			req:        &pb.GetLogRequest{Name: name, Offset: 4, LimitBytes: 6},
+			want:       "two\nth",
+			wantOffset: 4,
+		},
+		{
+			name:       "end of the log",
+			req:        &pb.GetLogRequest{Name: name, Offset: int64(len(data))},
+			want:       "",
+			wantOffset: int64(len(data)),
+		},
+		{
+			name:       "tail",
+			req:        &pb.GetLogRequest{Name: name, TailLines: 2},
+			want:       "two\nthree\n",
+			wantOffset: 4,
+		},
+		{
+			name:       "tail with limit",
+			req:        &pb.GetLogRequest{Name: name, TailLines: 2, LimitBytes: 8},
+			want:       "o\nthree\n",
+			wantOffset: 6,
+		},
+		{
+			name:     "past the end",
+			req:      &pb.GetLogRequest{Name: name, Offset: int64(len(data)) + 1},
+			wantCode: codes.OutOfRange,
+		},
+		{
+			name:     "negative offset",
+			req:      &pb.GetLogRequest{Name: name, Offset: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "offset and tail",
+			req:      &pb.GetLogRequest{Name: name, Offset: 1, TailLines: 1},
+			wantCode: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockGetLogServer{
+				ctx:          ctx,
+				receivedData: &bytes.Buffer{},
+			}
+			err := srv.GetLog(tc.req, mock)
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("GetLog: want %v, got %v", tc.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := mock.receivedData.String(); got != tc.want {
+				t.Errorf("want data %q, got %q", tc.want, got)
+			}
+			if len(mock.messages) == 0 {
+				t.Fatal("no message received")
+			}
+			first := mock.messages[0]
+			if first.GetSize() != int64(len(data)) || first.GetOffset() != tc.wantOffset {
+				t.Errorf("want size %d and offset %d in the first message, got %d and %d", len(data), tc.wantOffset, first.GetSize(), first.GetOffset())
+			}
+			for _, m := range mock.messages[1:] {
+				if m.GetSize() != 0 || m.GetOffset() != 0 {
+					t.Errorf("want size and offset only in the first message, got %+v", m)
+				}
+			}
+		})
+	}
+}
+
 func TestUpdateLog(t *testing.T) {
 	testDir, err := os.MkdirTemp("", "test-logs-")
 	c := &config.Config{
@@ -221,6 +394,8 @@ func TestUpdateLog(t *testing.T) {
 		t.Fatalf("CreateRecord: %v", err)
 	}
 
+	checks := countingChecker{}
+	srv.auth = checks
 	mock := &mockUpdateLogServer{
 		ctx:       ctx,
 		record:    rec,
@@ -230,6 +405,12 @@ func TestUpdateLog(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to put log: %v", err)
 	}
+	// The stream is authorized once, not for each message, nor when the
+	// Log record is updated once the upload ends.
+	want := countingChecker{auth.ResourceLogs + "/" + auth.PermissionUpdate: 1}
+	if diff := cmp.Diff(want, checks); diff != "" {
+		t.Errorf("checks (-want, +got): %s", diff)
+	}
 	actualData, err := os.ReadFile(path)
 	if err != nil {
 		t.Fatalf("failed to read data from file: %v", err)
@@ -241,6 +422,466 @@ func TestUpdateLog(t *testing.T) {
 	if mock.bytesReceived != int64(len(expectedData)) {
 		t.Errorf("expected to have received %d bytes, got %d", len(expectedData), mock.bytesReceived)
 	}
+
+	// The log is marked as complete once it is uploaded.
+	got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: recordName})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	object := &v1alpha2.Log{}
+	if err := json.Unmarshal(got.GetData().GetValue(), object); err != nil {
+		t.Fatalf("failed to unmarshal log: %v", err)
+	}
+	if object.Status.InProgress || object.Status.Size != int64(len(expectedData)) {
+		t.Errorf("want complete log of %d bytes, got %+v", len(expectedData), object.Status)
+	}
+}
+
+func TestUpdateLog_Resume(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *config.Config
+	}{
+		{
+			name:   "file",
+			config: &config.Config{LOGS_TYPE: string(v1alpha2.FileLogType)},
+		},
+		{
+			name:   "compressed file",
+			config: &config.Config{LOGS_TYPE: string(v1alpha2.FileLogType), LOGS_COMPRESSION: "gzip"},
+		},
+		{
+			name:   "database",
+			config: &config.Config{LOGS_TYPE: string(v1alpha2.DBLogType)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.LOGS_API = true
+			tc.config.LOGS_PATH = t.TempDir()
+			tc.config.DB_ENABLE_AUTO_MIGRATION = true
+			srv, err := New(tc.config, logger.Get("info"), test.NewDB(t))
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+			ctx := context.Background()
+			res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+				Parent: "foo",
+				Result: &pb.Result{Name: result.FormatName("foo", "bar")},
+			})
+			if err != nil {
+				t.Fatalf("CreateResult: %v", err)
+			}
+			rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+				Parent: res.GetName(),
+				Record: &pb.Record{
+					Name: record.FormatName(res.GetName(), "baz-log"),
+					Data: &pb.Any{
+						Type: v1alpha2.LogRecordType,
+						Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+							ObjectMeta: metav1.ObjectMeta{Name: "baz-log", UID: "baz-uid"},
+							Spec: v1alpha2.LogSpec{
+								Resource: v1alpha2.Resource{Namespace: "foo", Name: "baz"},
+								Type:     v1alpha2.LogType(tc.config.LOGS_TYPE),
+							},
+						}),
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("CreateRecord: %v", err)
+			}
+			name := log.FormatName(res.GetName(), "baz-log")
+			check := func(wantData string, wantStatus v1alpha2.LogStatus) {
+				t.Helper()
+				mock := &mockGetLogServer{ctx: ctx, receivedData: &bytes.Buffer{}}
+				if err := srv.GetLog(&pb.GetLogRequest{Name: name}, mock); err != nil {
+					t.Fatalf("GetLog: %v", err)
+				}
+				if got := mock.receivedData.String(); got != wantData {
+					t.Errorf("want log %q, got %q", wantData, got)
+				}
+				got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()})
+				if err != nil {
+					t.Fatalf("GetRecord: %v", err)
+				}
+				object := &v1alpha2.Log{}
+				if err := json.Unmarshal(got.GetData().GetValue(), object); err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(wantStatus, object.Status, cmpopts.IgnoreFields(v1alpha2.LogStatus{}, "Path", "Compression")); diff != "" {
+					t.Errorf("status (-want, +got): %s", diff)
+				}
+			}
+			checksum := func(data string) string {
+				sum := sha256.Sum256([]byte(data))
+				return "sha256:" + hex.EncodeToString(sum[:])
+			}
+
+			// The upload is interrupted.
+			interrupted := status.Error(codes.Unavailable, "connection lost")
+			if err := srv.UpdateLog(&mockUpdateLogServer{
+				ctx:       ctx,
+				record:    rec,
+				logStream: []string{"Hello ", "world! "},
+				err:       interrupted,
+			}); err != interrupted {
+				t.Fatalf("UpdateLog: want %v, got %v", interrupted, err)
+			}
+			check("Hello world! ", v1alpha2.LogStatus{Size: 13, Upload: v1alpha2.LogUploadIncomplete})
+
+			// The upload resumes at the size of the stored log only.
+			if err := srv.UpdateLog(&mockUpdateLogServer{
+				ctx:       ctx,
+				record:    rec,
+				logStream: []string{"world! "},
+				offset:    6,
+			}); status.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("UpdateLog: want FailedPrecondition, got %v", err)
+			}
+			check("Hello world! ", v1alpha2.LogStatus{Size: 13, Upload: v1alpha2.LogUploadIncomplete})
+
+			if err := srv.UpdateLog(&mockUpdateLogServer{
+				ctx:       ctx,
+				record:    rec,
+				logStream: []string{"This is ", "Tekton Results."},
+				offset:    13,
+			}); err != nil {
+				t.Fatalf("UpdateLog: %v", err)
+			}
+			data := "Hello world! This is Tekton Results."
+			check(data, v1alpha2.LogStatus{Size: int64(len(data)), Upload: v1alpha2.LogUploadComplete, Checksum: checksum(data)})
+
+			// Uploads from the start replace the stored log.
+			if err := srv.UpdateLog(&mockUpdateLogServer{
+				ctx:       ctx,
+				record:    rec,
+				logStream: []string{"Hello again!"},
+			}); err != nil {
+				t.Fatalf("UpdateLog: %v", err)
+			}
+			check("Hello again!", v1alpha2.LogStatus{Size: 12, Upload: v1alpha2.LogUploadComplete, Checksum: checksum("Hello again!")})
+		})
+	}
+}
+
+func TestGetLogDownloadURL(t *testing.T) {
+	c := &config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                string(v1alpha2.S3LogType),
+		LOGS_PATH:                "/logs",
+		S3_DOWNLOAD_URL_EXPIRY:   5 * time.Minute,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}
+	data := "Hello world!"
+	test.NewS3(t, c, map[string]string{"/logs/foo/baz-log": data})
+	srv, err := New(c, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: result.FormatName("foo", "bar")},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	createLog := func(name string, logType v1alpha2.LogType) string {
+		t.Helper()
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), name),
+				Data: &pb.Any{
+					Type: v1alpha2.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+						Spec:   v1alpha2.LogSpec{Type: logType},
+						Status: v1alpha2.LogStatus{Path: "foo/" + name, Size: int64(len(data))},
+					}),
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		return log.FormatName(res.GetName(), name)
+	}
+
+	name := createLog("baz-log", v1alpha2.S3LogType)
+	got, err := srv.GetLogDownloadURL(ctx, &pb.GetLogDownloadURLRequest{Name: name})
+	if err != nil {
+		t.Fatalf("GetLogDownloadURL: %v", err)
+	}
+	if got.GetSize() != int64(len(data)) || !got.GetExpireTime().AsTime().Equal(fakeClock.Now().Add(5*time.Minute)) {
+		t.Errorf("want the size of the log and the expiry of the URL, got %+v", got)
+	}
+	resp, err := http.Get(got.GetUrl())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != data {
+		t.Errorf("want the log downloaded, got %s: %s", resp.Status, body)
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.GetLogDownloadURLRequest
+		want codes.Code
+	}{
+		{
+			name: "invalid name",
+			req:  &pb.GetLogDownloadURLRequest{Name: "foo/results/bar"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			req:  &pb.GetLogDownloadURLRequest{Name: log.FormatName(res.GetName(), "missing")},
+			want: codes.NotFound,
+		},
+		{
+			name: "not stored in S3",
+			req:  &pb.GetLogDownloadURLRequest{Name: createLog("file-log", v1alpha2.FileLogType)},
+			want: codes.FailedPrecondition,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := srv.GetLogDownloadURL(ctx, tc.req); status.Code(err) != tc.want {
+				t.Errorf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	// Download URLs are disabled without an expiry.
+	srv.config.S3_DOWNLOAD_URL_EXPIRY = 0
+	if _, err := srv.GetLogDownloadURL(ctx, &pb.GetLogDownloadURLRequest{Name: name}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("want Unimplemented, got %v", err)
+	}
+}
+
+func TestGetLog_Compression(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		LOGS_PATH:                t.TempDir(),
+		LOGS_COMPRESSION:         "gzip",
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz"),
+			Data: &pb.Any{
+				Type: v1alpha2.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+					Spec: v1alpha2.LogSpec{
+						Resource: v1alpha2.Resource{
+							Namespace: "foo",
+							Name:      "baz",
+						},
+						Type: v1alpha2.FileLogType,
+					},
+					Status: v1alpha2.LogStatus{
+						Path: "baz.log",
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	if err := srv.UpdateLog(&mockUpdateLogServer{
+		ctx:       ctx,
+		record:    rec,
+		logStream: []string{"one\ntwo\n", "three\n"},
+	}); err != nil {
+		t.Fatalf("UpdateLog: %v", err)
+	}
+
+	// The codec is recorded in the status of the log.
+	got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	object := &v1alpha2.Log{}
+	if err := json.Unmarshal(got.GetData().GetValue(), object); err != nil {
+		t.Fatalf("failed to unmarshal log: %v", err)
+	}
+	if object.Status.Compression != v1alpha2.GzipLogCompression || object.Status.Size != int64(len("one\ntwo\nthree\n")) {
+		t.Errorf("want gzip log of 14 bytes, got %+v", object.Status)
+	}
+
+	name := log.FormatName(res.GetName(), "baz")
+	for _, tc := range []struct {
+		name            string
+		req             *pb.GetLogRequest
+		want            string
+		wantCompression string
+	}{
+		{
+			name: "decompressed",
+			req:  &pb.GetLogRequest{Name: name},
+			want: "one\ntwo\nthree\n",
+		},
+		{
+			name: "unsupported codec",
+			req:  &pb.GetLogRequest{Name: name, AcceptCompression: []string{"zstd"}},
+			want: "one\ntwo\nthree\n",
+		},
+		{
+			name: "range",
+			req:  &pb.GetLogRequest{Name: name, Offset: 4, LimitBytes: 3, AcceptCompression: []string{"gzip"}},
+			want: "two",
+		},
+		{
+			name: "tail",
+			req:  &pb.GetLogRequest{Name: name, TailLines: 1},
+			want: "three\n",
+		},
+		{
+			name:            "compressed",
+			req:             &pb.GetLogRequest{Name: name, AcceptCompression: []string{"zstd", "gzip"}},
+			want:            "one\ntwo\nthree\n",
+			wantCompression: "gzip",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockGetLogServer{
+				ctx:          ctx,
+				receivedData: &bytes.Buffer{},
+			}
+			if err := srv.GetLog(tc.req, mock); err != nil {
+				t.Fatalf("GetLog: %v", err)
+			}
+			if got := mock.messages[0].GetCompression(); got != tc.wantCompression {
+				t.Fatalf("want compression %q, got %q", tc.wantCompression, got)
+			}
+			data := mock.receivedData.Bytes()
+			if tc.wantCompression != "" {
+				r, err := gzip.NewReader(mock.receivedData)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				if data, err = io.ReadAll(r); err != nil {
+					t.Fatalf("failed to decompress log: %v", err)
+				}
+			}
+			if string(data) != tc.want {
+				t.Errorf("want data %q, got %q", tc.want, data)
+			}
+		})
+	}
+}
+
+func TestGetLog_Follow(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "log")
+	appendLog := func(data string) {
+		t.Helper()
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatalf("failed to open log: %v", err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(data); err != nil {
+			t.Fatalf("failed to write log: %v", err)
+		}
+	}
+	appendLog("one\n")
+	object := &v1alpha2.Log{
+		Spec: v1alpha2.LogSpec{
+			Resource: v1alpha2.Resource{
+				Namespace: "foo",
+				Name:      "baz",
+			},
+			Type: v1alpha2.FileLogType,
+		},
+		Status: v1alpha2.LogStatus{
+			Path:       path,
+			InProgress: true,
+		},
+	}
+	rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz"),
+			Data: &pb.Any{
+				Type:  v1alpha2.LogRecordType,
+				Value: jsonutil.AnyBytes(t, object),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	mock := &mockGetLogServer{
+		ctx:          ctx,
+		receivedData: &bytes.Buffer{},
+	}
+	done := make(chan error)
+	go func() {
+		done <- srv.GetLog(&pb.GetLogRequest{Name: log.FormatName(res.GetName(), "baz"), Follow: true}, mock)
+	}()
+
+	// The data appended while the log is uploaded is streamed.
+	fakeClock.BlockUntil(1)
+	appendLog("two\n")
+	fakeClock.Advance(logFollowInterval)
+
+	// The stream ends once the upload completes, with the data appended
+	// before it completed.
+	fakeClock.BlockUntil(1)
+	appendLog("three\n")
+	object.Status.InProgress = false
+	object.Status.Size = int64(len("one\ntwo\nthree\n"))
+	rec.Data.Value = jsonutil.AnyBytes(t, object)
+	if _, err := srv.UpdateRecord(ctx, &pb.UpdateRecordRequest{Record: rec}); err != nil {
+		t.Fatalf("UpdateRecord: %v", err)
+	}
+	fakeClock.Advance(logFollowInterval)
+
+	if err := <-done; err != nil {
+		t.Fatalf("GetLog: %v", err)
+	}
+	if got, want := mock.receivedData.String(), "one\ntwo\nthree\n"; got != want {
+		t.Errorf("want data %q, got %q", want, got)
+	}
 }
 
 func TestListLogs(t *testing.T) {
@@ -377,7 +1018,7 @@ func TestListLogs(t *testing.T) {
 			},
 			want: &pb.ListRecordsResponse{
 				Records:       records[:1],
-				NextPageToken: pagetoken(t, records[1].GetUid(), `data_type == "results.tekton.dev/v1alpha2.Log"`),
+				NextPageToken: pagetoken(t, records[0].GetUid(), `data_type == "results.tekton.dev/v1alpha2.Log"`),
 			},
 		},
 		{
@@ -388,7 +1029,7 @@ func TestListLogs(t *testing.T) {
 			},
 			want: &pb.ListRecordsResponse{
 				Records:       records[:1],
-				NextPageToken: pagetoken(t, records[1].GetUid(), ""),
+				NextPageToken: pagetoken(t, records[0].GetUid(), ""),
 			},
 		},
 		// Order By
@@ -651,3 +1292,204 @@ func TestDeleteLog(t *testing.T) {
 		}
 	})
 }
+
+func TestLogs_DBLogType(t *testing.T) {
+	gdb := test.NewDB(t)
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                string(v1alpha2.DBLogType),
+		LOGS_BUFFER_SIZE:         4,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), gdb)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz"),
+			Data: &pb.Any{
+				Type: v1alpha2.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "baz-log",
+						UID:  "baz-uid",
+					},
+					Spec: v1alpha2.LogSpec{
+						Resource: v1alpha2.Resource{
+							Namespace: "foo",
+							Name:      "baz",
+						},
+						Type: v1alpha2.DBLogType,
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	data := "Hello world! This is Tekton Results."
+	if err := srv.UpdateLog(&mockUpdateLogServer{
+		ctx:       ctx,
+		record:    rec,
+		logStream: []string{data[:13], data[13:]},
+	}); err != nil {
+		t.Fatalf("UpdateLog: %v", err)
+	}
+
+	name := log.FormatName(res.GetName(), "baz")
+	mock := &mockGetLogServer{
+		ctx:          ctx,
+		receivedData: &bytes.Buffer{},
+	}
+	if err := srv.GetLog(&pb.GetLogRequest{Name: name, Offset: 6, LimitBytes: 5}, mock); err != nil {
+		t.Fatalf("GetLog: %v", err)
+	}
+	if got := mock.receivedData.String(); got != "world" {
+		t.Errorf("want data %q, got %q", "world", got)
+	}
+	if size := mock.messages[0].GetSize(); size != int64(len(data)) {
+		t.Errorf("want size %d, got %d", len(data), size)
+	}
+
+	if _, err := srv.DeleteLog(ctx, &pb.DeleteLogRequest{Name: name}); err != nil {
+		t.Fatalf("DeleteLog: %v", err)
+	}
+	var chunks int64
+	if err := gdb.Model(&db.LogChunk{}).Count(&chunks).Error; err != nil {
+		t.Fatal(err)
+	}
+	if chunks != 0 {
+		t.Errorf("want log chunks deleted, got %d", chunks)
+	}
+}
+
+func TestLogs_Steps(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		LOGS_PATH:                t.TempDir(),
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	// The Record of the TaskRun is named after its UID.
+	if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "run-uid"),
+			Data: &pb.Any{Type: "tekton.dev/v1beta1.TaskRun", Value: []byte("{}")},
+		},
+	}); err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	// The run has a combined log, and a log per step.
+	for _, l := range []struct {
+		name string
+		step *v1alpha2.LogStep
+		data string
+	}{
+		{name: "combined", data: "[build] building\n[push] pushing\n"},
+		{name: "build", step: &v1alpha2.LogStep{Name: "build", Container: "step-build"}, data: "building\n"},
+		{name: "push", step: &v1alpha2.LogStep{Name: "push", Container: "step-push"}, data: "pushing\n"},
+	} {
+		rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), l.name),
+				Data: &pb.Any{
+					Type: v1alpha2.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: l.name},
+						Spec: v1alpha2.LogSpec{
+							Resource: v1alpha2.Resource{Kind: "TaskRun", Namespace: "foo", Name: "run", UID: "run-uid"},
+							Type:     v1alpha2.FileLogType,
+							Step:     l.step,
+						},
+					}),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		if err := srv.UpdateLog(&mockUpdateLogServer{ctx: ctx, record: rec, logStream: []string{l.data}}); err != nil {
+			t.Fatalf("UpdateLog: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		req    *pb.GetLogRequest
+		want   string
+		status codes.Code
+	}{
+		{
+			name: "combined log of the run",
+			req:  &pb.GetLogRequest{Name: log.FormatName(res.GetName(), "run-uid")},
+			want: "[build] building\n[push] pushing\n",
+		},
+		{
+			name: "step of the run",
+			req:  &pb.GetLogRequest{Name: log.FormatName(res.GetName(), "run-uid"), Step: "build"},
+			want: "building\n",
+		},
+		{
+			name: "step of a log of the run",
+			req:  &pb.GetLogRequest{Name: log.FormatName(res.GetName(), "build"), Step: "push"},
+			want: "pushing\n",
+		},
+		{
+			name:   "missing step",
+			req:    &pb.GetLogRequest{Name: log.FormatName(res.GetName(), "combined"), Step: "test"},
+			status: codes.NotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockGetLogServer{ctx: ctx}
+			err := srv.GetLog(tc.req, mock)
+			if status.Code(err) != tc.status {
+				t.Fatalf("GetLog: want status %v, got %v", tc.status, err)
+			}
+			if got := mock.receivedData.String(); err == nil && got != tc.want {
+				t.Errorf("GetLog: want %q, got %q", tc.want, got)
+			}
+		})
+	}
+
+	// Logs are filtered by step.
+	got, err := srv.ListLogs(ctx, &pb.ListRecordsRequest{
+		Parent: res.GetName(),
+		Filter: `data.spec.step.name == "push"`,
+	})
+	if err != nil {
+		t.Fatalf("ListLogs: %v", err)
+	}
+	if len(got.GetRecords()) != 1 || got.GetRecords()[0].GetName() != log.FormatName(res.GetName(), "push") {
+		t.Errorf("ListLogs: want the log of the push step, got %v", got.GetRecords())
+	}
+}
@@ -89,3 +89,25 @@ func orderByDirection(field string, direction string) (string, error) {
 		return "", status.Errorf(codes.InvalidArgument, "invalid sort direction %q", direction)
 	}
 }
+
+// sortKey is a field of a normalized order by clause.
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+// sortKeys splits an order by clause returned by orderBy into its fields.
+func sortKeys(sortOrder string) []sortKey {
+	if sortOrder == "" {
+		return nil
+	}
+	var keys []sortKey
+	for _, field := range strings.Split(sortOrder, ",") {
+		f := strings.Fields(field)
+		keys = append(keys, sortKey{
+			field: f[0],
+			desc:  len(f) == 2 && f[1] == "DESC",
+		})
+	}
+	return keys
+}
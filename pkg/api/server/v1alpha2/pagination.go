@@ -16,10 +16,15 @@ package server
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 const (
@@ -38,17 +43,117 @@ func pageSize(in int) (int, error) {
 	return in, nil
 }
 
-func pageStart(token, filter string) (string, error) {
+// pageStart decodes the page token of a List request. The token must have
+// been issued for the same filter and (normalized) order by clause. A nil
+// token is returned for the first page.
+func pageStart(token, filter, sortOrder string) (*pagination.Token, error) {
 	if token == "" {
-		return "", nil
+		return nil, nil
 	}
 
-	tokenName, tokenFilter, err := pagination.DecodeToken(token)
+	t, err := pagination.ParseToken(token)
 	if err != nil {
-		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("invalid PageToken: %v", err))
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid PageToken: %v", err))
 	}
-	if filter != tokenFilter {
-		return "", status.Error(codes.InvalidArgument, "filter does not match previous query")
+	if filter != t.Filter {
+		return nil, status.Error(codes.InvalidArgument, "filter does not match previous query")
 	}
-	return tokenName, nil
+	if sortOrder != t.OrderBy {
+		return nil, status.Error(codes.InvalidArgument, "order_by does not match previous query")
+	}
+	if len(t.SortValues) != len(sortKeys(sortOrder)) {
+		return nil, status.Error(codes.InvalidArgument, "invalid PageToken: sort values do not match order_by")
+	}
+	return t, nil
+}
+
+// pageQuery orders q by the sort order followed by id, which makes the order
+// total, and restricts it to the items following start (if any). This is
+// keyset pagination: for `created_time DESC` the items following (t, id) are
+// those with `created_time < t OR (created_time = t AND id > id)`. The sort
+// keys are timestamps, which are compared and ordered as such, e.g. instead
+// of as text on SQLite.
+func pageQuery(q *gorm.DB, sortOrder string, start *pagination.Token) *gorm.DB {
+	keys := sortKeys(sortOrder)
+	columns := make([]string, len(keys))
+	for i, k := range keys {
+		column, err := cel2sql.Timestamp(q.Dialector.Name(), k.field)
+		if err != nil {
+			q.AddError(err)
+			return q
+		}
+		columns[i] = column
+	}
+	arg, err := cel2sql.Timestamp(q.Dialector.Name(), "?")
+	if err != nil {
+		q.AddError(err)
+		return q
+	}
+
+	if start != nil {
+		var (
+			or   []string
+			args []interface{}
+		)
+		for i := 0; i <= len(keys); i++ {
+			var and []string
+			for j := 0; j < i; j++ {
+				and = append(and, columns[j]+" = "+arg)
+				args = append(args, start.SortValues[j])
+			}
+			if i < len(keys) {
+				op := " > "
+				if keys[i].desc {
+					op = " < "
+				}
+				and = append(and, columns[i]+op+arg)
+				args = append(args, start.SortValues[i])
+			} else {
+				and = append(and, "id > ?")
+				args = append(args, start.Name)
+			}
+			or = append(or, strings.Join(and, " AND "))
+		}
+		q = q.Where("(("+strings.Join(or, ") OR (")+"))", args...)
+	}
+	order := make([]string, 0, len(keys)+1)
+	for i, k := range keys {
+		if k.desc {
+			order = append(order, columns[i]+" DESC")
+		} else {
+			order = append(order, columns[i])
+		}
+	}
+	return q.Order(strings.Join(append(order, "id"), ","))
+}
+
+// pageCursor returns the position of an item in the sort order.
+func pageCursor(sortOrder, id string, createdTime, updatedTime time.Time) *pagination.Token {
+	t := &pagination.Token{
+		Name:    id,
+		OrderBy: sortOrder,
+	}
+	for _, k := range sortKeys(sortOrder) {
+		switch k.field {
+		case "created_time":
+			t.SortValues = append(t.SortValues, createdTime)
+		case "updated_time":
+			t.SortValues = append(t.SortValues, updatedTime)
+		}
+	}
+	return t
+}
+
+// sortable is implemented by the resources returned by List methods.
+type sortable interface {
+	GetId() string
+	GetCreateTime() *timestamppb.Timestamp
+	GetUpdateTime() *timestamppb.Timestamp
+}
+
+// nextPageToken returns the token of the page following item.
+func nextPageToken(item sortable, filter, sortOrder string) (string, error) {
+	t := pageCursor(sortOrder, item.GetId(), item.GetCreateTime().AsTime(), item.GetUpdateTime().AsTime())
+	t.Filter = filter
+	return t.Encode()
 }
@@ -15,8 +15,21 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/pagination"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 )
 
 func TestPageSize(t *testing.T) {
@@ -48,24 +61,54 @@ func TestPageSize(t *testing.T) {
 }
 
 func TestPageStart(t *testing.T) {
+	ts := time.Unix(1, 0).UTC()
+	sorted, err := (&pagination.Token{Name: "a", Filter: "b", OrderBy: "created_time DESC", SortValues: []time.Time{ts}}).Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
 	for _, tc := range []struct {
-		name   string
-		token  string
-		filter string
-		want   string
-		err    bool
+		name      string
+		token     string
+		filter    string
+		sortOrder string
+		want      *pagination.Token
+		err       bool
 	}{
 		{
 			name:   "success",
 			token:  pagetoken(t, "a", "b"),
 			filter: "b",
-			want:   "a",
+			want:   &pagination.Token{Name: "a", Filter: "b"},
+		},
+		{
+			name:      "sorted",
+			token:     sorted,
+			filter:    "b",
+			sortOrder: "created_time DESC",
+			want:      &pagination.Token{Name: "a", Filter: "b", OrderBy: "created_time DESC", SortValues: []time.Time{ts}},
+		},
+		{
+			name: "first page",
 		},
 		{
 			name:  "wrong filter",
 			token: pagetoken(t, "a", "c"),
 			err:   true,
 		},
+		{
+			name:      "wrong order by",
+			token:     sorted,
+			filter:    "b",
+			sortOrder: "created_time ASC",
+			err:       true,
+		},
+		{
+			name:      "missing sort values",
+			token:     pagetoken(t, "a", "b"),
+			filter:    "b",
+			sortOrder: "created_time",
+			err:       true,
+		},
 		{
 			name:  "invalid token",
 			token: "tacocat",
@@ -73,9 +116,88 @@ func TestPageStart(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := pageStart(tc.token, tc.filter)
-			if got != tc.want || (err == nil && tc.err) {
-				t.Errorf("want (%s, %t), got (%s, %v)", tc.want, tc.err, got, err)
+			got, err := pageStart(tc.token, tc.filter, tc.sortOrder)
+			if (err != nil) != tc.err {
+				t.Fatalf("want error %t, got %v", tc.err, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPageQuery_Timestamps(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	// SQLite stores timestamps as text, in the time zone they were written
+	// in, and with as many fractional digits as needed, which doesn't order
+	// as the times of the page tokens do.
+	base := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	est := time.FixedZone("EST", -5*60*60)
+	created := []time.Time{
+		base,
+		base.In(est),
+		base.Add(500 * time.Millisecond).In(est),
+		base.Add(time.Second),
+		base.Add(1500 * time.Millisecond),
+		base.Add(2 * time.Second).In(est),
+		base.Add(-time.Hour),
+	}
+	for i, c := range created {
+		if err := srv.db.Create(&db.Result{
+			Parent:      "page",
+			ID:          strconv.Itoa(i),
+			Name:        strconv.Itoa(i),
+			CreatedTime: c,
+			UpdatedTime: c,
+		}).Error; err != nil {
+			t.Fatalf("failed to create Result: %v", err)
+		}
+	}
+
+	for _, order := range []string{"created_time", "created_time desc"} {
+		t.Run(order, func(t *testing.T) {
+			want := make([]int, len(created))
+			for i := range want {
+				want[i] = i
+			}
+			sort.SliceStable(want, func(i, j int) bool {
+				a, b := created[want[i]], created[want[j]]
+				if strings.HasSuffix(order, "desc") {
+					return a.After(b)
+				}
+				return a.Before(b)
+			})
+
+			var got []int
+			req := &pb.ListResultsRequest{Parent: "page", OrderBy: order, PageSize: 2}
+			for page := 0; ; page++ {
+				if page > len(created) {
+					t.Fatalf("too many pages, got %v", got)
+				}
+				resp, err := srv.ListResults(ctx, req)
+				if err != nil {
+					t.Fatalf("ListResults: %v", err)
+				}
+				for _, r := range resp.GetResults() {
+					id, err := strconv.Atoi(r.GetId())
+					if err != nil {
+						t.Fatal(err)
+					}
+					got = append(got, id)
+				}
+				if resp.GetNextPageToken() == "" {
+					break
+				}
+				req.PageToken = resp.GetNextPageToken()
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
 			}
 		})
 	}
@@ -0,0 +1,117 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// parentsCache keeps the parents of all Results until they expire.
+type parentsCache struct {
+	mu      sync.Mutex
+	parents []string
+	expiry  time.Time
+}
+
+// checkList checks whether the caller may list the resources of the parent. If
+// list filtering is enabled, lists across parents (`-`) of callers that may not
+// list all parents are filtered to the parents they may list, which are
+// returned with filtered set.
+func (s *Server) checkList(ctx context.Context, parent, resource string) ([]string, bool, error) {
+	if f, ok := s.auth.(auth.ParentFilter); ok && parent == "-" && s.config.AUTH_FILTER_LISTS {
+		return f.FilterParents(ctx, resource, auth.PermissionList, s.parents)
+	}
+	return nil, false, s.auth.Check(ctx, parent, resource, auth.PermissionList)
+}
+
+// checkWatch checks whether the caller may watch the resources of the parent,
+// and returns whether it may watch those of a given parent. If list filtering
+// is enabled, watches across parents (`-`) of callers that may not watch all
+// parents only get the events of the parents they may watch, which are
+// authorized as their first event comes. The decisions are kept for
+// AUTH_CACHE_TTL, in a cache of AUTH_CACHE_SIZE parents, and the parents are
+// authorized again once they expire.
+func (s *Server) checkWatch(ctx context.Context, parent, resource string) (func(parent string) bool, error) {
+	all := func(string) bool { return true }
+	f, ok := s.auth.(auth.ParentFilter)
+	if !ok || parent != "-" || !s.config.AUTH_FILTER_LISTS {
+		return all, s.auth.Check(ctx, parent, resource, auth.PermissionWatch)
+	}
+	_, filtered, err := f.FilterParents(ctx, resource, auth.PermissionWatch, func(context.Context) ([]string, error) {
+		return nil, nil
+	})
+	if err != nil || !filtered {
+		return all, err
+	}
+
+	var allowed *cache.LRUExpireCache
+	ttl := s.config.AUTH_CACHE_TTL
+	if size := s.config.AUTH_CACHE_SIZE; size > 0 && ttl > 0 {
+		allowed = cache.NewLRUExpireCacheWithClock(size, clock)
+	}
+	return func(parent string) bool {
+		if allowed != nil {
+			if ok, found := allowed.Get(parent); found {
+				return ok.(bool)
+			}
+		}
+		parents, filtered, err := f.FilterParents(ctx, resource, auth.PermissionWatch, func(context.Context) ([]string, error) {
+			return []string{parent}, nil
+		})
+		if err != nil {
+			s.logger.Debugf("failed to authorize watch of %s: %v", parent, err)
+		}
+		ok := err == nil && (!filtered || len(parents) == 1)
+		if allowed != nil {
+			allowed.Add(parent, ok, ttl)
+		}
+		return ok
+	}, nil
+}
+
+// parents returns the parents of all Results, including the deleted ones. They
+// are cached for AUTH_CACHE_TTL, so that new parents are listed once it
+// expires.
+func (s *Server) parents(ctx context.Context) ([]string, error) {
+	ttl := s.config.AUTH_CACHE_TTL
+	c := &s.parentsCache
+	c.mu.Lock()
+	cached, fresh := c.parents, ttl > 0 && clock.Now().Before(c.expiry)
+	c.mu.Unlock()
+	if fresh {
+		return cached, nil
+	}
+
+	// The parents are queried outside of the lock, so that lists do not wait
+	// for each other.
+	var parents []string
+	q := s.db.WithContext(ctx).Unscoped().Model(&db.Result{}).Distinct().Pluck("parent", &parents)
+	if err := errors.Wrap(q.Error); err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.parents, c.expiry = parents, clock.Now().Add(ttl)
+	}
+	return parents, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parentChecker allows the requests on its parents, filtering lists and
+// watches across parents to them.
+type parentChecker []string
+
+func (c parentChecker) Check(_ context.Context, parent, _, _ string) error {
+	for _, p := range c {
+		if p == parent {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+func (c parentChecker) FilterParents(ctx context.Context, _, _ string, candidates auth.Candidates) ([]string, bool, error) {
+	parents, err := candidates(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	filtered := []string{}
+	for _, p := range parents {
+		if c.Check(ctx, p, "", "") == nil {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, true, nil
+}
+
+func TestParents(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, AUTH_CACHE_TTL: time.Minute}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	create := func(parent string) {
+		t.Helper()
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: parent,
+			Result: &pb.Result{Name: result.FormatName(parent, "a")},
+		}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+	}
+	parents := func(want ...string) {
+		t.Helper()
+		got, err := srv.parents(ctx)
+		if err != nil {
+			t.Fatalf("parents: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("parents (-want, +got): %s", diff)
+		}
+	}
+
+	create("foo")
+	parents("foo")
+	// New parents are listed once the cache expires.
+	create("bar")
+	parents("foo")
+	fakeClock.Advance(time.Minute)
+	parents("bar", "foo")
+}
+
+func TestWatch_FilterParents(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, AUTH_FILTER_LISTS: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cursor := watchCursor(t, srv)
+	names := []string{"foo/results/a", "bar/results/a", "foo/results/b"}
+	for _, name := range names {
+		p, _, err := result.ParseName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: p,
+			Result: &pb.Result{Name: name},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), "a"),
+				Data: &pb.Any{Type: "test", Value: []byte("{}")},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+	}
+	WithAuth(parentChecker{"foo"})(srv)
+
+	results := &mockWatchResultsServer{ctx: ctx, events: make(chan *pb.WatchResultsResponse, 10)}
+	records := &mockWatchRecordsServer{ctx: ctx, events: make(chan *pb.WatchRecordsResponse, 10)}
+	done := make(chan error, 2)
+	go func() {
+		done <- srv.WatchResults(&pb.WatchResultsRequest{Parent: "-", Cursor: cursor}, results)
+	}()
+	go func() {
+		done <- srv.WatchRecords(&pb.WatchRecordsRequest{Parent: "-/results/-", Cursor: cursor}, records)
+	}()
+
+	// Only the events of the parents the caller may watch are sent.
+	for _, want := range []string{names[0], names[2]} {
+		select {
+		case e := <-results.events:
+			if e.GetResult().GetName() != want {
+				t.Errorf("want event of %s, got %v", want, e)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for Result event")
+		}
+		select {
+		case e := <-records.events:
+			if e.GetRecord().GetName() != record.FormatName(want, "a") {
+				t.Errorf("want event of %s, got %v", record.FormatName(want, "a"), e)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for Record event")
+		}
+	}
+	cancel()
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("watch: %v", err)
+		}
+	}
+	select {
+	case e := <-results.events:
+		t.Errorf("unexpected event %v", e)
+	case e := <-records.events:
+		t.Errorf("unexpected event %v", e)
+	default:
+	}
+
+	// Watches across parents are denied if filtering is disabled.
+	srv.config.AUTH_FILTER_LISTS = false
+	if err := srv.WatchResults(&pb.WatchResultsRequest{Parent: "-"}, results); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("WatchResults: %v, want %v", err, codes.Unauthenticated)
+	}
+}
+
+// revocableChecker is a parentChecker whose parents change, counting the
+// filters of parents.
+type revocableChecker struct {
+	parents parentChecker
+	filters int
+}
+
+func (c *revocableChecker) Check(ctx context.Context, parent, resource, verb string) error {
+	return c.parents.Check(ctx, parent, resource, verb)
+}
+
+func (c *revocableChecker) FilterParents(ctx context.Context, resource, verb string, candidates auth.Candidates) ([]string, bool, error) {
+	c.filters++
+	return c.parents.FilterParents(ctx, resource, verb, candidates)
+}
+
+func TestCheckWatch_Expiry(t *testing.T) {
+	srv, err := New(&config.Config{
+		DB_ENABLE_AUTO_MIGRATION: true,
+		AUTH_FILTER_LISTS:        true,
+		AUTH_CACHE_SIZE:          1,
+		AUTH_CACHE_TTL:           time.Minute,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	checker := &revocableChecker{parents: parentChecker{"foo", "bar"}}
+	WithAuth(checker)(srv)
+
+	allowed, err := srv.checkWatch(context.Background(), "-", auth.ResourceResults)
+	if err != nil {
+		t.Fatalf("checkWatch: %v", err)
+	}
+	check := func(parent string, want bool, wantFilters int) {
+		t.Helper()
+		if got := allowed(parent); got != want {
+			t.Errorf("allowed(%s): want %t, got %t", parent, want, got)
+		}
+		if checker.filters != wantFilters {
+			t.Errorf("allowed(%s): want %d filters, got %d", parent, wantFilters, checker.filters)
+		}
+	}
+
+	// The decisions are kept until they expire.
+	check("foo", true, 2)
+	check("foo", true, 2)
+	checker.parents = parentChecker{"bar"}
+	check("foo", true, 2)
+	fakeClock.Advance(time.Minute + time.Second)
+	check("foo", false, 3)
+	check("foo", false, 3)
+
+	// The cache is bounded, evicting the least recently used parents.
+	check("bar", true, 4)
+	check("foo", false, 5)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	"regexp"
+	"strings"
 
 	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -58,6 +59,20 @@ func FormatName(parent, name string) string {
 	return fmt.Sprintf("%s/records/%s", parent, name)
 }
 
+// ParseRevisionName splits the name of a Record revision, of the form
+// "<record name>@<revision id>", into its Record name and revision ID. The
+// revision ID is empty if the name does not refer to a revision.
+func ParseRevisionName(raw string) (name, revision string) {
+	name, revision, _ = strings.Cut(raw, "@")
+	return name, revision
+}
+
+// FormatRevisionName takes in a Record name ("a/results/b/records/c") and a
+// revision ID ("d") and returns the revision name ("a/results/b/records/c@d").
+func FormatRevisionName(name, revision string) string {
+	return fmt.Sprintf("%s@%s", name, revision)
+}
+
 // ToStorage converts an API Record into its corresponding database storage
 // equivalent.
 // parent,result,name should be the name parts (e.g. not containing "/results/" or "/records/").
@@ -126,6 +141,9 @@ func ToAPI(r *db.Record) (*pb.Record, error) {
 		out.UpdatedTime = timestamppb.New(r.UpdatedTime)
 		out.UpdateTime = timestamppb.New(r.UpdatedTime)
 	}
+	if r.DeleteTime.Valid {
+		out.DeleteTime = timestamppb.New(r.DeleteTime.Time)
+	}
 
 	if r.Data != nil {
 		out.Data = &pb.Any{
@@ -137,6 +155,38 @@ func ToAPI(r *db.Record) (*pb.Record, error) {
 	return out, nil
 }
 
+// NewRevision returns a revision with the current values of the Record, to be
+// saved before the Record is updated.
+func NewRevision(r *db.Record, id string) *db.RecordRevision {
+	return &db.RecordRevision{
+		Parent:      r.Parent,
+		ResultID:    r.ResultID,
+		RecordID:    r.ID,
+		ID:          id,
+		Type:        r.Type,
+		Data:        r.Data,
+		Etag:        r.Etag,
+		UpdatedTime: r.UpdatedTime,
+	}
+}
+
+// RevisionToAPI converts a revision of the given Record into its
+// corresponding API equivalent.
+func RevisionToAPI(r *db.Record, rev *db.RecordRevision) (*pb.Record, error) {
+	prev := *r
+	prev.Type = rev.Type
+	prev.Data = rev.Data
+	prev.Etag = rev.Etag
+	prev.UpdatedTime = rev.UpdatedTime
+	out, err := ToAPI(&prev)
+	if err != nil {
+		return nil, err
+	}
+	out.Name = FormatRevisionName(out.Name, rev.ID)
+	out.RevisionId = rev.ID
+	return out, nil
+}
+
 // Match determines whether the given CEL filter matches the result.
 func Match(r *pb.Record, prg cel.Program) (bool, error) {
 	if r == nil {
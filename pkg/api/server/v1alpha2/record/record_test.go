@@ -288,6 +288,54 @@ func TestToAPI(t *testing.T) {
 	}
 }
 
+func TestRevisionToAPI(t *testing.T) {
+	r := &db.Record{
+		Parent:      "foo",
+		ResultID:    "1",
+		ResultName:  "bar",
+		Name:        "baz",
+		ID:          "a",
+		Type:        "new",
+		Data:        []byte(`{"new": true}`),
+		CreatedTime: clock.Now(),
+		UpdatedTime: clock.Now().Add(time.Hour),
+		Etag:        "new-etag",
+	}
+	rev := NewRevision(&db.Record{
+		Parent:      "foo",
+		ResultID:    "1",
+		ID:          "a",
+		Type:        "old",
+		Data:        []byte(`{"old": true}`),
+		UpdatedTime: clock.Now().Add(time.Minute),
+		Etag:        "old-etag",
+	}, "rev")
+	got, err := RevisionToAPI(r, rev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &pb.Record{
+		Name:        "foo/results/bar/records/baz@rev",
+		Id:          "a",
+		Uid:         "a",
+		RevisionId:  "rev",
+		Data:        &pb.Any{Type: "old", Value: []byte(`{"old": true}`)},
+		CreatedTime: timestamppb.New(clock.Now()),
+		CreateTime:  timestamppb.New(clock.Now()),
+		UpdatedTime: timestamppb.New(clock.Now().Add(time.Minute)),
+		UpdateTime:  timestamppb.New(clock.Now().Add(time.Minute)),
+		Etag:        "old-etag",
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("-want,+got: %s", diff)
+	}
+
+	name, revision := ParseRevisionName(got.GetName())
+	if name != "foo/results/bar/records/baz" || revision != "rev" {
+		t.Errorf("ParseRevisionName(%s): got (%s, %s)", got.GetName(), name, revision)
+	}
+}
+
 func TestFormatName(t *testing.T) {
 	got := FormatName("a", "b")
 	want := "a/records/b"
@@ -20,13 +20,14 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
-	celenv "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
 	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	"github.com/tektoncd/results/pkg/internal/protoutil"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
@@ -60,6 +61,27 @@ func (s *Server) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 		return nil, err
 	}
 
+	var out *pb.Record
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		out, err = s.createRecord(tx, parent, resultName, resultID, name, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.events.PublishRecord(pb.EventType_ADDED, out)
+	return out, nil
+}
+
+// createRecord populates the server provided fields of the Record and inserts
+// it in the Result with the given ID. A soft deleted Record of the same name
+// is permanently deleted first.
+func (s *Server) createRecord(tx *gorm.DB, parent, resultName, resultID, name string, r *pb.Record) (*pb.Record, error) {
+	if err := errors.Wrap(purgeDeletedRecord(tx, parent, resultName, name)); err != nil {
+		return nil, err
+	}
+
 	// Populate Result with server provided fields.
 	protoutil.ClearOutputOnly(r)
 	r.Id = uid()
@@ -69,23 +91,40 @@ func (s *Server) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 	r.UpdatedTime = ts
 	r.UpdateTime = ts
 
-	store, err := record.ToStorage(parent, resultName, resultID, name, req.GetRecord(), s.config)
+	store, err := record.ToStorage(parent, resultName, resultID, name, r, s.config)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.redactRecord(store); err != nil {
+		return nil, err
+	}
 	if err := record.UpdateEtag(store); err != nil {
 		return nil, err
 	}
-	q := s.db.WithContext(ctx).
-		Model(store).
-		Create(store).Error
+	q := tx.Model(store).Create(store).Error
 	if err := errors.Wrap(q); err != nil {
 		return nil, err
 	}
-
 	return record.ToAPI(store)
 }
 
+// purgeDeletedRecord permanently deletes the soft deleted Record of the given
+// name, if any, which would otherwise keep the name from being used again.
+// The deletion of its stored log is queued, and done by RunLogDeletions.
+func purgeDeletedRecord(tx *gorm.DB, parent, resultName, name string) error {
+	var deleted []*db.Record
+	if err := tx.Unscoped().Where("parent = ? AND result_name = ? AND name = ? AND delete_time IS NOT NULL", parent, resultName, name).Find(&deleted).Error; err != nil {
+		return err
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+	if _, err := queueLogDeletions(tx, deleted); err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(deleted).Error
+}
+
 // resultID is a utility struct to extract partial Result data representing
 // Result name <-> ID mappings.
 type resultID struct {
@@ -107,7 +146,8 @@ func (s *Server) getResultIDImpl(ctx context.Context, parent, result string) (st
 
 // GetRecord returns a single Record.
 func (s *Server) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.Record, error) {
-	parent, result, name, err := record.ParseName(req.GetName())
+	recordName, revision := record.ParseRevisionName(req.GetName())
+	parent, result, name, err := record.ParseName(recordName)
 	if err != nil {
 		return nil, err
 	}
@@ -115,10 +155,17 @@ func (s *Server) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.R
 		return nil, err
 	}
 
-	r, err := getRecord(s.db.WithContext(ctx), parent, result, name)
+	q := s.db.WithContext(ctx)
+	if req.GetShowDeleted() {
+		q = q.Unscoped()
+	}
+	r, err := getRecord(q, parent, result, name)
 	if err != nil {
 		return nil, err
 	}
+	if revision != "" {
+		return s.getRecordRevision(ctx, r, revision)
+	}
 	return record.ToAPI(r)
 }
 
@@ -146,21 +193,25 @@ func (s *Server) ListRecords(ctx context.Context, req *pb.ListRecordsRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+	parents, filtered, err := s.checkList(ctx, parent, auth.ResourceRecords)
+	if err != nil {
 		return nil, err
 	}
+	if filtered && len(parents) == 0 {
+		return &pb.ListRecordsResponse{ParentsFiltered: true}, nil
+	}
 
 	userPageSize, err := pageSize(int(req.GetPageSize()))
 	if err != nil {
 		return nil, err
 	}
 
-	start, err := pageStart(req.GetPageToken(), req.GetFilter())
+	sortOrder, err := orderBy(req.GetOrderBy())
 	if err != nil {
 		return nil, err
 	}
 
-	sortOrder, err := orderBy(req.GetOrderBy())
+	start, err := pageStart(req.GetPageToken(), req.GetFilter(), sortOrder)
 	if err != nil {
 		return nil, err
 	}
@@ -169,38 +220,39 @@ func (s *Server) ListRecords(ctx context.Context, req *pb.ListRecordsRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	prg, err := celenv.ParseFilter(env, req.GetFilter())
+	filter, err := s.parseFilter(env, req.GetFilter(), cel2sql.RecordsView)
 	if err != nil {
 		return nil, err
 	}
 	// Fetch n+1 items to get the next token.
-	out, err := s.getFilteredPaginatedSortedRecords(ctx, req.GetParent(), start, userPageSize+1, prg, sortOrder)
+	out, err := s.getFilteredPaginatedSortedRecords(ctx, req.GetParent(), parents, start, userPageSize+1, filter, sortOrder, req.GetShowDeleted())
 	if err != nil {
 		return nil, err
 	}
 
-	// If we returned the full n+1 items, use the last element as the next page
-	// token.
+	// If we returned the full n+1 items, there is a next page starting after
+	// the last item of this one.
 	var nextToken string
 	if len(out) > userPageSize {
-		next := out[len(out)-1]
+		out = out[:userPageSize]
 		var err error
-		nextToken, err = pagination.EncodeToken(next.GetId(), req.GetFilter())
+		nextToken, err = nextPageToken(out[len(out)-1], req.GetFilter(), sortOrder)
 		if err != nil {
 			return nil, err
 		}
-		out = out[:len(out)-1]
 	}
 
 	return &pb.ListRecordsResponse{
-		Records:       out,
-		NextPageToken: nextToken,
+		Records:         out,
+		NextPageToken:   nextToken,
+		ParentsFiltered: filtered,
 	}, nil
 }
 
 // getFilteredPaginatedSortedRecords returns the specified number of results that
-// match the given CEL program.
-func (s *Server) getFilteredPaginatedSortedRecords(ctx context.Context, parent, start string, pageSize int, prg cel.Program, sortOrder string) ([]*pb.Record, error) {
+// match the given filter, including the deleted ones if showDeleted is set.
+// Lists across parents are restricted to the given parents, if not nil.
+func (s *Server) getFilteredPaginatedSortedRecords(ctx context.Context, parent string, parents []string, start *pagination.Token, pageSize int, filter *filter, sortOrder string, showDeleted bool) ([]*pb.Record, error) {
 	parent, result, err := result.ParseName(parent)
 	if err != nil {
 		return nil, err
@@ -211,18 +263,23 @@ func (s *Server) getFilteredPaginatedSortedRecords(ctx context.Context, parent,
 	for len(out) < pageSize {
 		batchSize := batcher.Next()
 		dbrecords := make([]*db.Record, 0, batchSize)
-		q := s.db.WithContext(ctx).Where("id > ?", start)
+		q := s.db.WithContext(ctx)
+		if showDeleted {
+			q = q.Unscoped()
+		}
 		// Specifying `-` allows users to read Records across Results.
 		// See https://google.aip.dev/159 for more details.
 		if parent != "-" {
 			q = q.Where("parent = ?", parent)
+		} else if parents != nil {
+			q = q.Where("parent IN ?", parents)
 		}
 		if result != "-" {
 			q = q.Where("result_name = ?", result)
 		}
-		if sortOrder != "" {
-			q = q.Order(sortOrder)
-		}
+		q = filter.apply(q)
+
+		q = pageQuery(q, sortOrder, start)
 		q = q.Limit(batchSize).Find(&dbrecords)
 		if err := errors.Wrap(q.Error); err != nil {
 			return nil, err
@@ -234,7 +291,7 @@ func (s *Server) getFilteredPaginatedSortedRecords(ctx context.Context, parent,
 			if err != nil {
 				return nil, err
 			}
-			ok, err := record.Match(api, prg)
+			ok, err := record.Match(api, filter.prg)
 			if err != nil {
 				return nil, err
 			}
@@ -255,7 +312,8 @@ func (s *Server) getFilteredPaginatedSortedRecords(ctx context.Context, parent,
 		}
 
 		// Set params for next batch.
-		start = dbrecords[len(dbrecords)-1].ID
+		last := dbrecords[len(dbrecords)-1]
+		start = pageCursor(sortOrder, last.ID, last.CreatedTime, last.UpdatedTime)
 		batcher.Update(len(dbrecords), batchSize)
 	}
 	return out, nil
@@ -282,41 +340,74 @@ func (s *Server) UpdateRecord(ctx context.Context, req *pb.UpdateRecordRequest)
 			return err
 		}
 
-		// If the user provided the Etag field, then make sure the value of this field matches what saved in the database.
-		// See https://google.aip.dev/154 for more information.
-		if req.GetEtag() != "" && req.GetEtag() != r.Etag {
-			return status.Error(codes.FailedPrecondition, "the etag mismatches")
+		out, err = s.updateRecord(tx, r, in, req.GetUpdateMask().GetPaths(), req.GetEtag())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.events.PublishRecord(pb.EventType_MODIFIED, out)
+	return out, nil
+}
+
+// updateRecord applies the fields of in selected by paths to the stored
+// Record r, or merges them all if no paths are given, and saves it. If etag is
+// set, it must match the etag of r.
+func (s *Server) updateRecord(tx *gorm.DB, r *db.Record, in *pb.Record, paths []string, etag string) (*pb.Record, error) {
+	// If the user provided the Etag field, then make sure the value of this field matches what saved in the database.
+	// See https://google.aip.dev/154 for more information.
+	if etag != "" && etag != r.Etag {
+		return nil, status.Error(codes.FailedPrecondition, "the etag mismatches")
+	}
+
+	// Keep the values being replaced as a revision of the record.
+	if s.config.RECORD_REVISIONS_ENABLE {
+		if err := errors.Wrap(tx.Create(record.NewRevision(r, uid())).Error); err != nil {
+			return nil, err
 		}
+	}
 
-		// Merge existing data with user request.
-		pb, err := record.ToAPI(r)
-		if err != nil {
-			return err
+	// Merge existing data with user request.
+	pb, err := record.ToAPI(r)
+	if err != nil {
+		return nil, err
+	}
+	// Without an update mask, the fields set in the request are merged
+	// into the existing Record.
+	// See https://google.aip.dev/134 for more information.
+	if len(paths) > 0 {
+		if err := protoutil.ApplyFieldMask(pb, in, paths, "name"); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask: %v", err)
 		}
-		// TODO: field mask support.
+	} else {
 		proto.Merge(pb, in)
+	}
 
-		updateTime := timestamppb.New(clock.Now())
-		pb.UpdatedTime = updateTime
-		pb.UpdateTime = updateTime
+	updateTime := timestamppb.New(clock.Now())
+	pb.UpdatedTime = updateTime
+	pb.UpdateTime = updateTime
 
-		// Convert back to storage and store.
-		s, err := record.ToStorage(r.Parent, r.ResultName, r.ResultID, r.Name, pb, s.config)
-		if err != nil {
-			return err
-		}
-		if err := record.UpdateEtag(s); err != nil {
-			return err
-		}
-		if err := errors.Wrap(tx.Save(s).Error); err != nil {
-			return err
-		}
+	// Convert back to storage and store.
+	store, err := record.ToStorage(r.Parent, r.ResultName, r.ResultID, r.Name, pb, s.config)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redactRecord(store); err != nil {
+		return nil, err
+	}
+	if err := record.UpdateEtag(store); err != nil {
+		return nil, err
+	}
+	if err := errors.Wrap(tx.Save(store).Error); err != nil {
+		return nil, err
+	}
 
-		pb.Etag = s.Etag
-		out = pb
-		return nil
-	})
-	return out, err
+	pb.Etag = store.Etag
+	// Return the data as stored, with its secrets redacted.
+	if pb.Data != nil && store.Type != v1alpha2.LogRecordType {
+		pb.Data.Value = store.Data
+	}
+	return pb, nil
 }
 
 // DeleteRecord deletes a given record.
@@ -337,7 +428,88 @@ func (s *Server) DeleteRecord(ctx context.Context, req *pb.DeleteRecordRequest)
 	if err != nil {
 		return &empty.Empty{}, err
 	}
-	return &empty.Empty{}, errors.Wrap(s.db.WithContext(ctx).Delete(&db.Record{}, r).Error)
+	if err := s.deleteRecord(ctx, r); err != nil {
+		return &empty.Empty{}, err
+	}
+	s.publishRecordDeleted(r)
+	return &empty.Empty{}, nil
+}
+
+// deleteRecord soft deletes the record, or deletes it along with its stored
+// log if soft deletion is disabled.
+func (s *Server) deleteRecord(ctx context.Context, r *db.Record) error {
+	if s.config.SOFT_DELETE_EXPIRY <= 0 {
+		_, err := s.deleteWithLogs(ctx, []*db.Record{r}, func(tx *gorm.DB) error {
+			return tx.Unscoped().Delete(&db.Record{}, r).Error
+		})
+		return errors.Wrap(err)
+	}
+	now := clock.Now()
+	if err := errors.Wrap(s.db.WithContext(ctx).Model(r).Update("delete_time", now).Error); err != nil {
+		return err
+	}
+	r.DeleteTime = gorm.DeletedAt{Time: now, Valid: true}
+	return nil
+}
+
+// UndeleteRecord restores a soft deleted Record. The Result of the Record must
+// not be deleted.
+func (s *Server) UndeleteRecord(ctx context.Context, req *pb.UndeleteRecordRequest) (*pb.Record, error) {
+	parent, resultName, name, err := record.ParseName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionUndelete); err != nil {
+		return nil, err
+	}
+
+	var (
+		r        *db.Record
+		restored bool
+	)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		r, err = getRecord(tx.Unscoped(), parent, resultName, name)
+		if err != nil {
+			return err
+		}
+		if !r.DeleteTime.Valid {
+			// Undeleting a Record that is not deleted returns it unchanged.
+			return nil
+		}
+		var live int64
+		if err := errors.Wrap(tx.Model(&db.Result{}).Where("parent = ? AND id = ?", r.Parent, r.ResultID).Count(&live).Error); err != nil {
+			return err
+		}
+		if live == 0 {
+			return status.Errorf(codes.FailedPrecondition, "result %s is deleted, undelete it first", result.FormatName(parent, resultName))
+		}
+		restored = true
+		return errors.Wrap(tx.Unscoped().Model(r).Update("delete_time", nil).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.DeleteTime = gorm.DeletedAt{}
+	out, err := record.ToAPI(r)
+	if err != nil {
+		return nil, err
+	}
+	if restored {
+		s.events.PublishRecord(pb.EventType_ADDED, out)
+	}
+	return out, nil
+}
+
+// publishRecordDeleted notifies watchers that the Record was deleted.
+func (s *Server) publishRecordDeleted(r *db.Record) {
+	out, err := record.ToAPI(r)
+	if err != nil {
+		s.logger.Errorf("failed to convert deleted record %s: %v", r.Name, err)
+		return
+	}
+	s.events.PublishRecord(pb.EventType_DELETED, out)
 }
 
 // recordCEL defines the CEL environment for querying Record data.
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/api/server/logger"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -38,6 +39,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -405,7 +407,7 @@ func TestListRecords(t *testing.T) {
 			},
 			want: &pb.ListRecordsResponse{
 				Records:       records[:1],
-				NextPageToken: pagetoken(t, records[1].GetId(), `data_type == "TaskRun"`),
+				NextPageToken: pagetoken(t, records[0].GetId(), `data_type == "TaskRun"`),
 			},
 		},
 		{
@@ -416,7 +418,7 @@ func TestListRecords(t *testing.T) {
 			},
 			want: &pb.ListRecordsResponse{
 				Records:       records[:1],
-				NextPageToken: pagetoken(t, records[1].GetId(), ""),
+				NextPageToken: pagetoken(t, records[0].GetId(), ""),
 			},
 		},
 		// Order By
@@ -593,7 +595,60 @@ func TestUpdateRecord(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update mask",
+			record: &pb.Record{
+				Name: record.FormatName(result.GetName(), "c"),
+				Data: &pb.Any{
+					Type:  "foo",
+					Value: jsonutil.AnyBytes(t, tr),
+				},
+			},
+			req: &pb.UpdateRecordRequest{
+				Record: &pb.Record{
+					Name: record.FormatName(result.GetName(), "c"),
+					Data: &pb.Any{
+						Type:  "bar",
+						Value: jsonutil.AnyBytes(t, &ppb.TaskRun{}),
+					},
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"data.type"}},
+			},
+			diff: &pb.Record{
+				Data: &pb.Any{
+					Type: "bar",
+				},
+			},
+		},
+		{
+			name: "update mask with immutable fields",
+			record: &pb.Record{
+				Name: record.FormatName(result.GetName(), "d"),
+			},
+			req: &pb.UpdateRecordRequest{
+				Record: &pb.Record{
+					Name: record.FormatName(result.GetName(), "d"),
+					Data: &pb.Any{
+						Value: jsonutil.AnyBytes(t, tr),
+					},
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "etag"}},
+			},
+		},
 		// Errors
+		{
+			name: "invalid update mask",
+			record: &pb.Record{
+				Name: record.FormatName(result.GetName(), "e"),
+			},
+			req: &pb.UpdateRecordRequest{
+				Record: &pb.Record{
+					Name: record.FormatName(result.GetName(), "e"),
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"foo"}},
+			},
+			status: codes.InvalidArgument,
+		},
 		{
 			name: "rename",
 			req: &pb.UpdateRecordRequest{
@@ -715,6 +770,86 @@ func TestDeleteRecord(t *testing.T) {
 	})
 }
 
+func TestUndeleteRecord(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, SOFT_DELETE_EXPIRY: time.Hour}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	result, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: result.GetName(),
+		Record: &pb.Record{
+			Name: recordutil.FormatName(result.GetName(), "baz"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord(): %v", err)
+	}
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: r.GetName()}); err != nil {
+		t.Fatalf("could not delete record: %v", err)
+	}
+
+	got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: r.GetName(), ShowDeleted: true})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	if got.GetDeleteTime() == nil {
+		t.Errorf("GetRecord: expected delete_time to be set, got %+v", got)
+	}
+
+	got, err = srv.UndeleteRecord(ctx, &pb.UndeleteRecordRequest{Name: r.GetName()})
+	if err != nil {
+		t.Fatalf("UndeleteRecord: %v", err)
+	}
+	if diff := cmp.Diff(r, got, protocmp.Transform()); diff != "" {
+		t.Errorf("UndeleteRecord (-want, +got): %s", diff)
+	}
+	// Undeleting a Record that is not deleted returns it.
+	got, err = srv.UndeleteRecord(ctx, &pb.UndeleteRecordRequest{Name: r.GetName()})
+	if err != nil {
+		t.Fatalf("UndeleteRecord: %v", err)
+	}
+	if diff := cmp.Diff(r, got, protocmp.Transform()); diff != "" {
+		t.Errorf("UndeleteRecord (-want, +got): %s", diff)
+	}
+
+	// Deleted Records are replaced when created again.
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: r.GetName()}); err != nil {
+		t.Fatalf("could not delete record: %v", err)
+	}
+	recreated, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: result.GetName(),
+		Record: &pb.Record{Name: r.GetName()},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord after delete: %v", err)
+	}
+	if recreated.GetId() == r.GetId() {
+		t.Errorf("CreateRecord after delete: expected a new ID, got %s", recreated.GetId())
+	}
+	if got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: r.GetName(), ShowDeleted: true}); err != nil || got.GetId() != recreated.GetId() {
+		t.Errorf("GetRecord: expected the created Record, got: (%+v, %v)", got, err)
+	}
+
+	// Records of a deleted Result cannot be undeleted on their own.
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: result.GetName()}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+	if _, err := srv.UndeleteRecord(ctx, &pb.UndeleteRecordRequest{Name: r.GetName()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FAILED_PRECONDITION, got: %v", err)
+	}
+}
+
 // TestListRecords_multiresult tests listing records across multiple parents.
 func TestListRecords_multiresult(t *testing.T) {
 	// Create a temporary database
@@ -757,10 +892,74 @@ func TestListRecords_multiresult(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ListRecords(): %v", err)
 	}
+	// Records are listed by ID, which are strings.
 	want := &pb.ListRecordsResponse{
 		Records: records[:4],
 	}
+	sort.Slice(want.Records, func(i, j int) bool {
+		return want.Records[i].GetId() < want.Records[j].GetId()
+	})
 	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
 		t.Error(diff)
 	}
 }
+
+func TestListRecords_FilterInMemory(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "memory",
+		Result: &pb.Result{Name: "memory/results/a"},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	for i, data := range []string{
+		`{"metadata": {"labels": {"app": "a"}}}`,
+		`{"metadata": {"labels": {"app": "b"}}}`,
+		`{"metadata": {}}`,
+	} {
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: result.GetName(),
+			Record: &pb.Record{
+				Name: recordutil.FormatName(result.GetName(), strconv.Itoa(i)),
+				Data: &pb.Any{Type: "test", Value: []byte(data)},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+	}
+
+	for _, filter := range []string{
+		`data.metadata.labels["app"] == "a"`,
+		`data.metadata.labels["app"] != "b"`,
+		`data.metadata.labels["app"] > "a"`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			// Comparing with a bool can't be translated to SQL, so the second
+			// filter is evaluated in memory.
+			var got [][]string
+			for _, f := range []string{filter, fmt.Sprintf("(%s) == true", filter)} {
+				resp, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{
+					Parent: result.GetName(),
+					Filter: f,
+				})
+				if err != nil {
+					t.Fatalf("ListRecords(%q): %v", f, err)
+				}
+				var names []string
+				for _, r := range resp.GetRecords() {
+					names = append(names, r.GetName())
+				}
+				got = append(got, names)
+			}
+			if diff := cmp.Diff(got[0], got[1]); diff != "" {
+				t.Errorf("-sql, +in memory: %s", diff)
+			}
+		})
+	}
+}
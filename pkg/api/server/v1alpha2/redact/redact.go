@@ -0,0 +1,274 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact redacts secrets from logs and Record data before they are
+// stored.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultReplacement replaces the redacted secrets.
+	DefaultReplacement = "[REDACTED]"
+
+	// ParamPattern is the name the redacted values of params are counted
+	// under.
+	ParamPattern = "param"
+	// PrivateKeyPattern is the name of the pattern of PEM private keys.
+	PrivateKeyPattern = "private-key"
+)
+
+// Builtin are the patterns of well-known secret formats, which are always
+// redacted.
+var Builtin = []*Pattern{
+	{Name: "aws-access-key-id", Regex: `\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`},
+	{Name: "aws-secret-access-key", Regex: `(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`},
+	{Name: "github-token", Regex: `\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`},
+	{Name: "jwt", Regex: `\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`},
+	{Name: PrivateKeyPattern, Regex: `-----BEGIN [A-Z0-9 ]*PRIVATE KEY[A-Z ]*-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY[A-Z ]*-----`},
+}
+
+var (
+	keyBegin = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY[A-Z ]*-----`)
+	keyEnd   = regexp.MustCompile(`-----END [A-Z0-9 ]*PRIVATE KEY[A-Z ]*-----`)
+)
+
+type Pattern struct {
+	// Name of the pattern, which redactions are counted under.
+	Name string `json:"name"`
+	// Regex matches the secrets. If it has capture groups, only the text
+	// matched by the first one is redacted, e.g. `password=(\S+)`.
+	Regex string `json:"regex"`
+}
+
+type Policy struct {
+	// Patterns are redacted along with the Builtin patterns.
+	Patterns []*Pattern `json:"patterns,omitempty"`
+	// Params are regular expressions matching the names of the params whose
+	// values are redacted. Names are matched in full, ignoring case.
+	Params []string `json:"params,omitempty"`
+	// Replacement replaces the redacted secrets. Defaults to
+	// DefaultReplacement.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// Counts are the numbers of secrets redacted by pattern name.
+type Counts map[string]int64
+
+// Add adds the counts of c to the counts.
+func (counts Counts) Add(c Counts) {
+	for name, n := range c {
+		counts[name] += n
+	}
+}
+
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Redactor redacts the secrets matched by the patterns of a Policy.
+type Redactor struct {
+	patterns    []*pattern
+	params      []*regexp.Regexp
+	replacement []byte
+}
+
+// New returns a Redactor of the Builtin patterns and the patterns of the
+// policy, which may be nil.
+func New(p *Policy) (*Redactor, error) {
+	if p == nil {
+		p = &Policy{}
+	}
+	r := &Redactor{replacement: []byte(p.Replacement)}
+	if p.Replacement == "" {
+		r.replacement = []byte(DefaultReplacement)
+	}
+	seen := make(map[string]bool)
+	for _, pt := range append(append([]*Pattern{}, Builtin...), p.Patterns...) {
+		if pt.Name == "" || pt.Name == ParamPattern || seen[pt.Name] {
+			return nil, fmt.Errorf("pattern %q: name must be set and unique", pt.Name)
+		}
+		seen[pt.Name] = true
+		re, err := regexp.Compile(pt.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", pt.Name, err)
+		}
+		r.patterns = append(r.patterns, &pattern{name: pt.Name, re: re})
+	}
+	for _, param := range p.Params {
+		re, err := regexp.Compile("(?i)^(?:" + param + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("param %s: %w", param, err)
+		}
+		r.params = append(r.params, re)
+	}
+	return r, nil
+}
+
+func Load(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("error parsing redaction policy %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// FromConfig returns the Redactor of the REDACTION_* variables, or nil if
+// redaction is disabled.
+func FromConfig(cfg *config.Config) (*Redactor, error) {
+	if !cfg.REDACTION_ENABLE {
+		return nil, nil
+	}
+	p := &Policy{}
+	if cfg.REDACTION_POLICY_PATH != "" {
+		var err error
+		if p, err = Load(cfg.REDACTION_POLICY_PATH); err != nil {
+			return nil, err
+		}
+	}
+	for _, param := range strings.Split(cfg.REDACTION_PARAMS, ",") {
+		if param = strings.TrimSpace(param); param != "" {
+			p.Params = append(p.Params, param)
+		}
+	}
+	r, err := New(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction policy: %w", err)
+	}
+	return r, nil
+}
+
+// Redact returns the data with the secrets replaced, and adds the number of
+// secrets redacted to counts.
+func (r *Redactor) Redact(data []byte, counts Counts) []byte {
+	for _, p := range r.patterns {
+		matches := p.re.FindAllSubmatchIndex(data, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		var (
+			out  []byte
+			last int
+		)
+		for _, m := range matches {
+			start, end := m[0], m[1]
+			if len(m) > 2 {
+				start, end = m[2], m[3]
+			}
+			if start >= end {
+				continue
+			}
+			out = append(out, data[last:start]...)
+			out = append(out, r.replacement...)
+			last = end
+			counts[p.name]++
+		}
+		data = append(out, data[last:]...)
+	}
+	return data
+}
+
+// RedactJSON redacts the secrets in the strings of a JSON document, and the
+// values of the params matched by the policy: the "value" and "default" of
+// the objects with a matching "name", like the params and environment
+// variables of Tekton resources. The document is returned as is if nothing is
+// redacted.
+func (r *Redactor) RedactJSON(data []byte, counts Counts) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	redacted := Counts{}
+	v = r.redactValue(v, redacted)
+	if len(redacted) == 0 {
+		return data, nil
+	}
+	counts.Add(redacted)
+
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+func (r *Redactor) redactValue(v interface{}, counts Counts) interface{} {
+	switch v := v.(type) {
+	case string:
+		return string(r.Redact([]byte(v), counts))
+	case []interface{}:
+		for i := range v {
+			v[i] = r.redactValue(v[i], counts)
+		}
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok && r.isSecretParam(name) {
+			for _, key := range []string{"value", "default"} {
+				if value, ok := v[key]; ok {
+					v[key] = r.replace(value)
+					counts[ParamPattern]++
+				}
+			}
+		}
+		for key, value := range v {
+			v[key] = r.redactValue(value, counts)
+		}
+	}
+	return v
+}
+
+func (r *Redactor) isSecretParam(name string) bool {
+	for _, re := range r.params {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// replace replaces the strings of a param value, which may be a string, an
+// array or an object.
+func (r *Redactor) replace(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return string(r.replacement)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.replace(v[i])
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = r.replace(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"bytes"
+	"io"
+)
+
+const (
+	// maxPending is the size of the data kept until a line, or a private
+	// key, ends. Longer lines are redacted in parts.
+	maxPending = 1024 * 1024
+	// maxMarker is longer than the markers that end private keys.
+	maxMarker = 64
+)
+
+// Writer redacts the secrets of a log line by line, and writes it to the
+// underlying writer. Patterns are matched within lines, except for private
+// keys, which are redacted whole. Flush writes the last line.
+type Writer struct {
+	r       *Redactor
+	w       io.Writer
+	counts  Counts
+	pending []byte
+	// inKey is set while the rest of a private key is discarded, once its
+	// start is redacted.
+	inKey bool
+}
+
+// NewWriter returns a Writer to w, which adds the number of secrets redacted
+// to counts.
+func (r *Redactor) NewWriter(w io.Writer, counts Counts) *Writer {
+	return &Writer{r: r, w: w, counts: counts}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	if err := w.process(false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush redacts and writes the data of the log that does not end a line.
+func (w *Writer) Flush() error {
+	return w.process(true)
+}
+
+// process writes the complete lines of the pending data, or all of it if
+// flush is set or it is longer than maxPending.
+func (w *Writer) process(flush bool) error {
+	for len(w.pending) > 0 {
+		if w.inKey {
+			loc := keyEnd.FindIndex(w.pending)
+			if loc == nil {
+				// Discard the key, but keep the data that may start the
+				// end marker.
+				keep := 0
+				if !flush {
+					keep = maxMarker
+					if len(w.pending) < keep {
+						keep = len(w.pending)
+					}
+				}
+				w.pending = append(w.pending[:0], w.pending[len(w.pending)-keep:]...)
+				return nil
+			}
+			w.pending = w.pending[loc[1]:]
+			w.inKey = false
+			continue
+		}
+
+		forced := flush || len(w.pending) >= maxPending
+		n := bytes.LastIndexByte(w.pending, '\n') + 1
+		if forced {
+			n = len(w.pending)
+		}
+		if n == 0 {
+			return nil
+		}
+		data := w.pending[:n]
+		if begin := openKey(data); begin >= 0 {
+			if !forced {
+				// Keep the lines of the private key until it ends.
+				n = bytes.LastIndexByte(data[:begin], '\n') + 1
+				if n == 0 {
+					return nil
+				}
+				data = data[:n]
+			} else {
+				// The private key is too long, or the log ends: redact
+				// the rest of it.
+				w.inKey = true
+				out := append(w.r.Redact(data[:begin], w.counts), w.r.replacement...)
+				w.counts[PrivateKeyPattern]++
+				if _, err := w.w.Write(out); err != nil {
+					return err
+				}
+				w.pending = w.pending[n:]
+				continue
+			}
+		}
+		if _, err := w.w.Write(w.r.Redact(data, w.counts)); err != nil {
+			return err
+		}
+		w.pending = w.pending[n:]
+	}
+	return nil
+}
+
+// openKey returns the offset of the last private key of the data that does
+// not end, or -1.
+func openKey(data []byte) int {
+	locs := keyBegin.FindAllIndex(data, -1)
+	if len(locs) == 0 {
+		return -1
+	}
+	last := locs[len(locs)-1]
+	if keyEnd.Match(data[last[1]:]) {
+		return -1
+	}
+	return last[0]
+}
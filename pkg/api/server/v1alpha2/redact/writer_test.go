@@ -0,0 +1,115 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriter(t *testing.T) {
+	r, err := New(nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		log    string
+		want   string
+		counts Counts
+	}{
+		{
+			name:   "lines",
+			log:    "token " + githubToken + "\nkey " + awsKeyID + "\nlast " + jwt,
+			want:   "token [REDACTED]\nkey [REDACTED]\nlast [REDACTED]",
+			counts: Counts{"github-token": 1, "aws-access-key-id": 1, "jwt": 1},
+		},
+		{
+			name:   "private key",
+			log:    "before\nkey: " + privateKey + " after\nend\n",
+			want:   "before\nkey: [REDACTED] after\nend\n",
+			counts: Counts{PrivateKeyPattern: 1},
+		},
+		{
+			name:   "private key without end",
+			log:    "before\n" + privateKey[:100],
+			want:   "before\n[REDACTED]",
+			counts: Counts{PrivateKeyPattern: 1},
+		},
+		{
+			name:   "no secrets",
+			log:    "one\ntwo\n",
+			want:   "one\ntwo\n",
+			counts: Counts{},
+		},
+	} {
+		// The log is written in chunks of all sizes, which split the
+		// secrets.
+		for size := 1; size <= len(tc.log); size++ {
+			out := &bytes.Buffer{}
+			counts := Counts{}
+			w := r.NewWriter(out, counts)
+			for i := 0; i < len(tc.log); i += size {
+				end := i + size
+				if end > len(tc.log) {
+					end = len(tc.log)
+				}
+				if _, err := w.Write([]byte(tc.log[i:end])); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+			if got := out.String(); got != tc.want {
+				t.Fatalf("%s, chunks of %d bytes: want %q, got %q", tc.name, size, tc.want, got)
+			}
+			if diff := cmp.Diff(tc.counts, counts); diff != "" {
+				t.Fatalf("%s, chunks of %d bytes: counts (-want, +got): %s", tc.name, size, diff)
+			}
+		}
+	}
+}
+
+func TestWriter_LongPrivateKey(t *testing.T) {
+	r, err := New(nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	out := &bytes.Buffer{}
+	counts := Counts{}
+	w := r.NewWriter(out, counts)
+	begin, end, _ := strings.Cut(privateKey, "\n")
+	// The key is longer than the data kept until it ends: its start is
+	// redacted, and the rest of it is discarded.
+	body := strings.Repeat(strings.Repeat("A", 63)+"\n", 2*maxPending/64)
+	for _, s := range []string{"line\n" + begin + "\n", body, end + "\nafter\n"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if want := "line\n[REDACTED]\nafter\n"; out.String() != want {
+		t.Errorf("want %q, got %q", want, out.String())
+	}
+	if diff := cmp.Diff(Counts{PrivateKeyPattern: 1}, counts); diff != "" {
+		t.Errorf("counts (-want, +got): %s", diff)
+	}
+}
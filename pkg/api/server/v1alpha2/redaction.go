@@ -0,0 +1,83 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/redact"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var redactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "results_redactions_total",
+	Help: "Number of secrets redacted from logs and Record data, by pattern.",
+}, []string{"source", "pattern"})
+
+func init() {
+	prometheus.MustRegister(redactions)
+}
+
+// countRedactions adds the redactions of logs or Records to the metrics.
+func countRedactions(source string, counts redact.Counts) {
+	for pattern, n := range counts {
+		redactions.WithLabelValues(source, pattern).Add(float64(n))
+	}
+}
+
+// redactRecord redacts the secrets in the data of the Record to store. The
+// data of Log Records, which is written by the server, is kept as is.
+func (s *Server) redactRecord(store *db.Record) error {
+	counts := redact.Counts{}
+	data, err := s.redactData(store.Type, store.Data, counts)
+	if err != nil {
+		return err
+	}
+	store.Data = data
+	countRedactions("record", counts)
+	return nil
+}
+
+// redactData returns the Record data of the given type with its secrets
+// redacted, as it is stored, and adds the redactions to counts.
+func (s *Server) redactData(typ string, data []byte, counts redact.Counts) ([]byte, error) {
+	if s.redactor == nil || typ == v1alpha2.LogRecordType || len(data) == 0 {
+		return data, nil
+	}
+	data, err := s.redactor.RedactJSON(data, counts)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid record data: %v", err)
+	}
+	return data, nil
+}
+
+// sameData returns whether the stored Record r already has the data, once its
+// secrets are redacted, so that writing the data would not change r.
+func (s *Server) sameData(r *db.Record, data *pb.Any) (bool, error) {
+	if r.Type != data.GetType() {
+		return false, nil
+	}
+	// The redactions are only counted once the data is stored.
+	value, err := s.redactData(data.GetType(), data.GetValue(), redact.Counts{})
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(r.Data, value), nil
+}
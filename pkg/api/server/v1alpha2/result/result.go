@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 	"knative.dev/pkg/ptr"
 )
 
@@ -140,9 +141,17 @@ func ToAPI(r *db.Result) *pb.Result {
 		Annotations: r.Annotations,
 		Etag:        r.Etag,
 		Summary:     summary,
+		DeleteTime:  deleteTS(r.DeleteTime),
 	}
 }
 
+func deleteTS(t gorm.DeletedAt) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
 func newTS(t *time.Time) *timestamppb.Timestamp {
 	if t == nil {
 		return nil
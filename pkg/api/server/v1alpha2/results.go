@@ -17,17 +17,19 @@ package server
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"gorm.io/gorm"
 
-	"github.com/google/cel-go/cel"
-	celenv "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
 	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	"github.com/tektoncd/results/pkg/internal/protoutil"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
@@ -40,7 +42,7 @@ func (s *Server) CreateResult(ctx context.Context, req *pb.CreateResultRequest)
 	r := req.GetResult()
 
 	// Validate the incoming request
-	parent, _, err := result.ParseName(r.GetName())
+	parent, name, err := result.ParseName(r.GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -71,10 +73,43 @@ func (s *Server) CreateResult(ctx context.Context, req *pb.CreateResultRequest)
 		return nil, err
 	}
 
-	if err := errors.Wrap(s.db.WithContext(ctx).Create(store).Error); err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := errors.Wrap(purgeDeletedResult(tx, parent, name)); err != nil {
+			return err
+		}
+		return errors.Wrap(tx.Create(store).Error)
+	})
+	if err != nil {
 		return nil, err
 	}
-	return result.ToAPI(store), nil
+	out := result.ToAPI(store)
+	s.events.PublishResult(pb.EventType_ADDED, out)
+	return out, nil
+}
+
+// purgeDeletedResult permanently deletes the soft deleted Result of the given
+// name, if any, along with its Records, which would otherwise keep the name
+// from being used again. The deletion of their stored logs is queued, and
+// done by RunLogDeletions.
+func purgeDeletedResult(tx *gorm.DB, parent, name string) error {
+	var deleted []*db.Result
+	if err := tx.Unscoped().Where("parent = ? AND name = ? AND delete_time IS NOT NULL", parent, name).Find(&deleted).Error; err != nil {
+		return err
+	}
+	for _, r := range deleted {
+		var logs []*db.Record
+		if err := tx.Unscoped().Where("parent = ? AND result_id = ? AND type = ?", r.Parent, r.ID, v1alpha2.LogRecordType).Find(&logs).Error; err != nil {
+			return err
+		}
+		if _, err := queueLogDeletions(tx, logs); err != nil {
+			return err
+		}
+		// Records are deleted by cascade.
+		if err := tx.Unscoped().Delete(&db.Result{}, r).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetResult returns a single Result.
@@ -86,7 +121,11 @@ func (s *Server) GetResult(ctx context.Context, req *pb.GetResultRequest) (*pb.R
 	if err := s.auth.Check(ctx, parent, auth.ResourceResults, auth.PermissionGet); err != nil {
 		return nil, err
 	}
-	store, err := getResultByParentName(s.db, parent, name)
+	q := s.db.WithContext(ctx)
+	if req.GetShowDeleted() {
+		q = q.Unscoped()
+	}
+	store, err := getResultByParentName(q, parent, name)
 	if err != nil {
 		return nil, err
 	}
@@ -124,9 +163,15 @@ func (s *Server) UpdateResult(ctx context.Context, req *pb.UpdateResultRequest)
 		// making sure to filter out any OUTPUT_ONLY fields, and only
 		// updatable fields.
 		// We can't use proto.Merge, since empty fields in the req should take
-		// precedence, so set each updatable field here.
-		newpb.Annotations = reqpb.GetAnnotations()
-		newpb.Summary = reqpb.GetSummary()
+		// precedence. Without an update mask, all updatable fields are
+		// replaced.
+		paths := req.GetUpdateMask().GetPaths()
+		if len(paths) == 0 {
+			paths = []string{"*"}
+		}
+		if err := protoutil.ApplyFieldMask(newpb, reqpb, paths, "name"); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid update_mask: %v", err)
+		}
 		toDB, err := result.ToStorage(newpb)
 		if err != nil {
 			return err
@@ -147,7 +192,11 @@ func (s *Server) UpdateResult(ctx context.Context, req *pb.UpdateResultRequest)
 
 		return nil
 	})
-	return out, err
+	if err != nil {
+		return nil, err
+	}
+	s.events.PublishResult(pb.EventType_MODIFIED, out)
+	return out, nil
 }
 
 // DeleteResult deletes a given result.
@@ -172,81 +221,202 @@ func (s *Server) DeleteResult(ctx context.Context, req *pb.DeleteResultRequest)
 		return &empty.Empty{}, err
 	}
 
-	// Delete the result.
-	delete := s.db.WithContext(ctx).Delete(&db.Result{}, r)
-	return &empty.Empty{}, errors.Wrap(delete.Error)
+	// Fetch the Records deleted along with the Result, to notify watchers.
+	var records []*db.Record
+	if err := errors.Wrap(s.db.WithContext(ctx).Where(&db.Record{Parent: r.Parent, ResultID: r.ID}).Find(&records).Error); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	if s.config.SOFT_DELETE_EXPIRY <= 0 {
+		// Soft deletion is disabled, delete the result. Records are deleted
+		// by cascade, and the deletion of their stored logs is queued.
+		var logs []*db.Record
+		if err := errors.Wrap(s.db.WithContext(ctx).Unscoped().Where("parent = ? AND result_id = ? AND type = ?", r.Parent, r.ID, v1alpha2.LogRecordType).Find(&logs).Error); err != nil {
+			return &empty.Empty{}, err
+		}
+		if _, err := s.deleteWithLogs(ctx, logs, func(tx *gorm.DB) error {
+			return tx.Unscoped().Delete(&db.Result{}, r).Error
+		}); err != nil {
+			return &empty.Empty{}, errors.Wrap(err)
+		}
+	} else {
+		// Mark the result and its records as deleted at the same time, so
+		// that undeleting the result only restores the records deleted along
+		// with it.
+		now := clock.Now()
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&db.Record{}).Where("parent = ? AND result_id = ?", r.Parent, r.ID).Update("delete_time", now).Error; err != nil {
+				return err
+			}
+			return tx.Model(r).Update("delete_time", now).Error
+		})
+		if err := errors.Wrap(err); err != nil {
+			return &empty.Empty{}, err
+		}
+		r.DeleteTime = gorm.DeletedAt{Time: now, Valid: true}
+		for _, rec := range records {
+			rec.DeleteTime = r.DeleteTime
+		}
+	}
+
+	for _, rec := range records {
+		s.publishRecordDeleted(rec)
+	}
+	s.events.PublishResult(pb.EventType_DELETED, result.ToAPI(r))
+	return &empty.Empty{}, nil
+}
+
+// UndeleteResult restores a soft deleted Result, along with the Records that
+// were deleted with it.
+func (s *Server) UndeleteResult(ctx context.Context, req *pb.UndeleteResultRequest) (*pb.Result, error) {
+	parent, name, err := result.ParseName(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceResults, auth.PermissionUndelete); err != nil {
+		return nil, err
+	}
+
+	var (
+		r        *db.Result
+		records  []*db.Record
+		restored bool
+	)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		r, err = getResultByParentName(tx.Unscoped(), parent, name)
+		if err != nil {
+			return err
+		}
+		if !r.DeleteTime.Valid {
+			// Undeleting a Result that is not deleted returns it unchanged.
+			return nil
+		}
+		restored = true
+
+		// Records deleted before the result keep an earlier delete time, and
+		// stay deleted.
+		since, err := cel2sql.TimeRange(s.db.Dialector.Name(), "delete_time", r.DeleteTime.Time, time.Time{})
+		if err != nil {
+			return err
+		}
+		deleted := func() *gorm.DB {
+			return tx.Unscoped().Model(&db.Record{}).
+				Where("parent = ? AND result_id = ?", r.Parent, r.ID).
+				Where(since.SQL, since.Args...)
+		}
+		if err := errors.Wrap(deleted().Find(&records).Error); err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			if err := errors.Wrap(deleted().Update("delete_time", nil).Error); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(tx.Unscoped().Model(r).Update("delete_time", nil).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.DeleteTime = gorm.DeletedAt{}
+	out := result.ToAPI(r)
+	if !restored {
+		return out, nil
+	}
+	s.events.PublishResult(pb.EventType_ADDED, out)
+	for _, rec := range records {
+		rec.DeleteTime = gorm.DeletedAt{}
+		api, err := record.ToAPI(rec)
+		if err != nil {
+			s.logger.Errorf("failed to convert undeleted record %s: %v", rec.Name, err)
+			continue
+		}
+		s.events.PublishRecord(pb.EventType_ADDED, api)
+	}
+	return out, nil
 }
 
 func (s *Server) ListResults(ctx context.Context, req *pb.ListResultsRequest) (*pb.ListResultsResponse, error) {
 	if req.GetParent() == "" {
 		return nil, status.Error(codes.InvalidArgument, "parent missing")
 	}
-	if err := s.auth.Check(ctx, req.GetParent(), auth.ResourceResults, auth.PermissionList); err != nil {
+	parents, filtered, err := s.checkList(ctx, req.GetParent(), auth.ResourceResults)
+	if err != nil {
 		return nil, err
 	}
+	if filtered && len(parents) == 0 {
+		return &pb.ListResultsResponse{ParentsFiltered: true}, nil
+	}
 
 	userPageSize, err := pageSize(int(req.GetPageSize()))
 	if err != nil {
 		return nil, err
 	}
 
-	start, err := pageStart(req.GetPageToken(), req.GetFilter())
+	sortOrder, err := orderBy(req.GetOrderBy())
 	if err != nil {
 		return nil, err
 	}
 
-	sortOrder, err := orderBy(req.GetOrderBy())
+	start, err := pageStart(req.GetPageToken(), req.GetFilter(), sortOrder)
 	if err != nil {
 		return nil, err
 	}
 
-	prg, err := celenv.ParseFilter(s.env, req.GetFilter())
+	filter, err := s.parseFilter(s.env, req.GetFilter(), cel2sql.ResultsView)
 	if err != nil {
 		return nil, err
 	}
 	// Fetch n+1 items to get the next token.
-	out, err := s.getFilteredPaginatedSortedResults(ctx, req.GetParent(), start, userPageSize+1, prg, sortOrder)
+	out, err := s.getFilteredPaginatedSortedResults(ctx, req.GetParent(), parents, start, userPageSize+1, filter, sortOrder, req.GetShowDeleted())
 	if err != nil {
 		return nil, err
 	}
 
-	// If we returned the full n+1 items, use the last element as the next page
-	// token.
+	// If we returned the full n+1 items, there is a next page starting after
+	// the last item of this one.
 	var nextToken string
 	if len(out) > userPageSize {
-		next := out[len(out)-1]
+		out = out[:userPageSize]
 		var err error
-		nextToken, err = pagination.EncodeToken(next.GetId(), req.GetFilter())
+		nextToken, err = nextPageToken(out[len(out)-1], req.GetFilter(), sortOrder)
 		if err != nil {
 			return nil, err
 		}
-		out = out[:len(out)-1]
 	}
 
 	return &pb.ListResultsResponse{
-		Results:       out,
-		NextPageToken: nextToken,
+		Results:         out,
+		NextPageToken:   nextToken,
+		ParentsFiltered: filtered,
 	}, nil
 }
 
 // getFilteredPaginatedSortedResults returns the specified number of results that
-// match the given CEL program.
-func (s *Server) getFilteredPaginatedSortedResults(ctx context.Context, parent string, start string, pageSize int, prg cel.Program, sortOrder string) ([]*pb.Result, error) {
+// match the given filter, including the deleted ones if showDeleted is set.
+// Lists across parents are restricted to the given parents, if not nil.
+func (s *Server) getFilteredPaginatedSortedResults(ctx context.Context, parent string, parents []string, start *pagination.Token, pageSize int, filter *filter, sortOrder string, showDeleted bool) ([]*pb.Result, error) {
 	out := make([]*pb.Result, 0, pageSize)
 	batcher := pagination.NewBatcher(pageSize, minPageSize, maxPageSize)
 	for len(out) < pageSize {
 		batchSize := batcher.Next()
 		dbresults := make([]*db.Result, 0, batchSize)
-		q := s.db.WithContext(ctx).Where("id > ?", start)
+		q := s.db.WithContext(ctx)
+		if showDeleted {
+			q = q.Unscoped()
+		}
 		// Specifying `-` allows users to read Results from any parent.
 		// See https://google.aip.dev/159 for more details.
 		if parent != "-" {
 			q = q.Where("parent = ?", parent)
+		} else if parents != nil {
+			q = q.Where("parent IN ?", parents)
 		}
 
-		if sortOrder != "" {
-			q.Order(sortOrder)
-		}
+		q = filter.apply(q)
+
+		q = pageQuery(q, sortOrder, start)
 		q.Limit(batchSize).Find(&dbresults)
 		if err := errors.Wrap(q.Error); err != nil {
 			return nil, err
@@ -255,7 +425,7 @@ func (s *Server) getFilteredPaginatedSortedResults(ctx context.Context, parent s
 		// Only return results that match the filter.
 		for _, r := range dbresults {
 			api := result.ToAPI(r)
-			ok, err := result.Match(api, prg)
+			ok, err := result.Match(api, filter.prg)
 			if err != nil {
 				return nil, err
 			}
@@ -276,7 +446,8 @@ func (s *Server) getFilteredPaginatedSortedResults(ctx context.Context, parent s
 		}
 
 		// Set params for next batch.
-		start = dbresults[len(dbresults)-1].ID
+		last := dbresults[len(dbresults)-1]
+		start = pageCursor(sortOrder, last.ID, last.CreatedTime, last.UpdatedTime)
 		batcher.Update(len(dbresults), batchSize)
 
 	}
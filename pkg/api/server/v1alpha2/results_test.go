@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/api/server/logger"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -27,11 +28,13 @@ import (
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/test"
 	recordutil "github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/internal/protoutil"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -238,6 +241,142 @@ func TestUpdateResult(t *testing.T) {
 	}
 }
 
+func TestUpdateResult_UpdateMask(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	initial := &pb.Result{
+		Annotations: map[string]string{"a": "1", "b": "2"},
+		Summary: &pb.RecordSummary{
+			Record:      "foo/results/bar/records/baz",
+			Type:        "TaskRun",
+			Status:      pb.RecordSummary_FAILURE,
+			Annotations: map[string]string{"x": "1"},
+		},
+	}
+
+	for idx, tc := range []struct {
+		name    string
+		mask    []string
+		update  *pb.Result
+		expect  *pb.Result
+		errcode codes.Code
+	}{
+		{
+			name:   "single annotation",
+			mask:   []string{"annotations.a"},
+			update: &pb.Result{Annotations: map[string]string{"a": "3", "b": "ignored"}},
+			expect: &pb.Result{
+				Annotations: map[string]string{"a": "3", "b": "2"},
+				Summary:     initial.Summary,
+			},
+		},
+		{
+			name:   "remove annotation",
+			mask:   []string{"annotations.b"},
+			update: &pb.Result{},
+			expect: &pb.Result{
+				Annotations: map[string]string{"a": "1"},
+				Summary:     initial.Summary,
+			},
+		},
+		{
+			name:   "summary status",
+			mask:   []string{"summary.status"},
+			update: &pb.Result{Summary: &pb.RecordSummary{Status: pb.RecordSummary_SUCCESS}},
+			expect: &pb.Result{
+				Annotations: initial.Annotations,
+				Summary: &pb.RecordSummary{
+					Record:      "foo/results/bar/records/baz",
+					Type:        "TaskRun",
+					Status:      pb.RecordSummary_SUCCESS,
+					Annotations: map[string]string{"x": "1"},
+				},
+			},
+		},
+		{
+			name:   "summary annotation",
+			mask:   []string{"summary.annotations.y"},
+			update: &pb.Result{Summary: &pb.RecordSummary{Annotations: map[string]string{"y": "2"}}},
+			expect: &pb.Result{
+				Annotations: initial.Annotations,
+				Summary: &pb.RecordSummary{
+					Record:      "foo/results/bar/records/baz",
+					Type:        "TaskRun",
+					Status:      pb.RecordSummary_FAILURE,
+					Annotations: map[string]string{"x": "1", "y": "2"},
+				},
+			},
+		},
+		{
+			name:   "clear summary",
+			mask:   []string{"summary"},
+			update: &pb.Result{Annotations: map[string]string{"ignored": "true"}},
+			expect: &pb.Result{
+				Annotations: initial.Annotations,
+			},
+		},
+		{
+			name: "full replacement",
+			mask: []string{"*"},
+			update: &pb.Result{
+				Annotations: map[string]string{"c": "3"},
+				Summary: &pb.RecordSummary{
+					Record: "foo/results/bar/records/qux",
+					Type:   "PipelineRun",
+				},
+			},
+			expect: &pb.Result{
+				Annotations: map[string]string{"c": "3"},
+				Summary: &pb.RecordSummary{
+					Record: "foo/results/bar/records/qux",
+					Type:   "PipelineRun",
+				},
+			},
+		},
+		{
+			name:    "unknown field",
+			mask:    []string{"summary.foo"},
+			update:  &pb.Result{},
+			errcode: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := proto.Clone(initial).(*pb.Result)
+			r.Name = fmt.Sprintf("foo/results/mask-%d", idx)
+			created, err := srv.CreateResult(ctx, &pb.CreateResultRequest{Parent: "foo", Result: r})
+			if err != nil {
+				t.Fatalf("could not create result: %v", err)
+			}
+
+			updated, err := srv.UpdateResult(ctx, &pb.UpdateResultRequest{
+				Name:       created.GetName(),
+				Result:     tc.update,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tc.mask},
+			})
+			if status.Code(err) != tc.errcode {
+				t.Fatalf("UpdateResult()=(%v, %v); want %v", updated, err, tc.errcode)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: created.GetName()})
+			if err != nil {
+				t.Fatalf("failed to get result from server: %v", err)
+			}
+			for _, r := range []*pb.Result{updated, got} {
+				if diff := cmp.Diff(tc.expect, r, protocmp.Transform(), protoutil.IgnoreResultOutputOnly(), protocmp.IgnoreFields(&pb.Result{}, "name")); diff != "" {
+					t.Errorf("-want, +got: %s", diff)
+				}
+			}
+		})
+	}
+}
+
 func TestGetResult(t *testing.T) {
 	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
 	if err != nil {
@@ -357,6 +496,158 @@ func TestCascadeDelete(t *testing.T) {
 	}
 }
 
+func TestSoftDeleteResult(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, SOFT_DELETE_EXPIRY: time.Hour}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	var records []*pb.Record
+	for _, name := range []string{"a", "b"} {
+		r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: recordutil.FormatName(res.GetName(), name),
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord(): %v", err)
+		}
+		records = append(records, r)
+	}
+
+	// Record a is deleted before the Result, and stays deleted when the
+	// Result is undeleted.
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: records[0].GetName()}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	fakeClock.Advance(time.Minute)
+	deleteTime := timestamppb.New(fakeClock.Now())
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: res.GetName()}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+
+	t.Run("hidden", func(t *testing.T) {
+		if got, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: res.GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetResult: expected NOT_FOUND, got: (%+v, %v)", got, err)
+		}
+		if got, err := srv.ListResults(ctx, &pb.ListResultsRequest{Parent: "foo"}); err != nil || len(got.GetResults()) != 0 {
+			t.Errorf("ListResults: expected no Results, got: (%+v, %v)", got, err)
+		}
+		if got, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: res.GetName()}); err != nil || len(got.GetRecords()) != 0 {
+			t.Errorf("ListRecords: expected no Records, got: (%+v, %v)", got, err)
+		}
+		if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: res.GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("DeleteResult: expected NOT_FOUND, got: %v", err)
+		}
+	})
+
+	t.Run("show deleted", func(t *testing.T) {
+		got, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: res.GetName(), ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("GetResult: %v", err)
+		}
+		want := proto.Clone(res).(*pb.Result)
+		want.DeleteTime = deleteTime
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("GetResult (-want, +got): %s", diff)
+		}
+		list, err := srv.ListResults(ctx, &pb.ListResultsRequest{Parent: "foo", ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("ListResults: %v", err)
+		}
+		if diff := cmp.Diff([]*pb.Result{want}, list.GetResults(), protocmp.Transform()); diff != "" {
+			t.Errorf("ListResults (-want, +got): %s", diff)
+		}
+		recs, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: res.GetName(), ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if len(recs.GetRecords()) != 2 {
+			t.Errorf("ListRecords: expected 2 Records, got %+v", recs.GetRecords())
+		}
+	})
+
+	t.Run("undelete", func(t *testing.T) {
+		got, err := srv.UndeleteResult(ctx, &pb.UndeleteResultRequest{Name: res.GetName()})
+		if err != nil {
+			t.Fatalf("UndeleteResult: %v", err)
+		}
+		if diff := cmp.Diff(res, got, protocmp.Transform()); diff != "" {
+			t.Errorf("UndeleteResult (-want, +got): %s", diff)
+		}
+		if _, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: res.GetName()}); err != nil {
+			t.Errorf("GetResult: %v", err)
+		}
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: records[1].GetName()}); err != nil {
+			t.Errorf("GetRecord(%s): %v", records[1].GetName(), err)
+		}
+		if got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: records[0].GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetRecord(%s): expected NOT_FOUND, got: (%+v, %v)", records[0].GetName(), got, err)
+		}
+	})
+
+	t.Run("undelete live", func(t *testing.T) {
+		got, err := srv.UndeleteResult(ctx, &pb.UndeleteResultRequest{Name: res.GetName()})
+		if err != nil {
+			t.Fatalf("UndeleteResult: %v", err)
+		}
+		if diff := cmp.Diff(res, got, protocmp.Transform()); diff != "" {
+			t.Errorf("UndeleteResult (-want, +got): %s", diff)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			want codes.Code
+		}{
+			{name: "foo/results/missing", want: codes.NotFound},
+			{name: "foo", want: codes.InvalidArgument},
+		} {
+			if got, err := srv.UndeleteResult(ctx, &pb.UndeleteResultRequest{Name: tc.name}); status.Code(err) != tc.want {
+				t.Errorf("UndeleteResult(%s): expected %v, got: (%+v, %v)", tc.name, tc.want, got, err)
+			}
+		}
+	})
+
+	t.Run("create after delete", func(t *testing.T) {
+		if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: res.GetName()}); err != nil {
+			t.Fatalf("DeleteResult: %v", err)
+		}
+		// The deleted Result and its Records are replaced.
+		got, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: "foo",
+			Result: &pb.Result{Name: res.GetName()},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		if got.GetId() == res.GetId() {
+			t.Errorf("CreateResult: expected a new ID, got %s", got.GetId())
+		}
+		if r, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: res.GetName(), ShowDeleted: true}); err != nil || r.GetId() != got.GetId() {
+			t.Errorf("GetResult: expected the created Result, got: (%+v, %v)", r, err)
+		}
+		recs, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: res.GetName(), ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if len(recs.GetRecords()) != 0 {
+			t.Errorf("ListRecords: expected no Records, got %+v", recs.GetRecords())
+		}
+	})
+}
+
 func TestListResults(t *testing.T) {
 	// Reset so IDs match names
 	lastID = 0
@@ -514,7 +805,7 @@ func TestListResults(t *testing.T) {
 			},
 			want: &pb.ListResultsResponse{
 				Results:       results[:1],
-				NextPageToken: pagetoken(t, results[1].GetId(), ""),
+				NextPageToken: pagetoken(t, results[0].GetId(), ""),
 			},
 		},
 		{
@@ -526,7 +817,7 @@ func TestListResults(t *testing.T) {
 			},
 			want: &pb.ListResultsResponse{
 				Results:       results[1:2],
-				NextPageToken: pagetoken(t, results[2].GetId(), `result.id > "1"`),
+				NextPageToken: pagetoken(t, results[1].GetId(), `result.id > "1"`),
 			},
 		},
 		{
@@ -549,7 +840,7 @@ func TestListResults(t *testing.T) {
 			},
 			want: &pb.ListResultsResponse{
 				Results:       results[1:2],
-				NextPageToken: pagetoken(t, results[2].GetId(), `result.id > "1"`),
+				NextPageToken: pagetoken(t, results[1].GetId(), `result.id > "1"`),
 			},
 		},
 		{
@@ -632,6 +923,122 @@ func TestListResults(t *testing.T) {
 	}
 }
 
+// TestListResults_Pagination checks that paging through Results in any
+// supported order returns every Result exactly once, including Results with
+// equal sort values.
+func TestListResults_Pagination(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	ctx := context.Background()
+
+	// Create Results in pairs sharing the same created_time.
+	results := make([]*pb.Result, 0, 9)
+	for i := 0; i < cap(results); i++ {
+		if i%2 == 0 {
+			fakeClock.Advance(time.Second)
+		}
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: "foo",
+			Result: &pb.Result{Name: fmt.Sprintf("foo/results/%d", i)},
+		})
+		if err != nil {
+			t.Fatalf("could not create result: %v", err)
+		}
+		results = append(results, res)
+	}
+	// Update every third Result in reverse order, so that updated_time does
+	// not follow created_time.
+	for i := len(results) - 1; i >= 0; i -= 3 {
+		fakeClock.Advance(time.Second)
+		res, err := srv.UpdateResult(ctx, &pb.UpdateResultRequest{
+			Name:   results[i].GetName(),
+			Result: results[i],
+		})
+		if err != nil {
+			t.Fatalf("could not update result: %v", err)
+		}
+		results[i] = res
+	}
+
+	for _, ob := range []string{
+		"",
+		"created_time",
+		"created_time desc",
+		"updated_time asc",
+		"updated_time desc, created_time desc",
+	} {
+		sortOrder, err := orderBy(ob)
+		if err != nil {
+			t.Fatalf("orderBy(%q): %v", ob, err)
+		}
+		want := make([]string, 0, len(results))
+		sorted := append([]*pb.Result{}, results...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			for _, k := range sortKeys(sortOrder) {
+				x, y := a.GetCreateTime().AsTime(), b.GetCreateTime().AsTime()
+				if k.field == "updated_time" {
+					x, y = a.GetUpdateTime().AsTime(), b.GetUpdateTime().AsTime()
+				}
+				if x.Equal(y) {
+					continue
+				}
+				return x.Before(y) != k.desc
+			}
+			return a.GetId() < b.GetId()
+		})
+		for _, r := range sorted {
+			want = append(want, r.GetId())
+		}
+
+		for _, pageSize := range []int32{1, 2, 4} {
+			t.Run(fmt.Sprintf("%s/%d", ob, pageSize), func(t *testing.T) {
+				var got []string
+				req := &pb.ListResultsRequest{
+					Parent:   "foo",
+					OrderBy:  ob,
+					PageSize: pageSize,
+				}
+				for {
+					resp, err := srv.ListResults(ctx, req)
+					if err != nil {
+						t.Fatalf("ListResults: %v", err)
+					}
+					for _, r := range resp.GetResults() {
+						got = append(got, r.GetId())
+					}
+					if resp.GetNextPageToken() == "" {
+						break
+					}
+					req.PageToken = resp.GetNextPageToken()
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("-want, +got: %s", diff)
+				}
+			})
+		}
+	}
+
+	// Page tokens cannot be reused with a different order.
+	resp, err := srv.ListResults(ctx, &pb.ListResultsRequest{
+		Parent:   "foo",
+		OrderBy:  "created_time desc",
+		PageSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("ListResults: %v", err)
+	}
+	if _, err := srv.ListResults(ctx, &pb.ListResultsRequest{
+		Parent:    "foo",
+		OrderBy:   "created_time asc",
+		PageToken: resp.GetNextPageToken(),
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %v, got %v", codes.InvalidArgument, err)
+	}
+}
+
 func pagetoken(t *testing.T, name, filter string) string {
 	if token, err := pagination.EncodeToken(name, filter); err != nil {
 		t.Fatalf("Failed to get encoded token: %v", err)
@@ -0,0 +1,361 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/retention"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRetentionInterval  = time.Hour
+	defaultRetentionBatchSize = 100
+
+	// expiredRule is the rule label of the soft deleted Results and Records
+	// purged once they expire.
+	expiredRule = "expired"
+)
+
+var (
+	retentionDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "results_retention_deleted_total",
+		Help: "Number of Results, Records, revisions and logs deleted by retention rules.",
+	}, []string{"kind", "rule"})
+	retentionErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "results_retention_errors_total",
+		Help: "Number of retention rule runs that failed.",
+	}, []string{"rule"})
+	retentionDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "results_retention_run_duration_seconds",
+		Help:    "Duration of the runs of the retention policy.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 4, 8),
+	})
+)
+
+func init() {
+	prometheus.MustRegister(retentionDeleted, retentionErrors, retentionDuration)
+}
+
+// RunRetention applies the retention policy, and purges expired soft deleted
+// Results and Records, periodically until the context is cancelled. The policy
+// may be nil.
+func (s *Server) RunRetention(ctx context.Context, policy *retention.Policy) {
+	interval := s.config.RETENTION_INTERVAL
+	if interval <= 0 {
+		interval = defaultRetentionInterval
+	}
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Prune(ctx, policy); err != nil {
+			s.logger.Errorf("Error applying retention policy: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		}
+	}
+}
+
+// Prune deletes the Results, Records and Record revisions that are not
+// retained by the policy, as well as the Results and Records that were soft
+// deleted for longer than SOFT_DELETE_EXPIRY. All rules are applied even if
+// some of them fail, in which case the first error is returned.
+func (s *Server) Prune(ctx context.Context, policy *retention.Policy) error {
+	start := time.Now()
+	defer func() {
+		retentionDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	var first error
+	if policy != nil {
+		for _, r := range policy.Rules {
+			if err := s.pruneRule(ctx, policy, r); err != nil {
+				retentionErrors.WithLabelValues(r.String()).Inc()
+				if first == nil {
+					first = fmt.Errorf("rule %s: %w", r, err)
+				}
+			}
+		}
+		for _, r := range policy.Revisions {
+			if err := s.pruneRevisions(ctx, policy, r); err != nil {
+				retentionErrors.WithLabelValues(r.String()).Inc()
+				if first == nil {
+					first = fmt.Errorf("rule %s: %w", r, err)
+				}
+			}
+		}
+	}
+	if s.config.SOFT_DELETE_EXPIRY > 0 {
+		if err := s.purge(ctx); err != nil {
+			retentionErrors.WithLabelValues(expiredRule).Inc()
+			if first == nil {
+				first = fmt.Errorf("purging expired deleted rows: %w", err)
+			}
+		}
+	}
+	return first
+}
+
+// purge deletes the Results and Records that were soft deleted for longer
+// than SOFT_DELETE_EXPIRY, along with their logs.
+func (s *Server) purge(ctx context.Context) error {
+	window, err := cel2sql.TimeRange(s.db.Dialector.Name(), "delete_time", time.Time{}, clock.Now().Add(-s.config.SOFT_DELETE_EXPIRY))
+	if err != nil {
+		return err
+	}
+	// Purging a Result purges the Records deleted with it.
+	if err := s.pruneBatches(ctx, expiredRule, true, func() *gorm.DB {
+		return s.db.WithContext(ctx).Unscoped().Model(&db.Result{}).Where(window.SQL, window.Args...).Order("id")
+	}); err != nil {
+		return err
+	}
+	return s.pruneBatches(ctx, expiredRule, false, func() *gorm.DB {
+		return s.db.WithContext(ctx).Unscoped().Model(&db.Record{}).Where(window.SQL, window.Args...).Order("id")
+	})
+}
+
+func (s *Server) pruneRule(ctx context.Context, policy *retention.Policy, r *retention.Rule) error {
+	// scope returns a query over the rows the rule applies to.
+	scope := func() *gorm.DB {
+		q := s.db.WithContext(ctx)
+		if r.Type == "" {
+			q = q.Model(&db.Result{})
+		} else {
+			q = q.Model(&db.Record{}).Where("type = ?", r.Type)
+		}
+		if r.Parent != "" {
+			return q.Where("parent = ?", r.Parent)
+		}
+		if overrides := policy.Overrides(r); len(overrides) > 0 {
+			q = q.Where("parent NOT IN ?", overrides)
+		}
+		return q
+	}
+
+	if r.MaxAge.Duration > 0 {
+		window, err := cel2sql.TimeRange(s.db.Dialector.Name(), "updated_time", time.Time{}, clock.Now().Add(-r.MaxAge.Duration))
+		if err != nil {
+			return err
+		}
+		if err := s.pruneBatches(ctx, r.String(), r.Type == "", func() *gorm.DB {
+			return scope().Where(window.SQL, window.Args...).Order("id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if r.MaxCount > 0 {
+		parents := []string{r.Parent}
+		if r.Parent == "" {
+			parents = nil
+			if err := scope().Distinct().Pluck("parent", &parents).Error; err != nil {
+				return err
+			}
+		}
+		for _, p := range parents {
+			p := p
+			// Deleting the rows past the newest MaxCount shifts the next
+			// ones into the same offset.
+			if err := s.pruneBatches(ctx, r.String(), r.Type == "", func() *gorm.DB {
+				return scope().Where("parent = ?", p).Order("created_time DESC, id DESC").Offset(r.MaxCount)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pruneBatches repeatedly deletes the first batch of Results, or Records,
+// selected by the query until none are left.
+func (s *Server) pruneBatches(ctx context.Context, rule string, isResult bool, query func() *gorm.DB) error {
+	size := s.retentionBatchSize()
+	for {
+		var n int
+		if isResult {
+			var results []*db.Result
+			if err := query().Limit(size).Find(&results).Error; err != nil {
+				return err
+			}
+			if err := s.deleteResults(ctx, rule, results); err != nil {
+				return err
+			}
+			n = len(results)
+		} else {
+			var records []*db.Record
+			if err := query().Limit(size).Find(&records).Error; err != nil {
+				return err
+			}
+			if err := s.deleteRecords(ctx, rule, records); err != nil {
+				return err
+			}
+			n = len(records)
+		}
+		if n < size {
+			return nil
+		}
+	}
+}
+
+func (s *Server) retentionBatchSize() int {
+	if s.config.RETENTION_BATCH_SIZE <= 0 {
+		return defaultRetentionBatchSize
+	}
+	return s.config.RETENTION_BATCH_SIZE
+}
+
+func (s *Server) pruneRevisions(ctx context.Context, policy *retention.Policy, r *retention.RevisionRule) error {
+	// scope returns a query over the revisions the rule applies to.
+	scope := func() *gorm.DB {
+		q := s.db.WithContext(ctx).Model(&db.RecordRevision{})
+		if r.Type != "" {
+			return q.Where("type = ?", r.Type)
+		}
+		if overrides := policy.RevisionOverrides(r); len(overrides) > 0 {
+			q = q.Where("type NOT IN ?", overrides)
+		}
+		return q
+	}
+
+	if r.MaxAge.Duration > 0 {
+		window, err := cel2sql.TimeRange(s.db.Dialector.Name(), "updated_time", time.Time{}, clock.Now().Add(-r.MaxAge.Duration))
+		if err != nil {
+			return err
+		}
+		if err := s.pruneRevisionBatches(ctx, r, func() *gorm.DB {
+			return scope().Where(window.SQL, window.Args...).Order("id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if r.MaxCount > 0 {
+		// Number the revisions of each Record from the newest, and delete the
+		// ones past MaxCount.
+		if err := s.pruneRevisionBatches(ctx, r, func() *gorm.DB {
+			ranked := scope().Select("id, ROW_NUMBER() OVER (PARTITION BY parent, record_id ORDER BY updated_time DESC, id DESC) AS n")
+			return s.db.WithContext(ctx).Table("(?) AS ranked", ranked).Where("n > ?", r.MaxCount).Order("id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneRevisionBatches repeatedly deletes the first batch of revisions
+// selected by the query until none are left.
+func (s *Server) pruneRevisionBatches(ctx context.Context, r *retention.RevisionRule, query func() *gorm.DB) error {
+	size := s.retentionBatchSize()
+	for {
+		var ids []string
+		if err := query().Limit(size).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err := s.db.WithContext(ctx).Where("id IN ?", ids).Delete(&db.RecordRevision{}).Error; err != nil {
+				return err
+			}
+			retentionDeleted.WithLabelValues("revision", r.String()).Add(float64(len(ids)))
+			s.logger.Debugf("Retention rule %s deleted %d revisions", r, len(ids))
+		}
+		if len(ids) < size {
+			return nil
+		}
+	}
+}
+
+// deleteResults deletes the Results along with their Records, whether they
+// are soft deleted or not, and queues the deletion of their logs. Watchers
+// are only notified of the ones that were not already soft deleted.
+func (s *Server) deleteResults(ctx context.Context, rule string, results []*db.Result) error {
+	if len(results) == 0 {
+		return nil
+	}
+	ids := make(map[string][]string)
+	for _, res := range results {
+		ids[res.Parent] = append(ids[res.Parent], res.ID)
+	}
+	for parent, ids := range ids {
+		var records []*db.Record
+		if err := s.db.WithContext(ctx).Unscoped().Where("parent = ? AND result_id IN ?", parent, ids).Find(&records).Error; err != nil {
+			return err
+		}
+		// Records are deleted by cascade.
+		logs, err := s.deleteWithLogs(ctx, records, func(tx *gorm.DB) error {
+			return tx.Unscoped().Where("parent = ? AND id IN ?", parent, ids).Delete(&db.Result{}).Error
+		})
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			if !rec.DeleteTime.Valid {
+				s.publishRecordDeleted(rec)
+			}
+		}
+		retentionDeleted.WithLabelValues("record", rule).Add(float64(len(records)))
+		retentionDeleted.WithLabelValues("log", rule).Add(float64(logs))
+	}
+	for _, res := range results {
+		if !res.DeleteTime.Valid {
+			s.events.PublishResult(pb.EventType_DELETED, result.ToAPI(res))
+		}
+	}
+	retentionDeleted.WithLabelValues("result", rule).Add(float64(len(results)))
+	s.logger.Debugf("Retention rule %s deleted %d Results", rule, len(results))
+	return nil
+}
+
+// deleteRecords deletes the Records, whether they are soft deleted or not,
+// and queues the deletion of their logs.
+func (s *Server) deleteRecords(ctx context.Context, rule string, records []*db.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	ids := make(map[string][]string)
+	for _, rec := range records {
+		ids[rec.Parent] = append(ids[rec.Parent], rec.ID)
+	}
+	logs, err := s.deleteWithLogs(ctx, records, func(tx *gorm.DB) error {
+		for parent, ids := range ids {
+			if err := tx.Unscoped().Where("parent = ? AND id IN ?", parent, ids).Delete(&db.Record{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if !rec.DeleteTime.Valid {
+			s.publishRecordDeleted(rec)
+		}
+	}
+	retentionDeleted.WithLabelValues("record", rule).Add(float64(len(records)))
+	retentionDeleted.WithLabelValues("log", rule).Add(float64(logs))
+	s.logger.Debugf("Retention rule %s deleted %d Records", rule, len(records))
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retention defines the policies used by the API server to prune old
+// Results, Records and Record revisions.
+package retention
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Rule limits how long, and how many, Results or Records are kept.
+type Rule struct {
+	// Parent the rule applies to. If empty, the rule applies to all parents
+	// that do not have a rule of their own for the same Type.
+	Parent string `json:"parent,omitempty"`
+	// Type of the Records the rule applies to, e.g.
+	// results.tekton.dev/v1alpha2.Log. If empty, the rule applies to Results,
+	// which are deleted along with all their Records.
+	Type string `json:"type,omitempty"`
+	// MaxAge deletes the Results or Records that have not been updated for
+	// longer than the given duration.
+	MaxAge metav1.Duration `json:"maxAge,omitempty"`
+	// MaxCount keeps at most the given number of Results or Records per
+	// parent, deleting the oldest ones first.
+	MaxCount int `json:"maxCount,omitempty"`
+}
+
+func (r *Rule) String() string {
+	parent, typ := r.Parent, r.Type
+	if parent == "" {
+		parent = "*"
+	}
+	if typ == "" {
+		typ = "results"
+	}
+	return fmt.Sprintf("%s/%s", parent, typ)
+}
+
+// RevisionRule limits how long, and how many, previous revisions of Records
+// are kept.
+type RevisionRule struct {
+	// Type of the Records the rule applies to. If empty, the rule applies to
+	// the Records of all types that do not have a rule of their own.
+	Type string `json:"type,omitempty"`
+	// MaxAge deletes the revisions that were written longer than the given
+	// duration ago.
+	MaxAge metav1.Duration `json:"maxAge,omitempty"`
+	// MaxCount keeps at most the given number of revisions per Record,
+	// deleting the oldest ones first.
+	MaxCount int `json:"maxCount,omitempty"`
+}
+
+func (r *RevisionRule) String() string {
+	typ := r.Type
+	if typ == "" {
+		typ = "*"
+	}
+	return fmt.Sprintf("revisions/%s", typ)
+}
+
+// Policy is a set of retention rules.
+type Policy struct {
+	Rules []*Rule `json:"rules"`
+	// Revisions are the rules applying to the previous revisions of Records.
+	Revisions []*RevisionRule `json:"revisions,omitempty"`
+}
+
+// Validate checks that the rules of the policy are well formed, and that no
+// two rules apply to the same parent and type, or no two revision rules to the
+// same type.
+func (p *Policy) Validate() error {
+	seen := make(map[string]bool, len(p.Rules))
+	for _, r := range p.Rules {
+		if r.MaxAge.Duration < 0 || r.MaxCount < 0 {
+			return fmt.Errorf("rule %s: maxAge and maxCount must not be negative", r)
+		}
+		if r.MaxAge.Duration == 0 && r.MaxCount == 0 {
+			return fmt.Errorf("rule %s: one of maxAge or maxCount must be set", r)
+		}
+		if seen[r.String()] {
+			return fmt.Errorf("rule %s: duplicate rule", r)
+		}
+		seen[r.String()] = true
+	}
+	seen = make(map[string]bool, len(p.Revisions))
+	for _, r := range p.Revisions {
+		if r.MaxAge.Duration < 0 || r.MaxCount < 0 {
+			return fmt.Errorf("rule %s: maxAge and maxCount must not be negative", r)
+		}
+		if r.MaxAge.Duration == 0 && r.MaxCount == 0 {
+			return fmt.Errorf("rule %s: one of maxAge or maxCount must be set", r)
+		}
+		if seen[r.String()] {
+			return fmt.Errorf("rule %s: duplicate rule", r)
+		}
+		seen[r.String()] = true
+	}
+	return nil
+}
+
+// Overrides returns the parents that have a rule of their own for the type of
+// the given rule, which takes precedence over a rule applying to all parents.
+func (p *Policy) Overrides(rule *Rule) []string {
+	if rule.Parent != "" {
+		return nil
+	}
+	var parents []string
+	for _, r := range p.Rules {
+		if r.Parent != "" && r.Type == rule.Type {
+			parents = append(parents, r.Parent)
+		}
+	}
+	return parents
+}
+
+// RevisionOverrides returns the types that have a revision rule of their own,
+// which takes precedence over a rule applying to all types.
+func (p *Policy) RevisionOverrides(rule *RevisionRule) []string {
+	if rule.Type != "" {
+		return nil
+	}
+	var types []string
+	for _, r := range p.Revisions {
+		if r.Type != "" {
+			types = append(types, r.Type)
+		}
+	}
+	return types
+}
+
+// Load reads a policy from a YAML or JSON file.
+func Load(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("error parsing retention policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retention policy %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// FromConfig returns the retention policy configured for the API server, or
+// nil if retention is not enabled.
+//
+// The policy is read from RETENTION_POLICY_PATH if set. RETENTION_MAX_AGE and
+// RETENTION_MAX_COUNT add a rule applying to the Results of all parents, unless
+// the policy file already has one. Likewise, RECORD_REVISIONS_MAX_AGE and
+// RECORD_REVISIONS_MAX_COUNT add a revision rule applying to all types.
+func FromConfig(cfg *config.Config) (*Policy, error) {
+	p := &Policy{}
+	if cfg.RETENTION_POLICY_PATH != "" {
+		var err error
+		if p, err = Load(cfg.RETENTION_POLICY_PATH); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RETENTION_MAX_AGE != 0 || cfg.RETENTION_MAX_COUNT != 0 {
+		def := &Rule{
+			MaxAge:   metav1.Duration{Duration: cfg.RETENTION_MAX_AGE},
+			MaxCount: cfg.RETENTION_MAX_COUNT,
+		}
+		for _, r := range p.Rules {
+			if r.String() == def.String() {
+				return nil, fmt.Errorf("RETENTION_MAX_AGE and RETENTION_MAX_COUNT conflict with rule %s of the retention policy", r)
+			}
+		}
+		p.Rules = append(p.Rules, def)
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RECORD_REVISIONS_MAX_AGE != 0 || cfg.RECORD_REVISIONS_MAX_COUNT != 0 {
+		def := &RevisionRule{
+			MaxAge:   metav1.Duration{Duration: cfg.RECORD_REVISIONS_MAX_AGE},
+			MaxCount: cfg.RECORD_REVISIONS_MAX_COUNT,
+		}
+		for _, r := range p.Revisions {
+			if r.String() == def.String() {
+				return nil, fmt.Errorf("RECORD_REVISIONS_MAX_AGE and RECORD_REVISIONS_MAX_COUNT conflict with rule %s of the retention policy", r)
+			}
+		}
+		p.Revisions = append(p.Revisions, def)
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if len(p.Rules) == 0 && len(p.Revisions) == 0 {
+		return nil, nil
+	}
+	return p, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writePolicy(t, `
+rules:
+- maxAge: 720h
+- parent: prod
+  maxAge: 8760h
+  maxCount: 10000
+- type: results.tekton.dev/v1alpha2.Log
+  maxAge: 168h
+revisions:
+- maxCount: 10
+- type: tekton.dev/v1beta1.TaskRun
+  maxAge: 24h
+`)
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := &Policy{Rules: []*Rule{
+		{MaxAge: metav1.Duration{Duration: 720 * time.Hour}},
+		{Parent: "prod", MaxAge: metav1.Duration{Duration: 8760 * time.Hour}, MaxCount: 10000},
+		{Type: "results.tekton.dev/v1alpha2.Log", MaxAge: metav1.Duration{Duration: 168 * time.Hour}},
+	}, Revisions: []*RevisionRule{
+		{MaxCount: 10},
+		{Type: "tekton.dev/v1beta1.TaskRun", MaxAge: metav1.Duration{Duration: 24 * time.Hour}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"prod"}, got.Overrides(got.Rules[0])); diff != "" {
+		t.Errorf("Overrides (-want, +got): %s", diff)
+	}
+	for _, r := range got.Rules[1:] {
+		if o := got.Overrides(r); o != nil {
+			t.Errorf("Overrides(%s): want none, got %v", r, o)
+		}
+	}
+	if diff := cmp.Diff([]string{"tekton.dev/v1beta1.TaskRun"}, got.RevisionOverrides(got.Revisions[0])); diff != "" {
+		t.Errorf("RevisionOverrides (-want, +got): %s", diff)
+	}
+	if o := got.RevisionOverrides(got.Revisions[1]); o != nil {
+		t.Errorf("RevisionOverrides(%s): want none, got %v", got.Revisions[1], o)
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown field":      "rules:\n- maxAge: 1h\n  foo: bar\n",
+		"bad duration":       "rules:\n- maxAge: 1 week\n",
+		"no limit":           "rules:\n- parent: foo\n",
+		"negative":           "rules:\n- maxCount: -1\n",
+		"duplicate":          "rules:\n- maxAge: 1h\n- maxCount: 1\n",
+		"revision limit":     "revisions:\n- type: foo\n",
+		"revision duplicate": "revisions:\n- type: foo\n  maxAge: 1h\n- type: foo\n  maxCount: 1\n",
+		"revision parent":    "revisions:\n- parent: foo\n  maxAge: 1h\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if p, err := Load(writePolicy(t, content)); err == nil {
+				t.Errorf("want error, got %+v", p)
+			}
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	file := writePolicy(t, "rules:\n- parent: foo\n  maxCount: 1\n")
+	for _, tc := range []struct {
+		name    string
+		cfg     *config.Config
+		want    *Policy
+		wantErr bool
+	}{
+		{
+			name: "disabled",
+			cfg:  &config.Config{},
+		},
+		{
+			name: "config",
+			cfg:  &config.Config{RETENTION_MAX_AGE: time.Hour, RETENTION_MAX_COUNT: 2},
+			want: &Policy{Rules: []*Rule{{MaxAge: metav1.Duration{Duration: time.Hour}, MaxCount: 2}}},
+		},
+		{
+			name: "file and config",
+			cfg:  &config.Config{RETENTION_POLICY_PATH: file, RETENTION_MAX_AGE: time.Hour},
+			want: &Policy{Rules: []*Rule{
+				{Parent: "foo", MaxCount: 1},
+				{MaxAge: metav1.Duration{Duration: time.Hour}},
+			}},
+		},
+		{
+			name: "revisions",
+			cfg:  &config.Config{RECORD_REVISIONS_MAX_COUNT: 5},
+			want: &Policy{Revisions: []*RevisionRule{{MaxCount: 5}}},
+		},
+		{
+			name: "revisions conflict",
+			cfg: &config.Config{
+				RETENTION_POLICY_PATH:      writePolicy(t, "revisions:\n- maxCount: 1\n"),
+				RECORD_REVISIONS_MAX_COUNT: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflict",
+			cfg: &config.Config{
+				RETENTION_POLICY_PATH: writePolicy(t, "rules:\n- maxCount: 1\n"),
+				RETENTION_MAX_AGE:     time.Hour,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			cfg:     &config.Config{RETENTION_POLICY_PATH: filepath.Join(t.TempDir(), "missing")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromConfig(tc.cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/retention"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"github.com/tektoncd/results/pkg/internal/jsonutil"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrune(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		DB_ENABLE_AUTO_MIGRATION: true,
+		RETENTION_BATCH_SIZE:     1,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	createResult := func(parent, name string) string {
+		t.Helper()
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: parent,
+			Result: &pb.Result{Name: result.FormatName(parent, name)},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		fakeClock.Advance(time.Hour)
+		return res.GetName()
+	}
+	// createLog creates a Log Record along with its log file.
+	createLog := func(parent, name string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("log"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: parent,
+			Record: &pb.Record{
+				Name: record.FormatName(parent, name),
+				Data: &pb.Any{
+					Type: v1alpha2.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+						Spec:   v1alpha2.LogSpec{Type: v1alpha2.FileLogType},
+						Status: v1alpha2.LogStatus{Path: path},
+					}),
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		fakeClock.Advance(time.Minute)
+		return path
+	}
+
+	fooA := createResult("foo", "a")
+	logA := createLog(fooA, "log-a")
+	createResult("foo", "b")
+	createResult("bar", "x")
+	createResult("foo", "c")
+	barY := createResult("bar", "y")
+	logY1 := createLog(barY, "log-y1")
+	logY2 := createLog(barY, "log-y2")
+	createResult("foo", "d")
+
+	policy := &retention.Policy{Rules: []*retention.Rule{
+		// Deletes bar/results/x, but not the Results of foo.
+		{MaxAge: metav1.Duration{Duration: 4 * time.Hour}},
+		// Deletes foo/results/a and foo/results/b.
+		{Parent: "foo", MaxCount: 2},
+		// Deletes log-y1.
+		{Type: v1alpha2.LogRecordType, MaxCount: 1},
+	}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	// Pruning is idempotent.
+	for i := 0; i < 2; i++ {
+		if err := srv.Prune(ctx, policy); err != nil {
+			t.Fatalf("Prune: %v", err)
+		}
+	}
+
+	results, err := srv.ListResults(ctx, &pb.ListResultsRequest{Parent: "-"})
+	if err != nil {
+		t.Fatalf("ListResults: %v", err)
+	}
+	var got []string
+	for _, r := range results.GetResults() {
+		got = append(got, r.GetName())
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff([]string{"bar/results/y", "foo/results/c", "foo/results/d"}, got); diff != "" {
+		t.Errorf("Results (-want, +got): %s", diff)
+	}
+
+	records, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: "-/results/-"})
+	if err != nil {
+		t.Fatalf("ListRecords: %v", err)
+	}
+	got = nil
+	for _, r := range records.GetRecords() {
+		got = append(got, r.GetName())
+	}
+	if diff := cmp.Diff([]string{record.FormatName(barY, "log-y2")}, got); diff != "" {
+		t.Errorf("Records (-want, +got): %s", diff)
+	}
+
+	for path, want := range map[string]bool{logA: false, logY1: false, logY2: true} {
+		_, err := os.Stat(path)
+		if exists := !errors.Is(err, os.ErrNotExist); exists != want {
+			t.Errorf("%s: want exists %t, got %v", path, want, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		kind, rule string
+		want       float64
+	}{
+		{kind: "result", rule: "*/results", want: 1},
+		{kind: "result", rule: "foo/results", want: 2},
+		{kind: "record", rule: "foo/results", want: 1},
+		{kind: "log", rule: "foo/results", want: 1},
+		{kind: "record", rule: "*/" + v1alpha2.LogRecordType, want: 1},
+	} {
+		m := &dto.Metric{}
+		if err := retentionDeleted.WithLabelValues(tc.kind, tc.rule).Write(m); err != nil {
+			t.Fatal(err)
+		}
+		if got := m.GetCounter().GetValue(); got != tc.want {
+			t.Errorf("deleted %s by %s: want %v, got %v", tc.kind, tc.rule, tc.want, got)
+		}
+	}
+}
+
+func TestPurge(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		DB_ENABLE_AUTO_MIGRATION: true,
+		SOFT_DELETE_EXPIRY:       time.Hour,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	var results []string
+	for _, name := range []string{"a", "b"} {
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: "purge",
+			Result: &pb.Result{Name: result.FormatName("purge", name)},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		results = append(results, res.GetName())
+	}
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte("log"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	logRecord := record.FormatName(results[0], "log")
+	if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: results[0],
+		Record: &pb.Record{
+			Name: logRecord,
+			Data: &pb.Any{
+				Type: v1alpha2.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha2.Log{
+					Spec:   v1alpha2.LogSpec{Type: v1alpha2.FileLogType},
+					Status: v1alpha2.LogStatus{Path: path},
+				}),
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	// The log is kept until the Log Record expires.
+	if _, err := srv.DeleteLog(ctx, &pb.DeleteLogRequest{Name: log.FormatName(results[0], "log")}); err != nil {
+		t.Fatalf("DeleteLog: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected log to be kept: %v", err)
+	}
+	fakeClock.Advance(30 * time.Minute)
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: results[1]}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+
+	deleted := func() (got []string) {
+		t.Helper()
+		res, err := srv.ListResults(ctx, &pb.ListResultsRequest{Parent: "purge", ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("ListResults: %v", err)
+		}
+		for _, r := range res.GetResults() {
+			if r.GetDeleteTime() != nil {
+				got = append(got, r.GetName())
+			}
+		}
+		rec, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: "purge/results/-", ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		for _, r := range rec.GetRecords() {
+			if r.GetDeleteTime() != nil {
+				got = append(got, r.GetName())
+			}
+		}
+		return got
+	}
+
+	for _, tc := range []struct {
+		advance time.Duration
+		want    []string
+		logKept bool
+	}{
+		{advance: 0, want: []string{results[1], logRecord}, logKept: true},
+		{advance: 45 * time.Minute, want: []string{results[1]}},
+		{advance: time.Hour},
+	} {
+		fakeClock.Advance(tc.advance)
+		if err := srv.Prune(ctx, nil); err != nil {
+			t.Fatalf("Prune: %v", err)
+		}
+		if diff := cmp.Diff(tc.want, deleted()); diff != "" {
+			t.Errorf("after %v, deleted (-want, +got): %s", tc.advance, diff)
+		}
+		if _, err := os.Stat(path); (err == nil) != tc.logKept {
+			t.Errorf("after %v: want log kept %t, got %v", tc.advance, tc.logKept, err)
+		}
+	}
+
+	// The Result that was not deleted is kept.
+	if _, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: results[0]}); err != nil {
+		t.Errorf("GetResult: %v", err)
+	}
+	for _, tc := range []struct {
+		kind string
+		want float64
+	}{
+		{kind: "result", want: 1},
+		{kind: "record", want: 1},
+		{kind: "log", want: 1},
+	} {
+		m := &dto.Metric{}
+		if err := retentionDeleted.WithLabelValues(tc.kind, expiredRule).Write(m); err != nil {
+			t.Fatal(err)
+		}
+		if got := m.GetCounter().GetValue(); got != tc.want {
+			t.Errorf("purged %s: want %v, got %v", tc.kind, tc.want, got)
+		}
+	}
+}
+
+func TestPruneRevisions(t *testing.T) {
+	srv, err := New(&config.Config{
+		DB_ENABLE_AUTO_MIGRATION: true,
+		RECORD_REVISIONS_ENABLE:  true,
+		RETENTION_BATCH_SIZE:     1,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "revisions",
+		Result: &pb.Result{Name: "revisions/results/a"},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	// x has revisions from 0 to 3 minutes, and y from 4 to 6 minutes.
+	x := createRevisions(t, srv, res.GetName(), "x", "test", 4)
+	y := createRevisions(t, srv, res.GetName(), "y", "other", 3)
+	fakeClock.Advance(10 * time.Minute)
+
+	policy := &retention.Policy{Revisions: []*retention.RevisionRule{
+		// Keeps the 2 newest revisions of x.
+		{Type: "test", MaxCount: 2},
+		// Deletes the oldest revision of y, but does not apply to x.
+		{MaxAge: metav1.Duration{Duration: 12 * time.Minute}},
+	}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if err := srv.Prune(ctx, policy); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	for _, tc := range []struct {
+		records []*pb.Record
+		want    []string
+	}{
+		{records: x, want: []string{x[3].GetEtag(), x[2].GetEtag()}},
+		{records: y, want: []string{y[2].GetEtag(), y[1].GetEtag()}},
+	} {
+		resp, err := srv.ListRecordRevisions(ctx, &pb.ListRecordRevisionsRequest{Name: tc.records[0].GetName()})
+		if err != nil {
+			t.Fatalf("ListRecordRevisions: %v", err)
+		}
+		var got []string
+		for _, r := range resp.GetRecords() {
+			got = append(got, r.GetEtag())
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s revisions (-want, +got): %s", tc.records[0].GetName(), diff)
+		}
+	}
+
+	for rule, want := range map[string]float64{"revisions/test": 2, "revisions/*": 1} {
+		m := &dto.Metric{}
+		if err := retentionDeleted.WithLabelValues("revision", rule).Write(m); err != nil {
+			t.Fatal(err)
+		}
+		if got := m.GetCounter().GetValue(); got != want {
+			t.Errorf("deleted revisions by %s: want %v, got %v", rule, want, got)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+// revisionsOrder is the order of the revisions returned by
+// ListRecordRevisions, newest first.
+const revisionsOrder = "updated_time DESC"
+
+// ListRecordRevisions lists the previous revisions of a Record.
+func (s *Server) ListRecordRevisions(ctx context.Context, req *pb.ListRecordRevisionsRequest) (*pb.ListRecordRevisionsResponse, error) {
+	parent, result, name, err := record.ParseName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+		return nil, err
+	}
+
+	userPageSize, err := pageSize(int(req.GetPageSize()))
+	if err != nil {
+		return nil, err
+	}
+	start, err := pageStart(req.GetPageToken(), "", revisionsOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := getRecord(s.db.WithContext(ctx), parent, result, name)
+	if err != nil {
+		return nil, err
+	}
+	// Fetch n+1 items to get the next token.
+	var revisions []*db.RecordRevision
+	q := s.db.WithContext(ctx).Where("parent = ? AND record_id = ?", r.Parent, r.ID)
+	q = pageQuery(q, revisionsOrder, start).Limit(userPageSize + 1).Find(&revisions)
+	if err := errors.Wrap(q.Error); err != nil {
+		return nil, err
+	}
+
+	var nextToken string
+	if len(revisions) > userPageSize {
+		revisions = revisions[:userPageSize]
+		last := revisions[len(revisions)-1]
+		nextToken, err = pageCursor(revisionsOrder, last.ID, time.Time{}, last.UpdatedTime).Encode()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]*pb.Record, 0, len(revisions))
+	for _, rev := range revisions {
+		api, err := record.RevisionToAPI(r, rev)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, api)
+	}
+	return &pb.ListRecordRevisionsResponse{
+		Records:       out,
+		NextPageToken: nextToken,
+	}, nil
+}
+
+// getRecordRevision returns the revision of the Record with the given ID.
+func (s *Server) getRecordRevision(ctx context.Context, r *db.Record, revision string) (*pb.Record, error) {
+	rev := &db.RecordRevision{}
+	q := s.db.WithContext(ctx).
+		Where("parent = ? AND record_id = ? AND id = ?", r.Parent, r.ID, revision).
+		First(rev)
+	if err := errors.Wrap(q.Error); err != nil {
+		return nil, err
+	}
+	return record.RevisionToAPI(r, rev)
+}
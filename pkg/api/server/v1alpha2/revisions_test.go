@@ -0,0 +1,150 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// createRevisions creates a Record of the given type and updates it n times,
+// returning the Record after each write, oldest first.
+func createRevisions(t *testing.T, srv *Server, parent, name, typ string, n int) []*pb.Record {
+	t.Helper()
+	ctx := context.Background()
+	r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: parent,
+		Record: &pb.Record{
+			Name: record.FormatName(parent, name),
+			Data: &pb.Any{Type: typ, Value: []byte(`{"n":0}`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	out := []*pb.Record{r}
+	for i := 1; i <= n; i++ {
+		fakeClock.Advance(time.Minute)
+		r, err = srv.UpdateRecord(ctx, &pb.UpdateRecordRequest{
+			Record: &pb.Record{
+				Name: r.GetName(),
+				Data: &pb.Any{Type: typ, Value: []byte(fmt.Sprintf(`{"n":%d}`, i))},
+			},
+		})
+		if err != nil {
+			t.Fatalf("UpdateRecord: %v", err)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func TestListRecordRevisions(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, RECORD_REVISIONS_ENABLE: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: "foo/results/bar"},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	records := createRevisions(t, srv, res.GetName(), "baz", "test", 3)
+	current := records[len(records)-1]
+
+	// Revisions are listed newest first, and have the values of the Record
+	// before each update.
+	var got []*pb.Record
+	token := ""
+	for {
+		resp, err := srv.ListRecordRevisions(ctx, &pb.ListRecordRevisionsRequest{
+			Name:      current.GetName(),
+			PageSize:  2,
+			PageToken: token,
+		})
+		if err != nil {
+			t.Fatalf("ListRecordRevisions: %v", err)
+		}
+		got = append(got, resp.GetRecords()...)
+		if token = resp.GetNextPageToken(); token == "" {
+			break
+		}
+	}
+	if len(got) != len(records)-1 {
+		t.Fatalf("expected %d revisions, got %+v", len(records)-1, got)
+	}
+	// Revision IDs are server assigned.
+	var want []*pb.Record
+	for i := len(records) - 2; i >= 0; i-- {
+		r := proto.Clone(records[i]).(*pb.Record)
+		r.Name = record.FormatRevisionName(r.GetName(), got[len(want)].GetRevisionId())
+		r.RevisionId = got[len(want)].GetRevisionId()
+		want = append(want, r)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ListRecordRevisions (-want, +got): %s", diff)
+	}
+
+	t.Run("get revision", func(t *testing.T) {
+		rev, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: want[1].GetName()})
+		if err != nil {
+			t.Fatalf("GetRecord: %v", err)
+		}
+		if diff := cmp.Diff(want[1], rev, protocmp.Transform()); diff != "" {
+			t.Errorf("GetRecord (-want, +got): %s", diff)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: record.FormatRevisionName(current.GetName(), "missing")}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetRecord: expected NOT_FOUND, got %v", err)
+		}
+		if _, err := srv.ListRecordRevisions(ctx, &pb.ListRecordRevisionsRequest{Name: record.FormatName(res.GetName(), "missing")}); status.Code(err) != codes.NotFound {
+			t.Errorf("ListRecordRevisions: expected NOT_FOUND, got %v", err)
+		}
+		if _, err := srv.ListRecordRevisions(ctx, &pb.ListRecordRevisionsRequest{Name: current.GetName(), PageToken: "invalid"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListRecordRevisions: expected INVALID_ARGUMENT, got %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv.config.RECORD_REVISIONS_ENABLE = false
+		defer func() { srv.config.RECORD_REVISIONS_ENABLE = true }()
+		records := createRevisions(t, srv, res.GetName(), "disabled", "test", 2)
+		resp, err := srv.ListRecordRevisions(ctx, &pb.ListRecordRevisionsRequest{Name: records[0].GetName()})
+		if err != nil {
+			t.Fatalf("ListRecordRevisions: %v", err)
+		}
+		if len(resp.GetRecords()) != 0 {
+			t.Errorf("expected no revisions, got %+v", resp.GetRecords())
+		}
+	})
+}
@@ -24,8 +24,13 @@ import (
 	"github.com/google/uuid"
 	cw "github.com/jonboulle/clockwork"
 	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
-	model "github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/migrate"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/events"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/redact"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"gorm.io/gorm"
 )
@@ -49,9 +54,16 @@ type Server struct {
 	db     *gorm.DB
 	auth   auth.Checker
 
-	// enableDatabaseAutoMigration controls whether the API server will
-	// auto-migrate the database upon startup.
-	enableDatabaseAutoMigration bool
+	// events broadcasts changes to Results and Records to Watch clients.
+	events *events.Broadcaster
+
+	// redactor redacts the secrets of logs and Record data, if redaction is
+	// enabled.
+	redactor *redact.Redactor
+
+	// parentsCache caches the parents that lists across parents are filtered
+	// from.
+	parentsCache parentsCache
 
 	// Converts result names -> IDs configurable to allow overrides for
 	// testing.
@@ -64,6 +76,13 @@ func New(config *config.Config, logger *zap.SugaredLogger, db *gorm.DB, opts ...
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
+	if err := log.ValidateCompression(v1alpha2.LogCompression(config.LOGS_COMPRESSION)); err != nil {
+		return nil, fmt.Errorf("invalid LOGS_COMPRESSION: %w", err)
+	}
+	redactor, err := redact.FromConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
 	srv := &Server{
 		db:     db,
@@ -71,7 +90,9 @@ func New(config *config.Config, logger *zap.SugaredLogger, db *gorm.DB, opts ...
 		config: config,
 		logger: logger,
 		// Default open auth for easier testing.
-		auth: auth.AllowAll{},
+		auth:     auth.AllowAll{},
+		events:   events.NewBroadcaster(watchEventLogSize),
+		redactor: redactor,
 	}
 
 	// Set default impls of overridable behavior
@@ -81,21 +102,77 @@ func New(config *config.Config, logger *zap.SugaredLogger, db *gorm.DB, opts ...
 		o(srv)
 	}
 
-	if config.DB_ENABLE_AUTO_MIGRATION {
-		if err := db.AutoMigrate(&model.Result{}, &model.Record{}); err != nil {
-			return nil, fmt.Errorf("error automigrating DB: %w", err)
-		}
+	if err := srv.migrate(context.Background()); err != nil {
+		return nil, err
 	}
 
 	return srv, nil
 }
 
+// migrate applies the pending migrations of the database schema if
+// auto-migration is enabled, and makes sure that the schema is not newer than
+// the server supports.
+func (s *Server) migrate(ctx context.Context) error {
+	m, err := migrate.New(s.db)
+	if err != nil {
+		return err
+	}
+	if s.config.DB_ENABLE_AUTO_MIGRATION {
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("error migrating DB: %w", err)
+		}
+		for _, mg := range applied {
+			s.logger.Infof("Applied migration %s", mg)
+		}
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if err := status.Check(); err != nil {
+		return err
+	}
+	if len(status.Pending) > 0 {
+		s.logger.Warnf("Database schema is at version %d, %d migrations are pending: run the migrate up command of the API server", status.Version, len(status.Pending))
+	}
+	return nil
+}
+
 type Option func(*Server)
 
 func WithAuth(c auth.Checker) Option {
 	return func(s *Server) {
-		s.auth = c
+		s.auth = auditedChecker{c}
+	}
+}
+
+// auditedChecker records the decisions of an auth checker in the audit events
+// of requests.
+type auditedChecker struct {
+	auth.Checker
+}
+
+func (c auditedChecker) Check(ctx context.Context, parent, resource, verb string) error {
+	err := c.Checker.Check(ctx, parent, resource, verb)
+	audit.RecordCheck(ctx, parent, resource, verb, err == nil)
+	return err
+}
+
+func (c auditedChecker) FilterParents(ctx context.Context, resource, verb string, candidates auth.Candidates) ([]string, bool, error) {
+	var (
+		parents  []string
+		filtered bool
+		err      error
+	)
+	if f, ok := c.Checker.(auth.ParentFilter); ok {
+		parents, filtered, err = f.FilterParents(ctx, resource, verb, candidates)
+	} else {
+		err = c.Checker.Check(ctx, "-", resource, verb)
 	}
+	audit.RecordCheck(ctx, "-", resource, verb, err == nil)
+	return parents, filtered, err
 }
 
 func withGetResultID(f getResultID) Option {
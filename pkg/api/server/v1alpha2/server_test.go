@@ -15,12 +15,24 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	cw "github.com/jonboulle/clockwork"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db/migrate"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/audit"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -36,3 +48,66 @@ func TestMain(m *testing.M) {
 	clock = fakeClock
 	os.Exit(m.Run())
 }
+
+func TestNewMigrations(t *testing.T) {
+	gdb := test.NewDB(t)
+
+	// Auto-migration disabled: the server starts with pending migrations.
+	if _, err := New(&config.Config{}, logger.Get("info"), gdb); err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	if _, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), gdb); err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// A migration applied by a newer server.
+	if err := gdb.Create(&migrate.Applied{Version: 9999, Name: "future", AppliedTime: time.Now()}).Error; err != nil {
+		t.Fatalf("failed to record migration: %v", err)
+	}
+	for _, autoMigrate := range []bool{false, true} {
+		if _, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: autoMigrate}, logger.Get("info"), gdb); !errors.Is(err, migrate.ErrNewerSchema) {
+			t.Errorf("New(auto-migration: %t): want %v, got %v", autoMigrate, migrate.ErrNewerSchema, err)
+		}
+	}
+}
+
+// verbChecker allows the requests of its verbs.
+type verbChecker []string
+
+func (c verbChecker) Check(_ context.Context, _, _, verb string) error {
+	for _, v := range c {
+		if v == verb {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+func TestWithAuth_Audit(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t),
+		WithAuth(verbChecker{auth.PermissionCreate}))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// The decisions of the auth checks are recorded in the audit events of
+	// requests.
+	ev := &audit.Event{}
+	if _, err := srv.CreateResult(audit.NewContext(context.Background(), ev), &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: "foo/results/bar"},
+	}); err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	if ev.Parent != "foo" || ev.Resource != auth.ResourceResults || ev.Verb != auth.PermissionCreate || ev.Decision != audit.DecisionAllow {
+		t.Errorf("CreateResult: got check %s %s/%s %s, want allowed create of foo/results", ev.Decision, ev.Parent, ev.Resource, ev.Verb)
+	}
+
+	ev = &audit.Event{}
+	if _, err := srv.DeleteResult(audit.NewContext(context.Background(), ev), &pb.DeleteResultRequest{Name: "foo/results/bar"}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("DeleteResult: %v, want %v", err, codes.Unauthenticated)
+	}
+	if ev.Parent != "foo" || ev.Resource != auth.ResourceResults || ev.Verb != auth.PermissionDelete || ev.Decision != audit.DecisionDeny {
+		t.Errorf("DeleteResult: got check %s %s/%s %s, want denied delete of foo/results", ev.Decision, ev.Parent, ev.Resource, ev.Verb)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// durationPercentiles are the percentiles of the durations reported in a
+// ResultsSummary, in the order of the p*_duration fields.
+var durationPercentiles = []int{50, 90, 95, 99}
+
+// groupKey is a parsed group_by field of a SummarizeResultsRequest.
+type groupKey struct {
+	// name is the group_by field as given in the request.
+	name string
+	// sql is the expression computing the field.
+	sql string
+	// status is set if the field is the RecordSummary status, whose values
+	// are reported by enum name.
+	status bool
+}
+
+// SummarizeResults computes aggregate statistics of the Results matching the
+// request in the database.
+func (s *Server) SummarizeResults(ctx context.Context, req *pb.SummarizeResultsRequest) (*pb.SummarizeResultsResponse, error) {
+	if req.GetParent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "parent missing")
+	}
+	parents, filtered, err := s.checkList(ctx, req.GetParent(), auth.ResourceResults)
+	if err != nil {
+		return nil, err
+	}
+	if filtered && len(parents) == 0 {
+		return &pb.SummarizeResultsResponse{ParentsFiltered: true}, nil
+	}
+
+	filter, err := s.parseFilter(s.env, req.GetFilter(), cel2sql.ResultsView)
+	if err != nil {
+		return nil, err
+	}
+	if filter.prg != nil {
+		return nil, status.Error(codes.InvalidArgument, "filter cannot be evaluated by the database")
+	}
+	var start, end time.Time
+	if req.GetStartTime() != nil {
+		start = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		end = req.GetEndTime().AsTime()
+	}
+	window, err := cel2sql.TimeRange(s.db.Dialector.Name(), "created_time", start, end)
+	if err != nil {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	}
+	keys, err := s.groupKeys(req.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+	duration, err := cel2sql.Seconds(s.db.Dialector.Name(), "recordsummary_start_time", "recordsummary_end_time")
+	if err != nil {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	}
+
+	// Raw tables are not scoped to the rows that are not soft deleted.
+	q := s.db.WithContext(ctx).Table("results").Where("delete_time IS NULL")
+	if req.GetParent() != "-" {
+		q = q.Where("parent = ?", req.GetParent())
+	} else if parents != nil {
+		q = q.Where("parent IN ?", parents)
+	}
+	q = filter.apply(q)
+	if window != nil {
+		q = q.Where(window.SQL, window.Args...)
+	}
+	selects := make([]string, 0, len(keys)+2)
+	for i, k := range keys {
+		selects = append(selects, fmt.Sprintf("%s AS g%d", k.sql, i))
+	}
+	selects = append(selects, "recordsummary_status AS status", duration+" AS duration")
+	q = q.Select(strings.Join(selects, ", "))
+	rows, err := s.db.WithContext(ctx).Raw(summaryQuery(len(keys)), q).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	defer rows.Close()
+
+	resp := &pb.SummarizeResultsResponse{ParentsFiltered: filtered}
+	for rows.Next() {
+		summary, err := scanSummary(rows, keys)
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		if summary.GetCount() > 0 {
+			resp.Summaries = append(resp.Summaries, summary)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return resp, nil
+}
+
+// groupKeys translates the group_by fields of a request to SQL expressions
+// over the results table.
+func (s *Server) groupKeys(groupBy []string) ([]*groupKey, error) {
+	keys := make([]*groupKey, 0, len(groupBy))
+	for _, name := range groupBy {
+		ast, issues := s.env.Compile("result." + name)
+		if issues != nil && issues.Err() != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid group_by %q: %v", name, issues.Err())
+		}
+		switch ast.ResultType().GetPrimitive() {
+		case exprpb.Type_STRING, exprpb.Type_INT64:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid group_by %q: only string and enum fields are supported", name)
+		}
+		sql, err := cel2sql.Expression(ast, cel2sql.ResultsView, s.db.Dialector.Name())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid group_by %q: unsupported field", name)
+		}
+		keys = append(keys, &groupKey{
+			name:   name,
+			sql:    sql,
+			status: sql == cel2sql.ResultsView.Fields["result.summary.status"].Column,
+		})
+	}
+	return keys, nil
+}
+
+// summaryQuery returns a query computing the statistics of each group from a
+// subquery, passed as its only argument, selecting the group values (g0, g1,
+// ...), status and duration of each Result.
+//
+// Percentiles use the nearest-rank method: the p-th percentile of n sorted
+// durations is the one ranked ceil(p*n/100). Ranks are computed with window
+// functions so that the query works on every supported database.
+func summaryQuery(groups int) string {
+	cols := make([]string, 0, groups)
+	for i := 0; i < groups; i++ {
+		cols = append(cols, fmt.Sprintf("g%d", i))
+	}
+	var partition, groupBy string
+	if groups > 0 {
+		partition = "PARTITION BY " + strings.Join(cols, ", ") + " "
+		groupBy = " GROUP BY " + strings.Join(cols, ", ") + " ORDER BY " + strings.Join(cols, ", ")
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	for _, c := range cols {
+		b.WriteString(c + ", ")
+	}
+	b.WriteString("COUNT(*)")
+	for v := 0; v < len(pb.RecordSummary_Status_name); v++ {
+		fmt.Fprintf(&b, ", SUM(CASE WHEN status = %d THEN 1 ELSE 0 END)", v)
+	}
+	b.WriteString(", COUNT(duration), AVG(duration)")
+	for _, p := range durationPercentiles {
+		fmt.Fprintf(&b, ", MIN(CASE WHEN rn = (%d * n + 99) / 100 THEN duration END)", p)
+	}
+	fmt.Fprintf(&b, " FROM (SELECT *, ROW_NUMBER() OVER (%sORDER BY CASE WHEN duration IS NULL THEN 1 ELSE 0 END, duration) AS rn, COUNT(duration) OVER (%s) AS n FROM (?) AS d) AS r",
+		partition, strings.TrimSpace(partition))
+	b.WriteString(groupBy)
+	return b.String()
+}
+
+// scanSummary reads a row returned by summaryQuery.
+func scanSummary(rows *sql.Rows, keys []*groupKey) (*pb.ResultsSummary, error) {
+	var (
+		groups      = make([]sql.NullString, len(keys))
+		count       int64
+		statuses    = make([]sql.NullInt64, len(pb.RecordSummary_Status_name))
+		durations   int64
+		mean        sql.NullFloat64
+		percentiles = make([]sql.NullFloat64, len(durationPercentiles))
+	)
+	dest := make([]interface{}, 0, len(groups)+len(statuses)+len(percentiles)+3)
+	for i := range groups {
+		dest = append(dest, &groups[i])
+	}
+	dest = append(dest, &count)
+	for i := range statuses {
+		dest = append(dest, &statuses[i])
+	}
+	dest = append(dest, &durations, &mean)
+	for i := range percentiles {
+		dest = append(dest, &percentiles[i])
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	out := &pb.ResultsSummary{
+		Group:         make(map[string]string, len(keys)),
+		Count:         count,
+		StatusCounts:  make(map[string]int64),
+		DurationCount: durations,
+		MeanDuration:  seconds(mean),
+		P50Duration:   seconds(percentiles[0]),
+		P90Duration:   seconds(percentiles[1]),
+		P95Duration:   seconds(percentiles[2]),
+		P99Duration:   seconds(percentiles[3]),
+	}
+	for i, k := range keys {
+		if !groups[i].Valid {
+			continue
+		}
+		v := groups[i].String
+		if k.status {
+			var n int32
+			if _, err := fmt.Sscan(v, &n); err == nil {
+				v = pb.RecordSummary_Status(n).String()
+			}
+		}
+		out.Group[k.name] = v
+	}
+	var completed int64
+	for v, n := range statuses {
+		if n.Int64 == 0 {
+			continue
+		}
+		out.StatusCounts[pb.RecordSummary_Status(v).String()] = n.Int64
+		if pb.RecordSummary_Status(v) != pb.RecordSummary_UNKNOWN {
+			completed += n.Int64
+		}
+	}
+	if completed > 0 {
+		out.SuccessRate = float64(out.StatusCounts[pb.RecordSummary_SUCCESS.String()]) / float64(completed)
+	}
+	return out, nil
+}
+
+// seconds converts a number of seconds computed by the database to a
+// Duration, rounded to the millisecond to hide floating point errors.
+func seconds(s sql.NullFloat64) *durationpb.Duration {
+	if !s.Valid {
+		return nil
+	}
+	return durationpb.New(time.Duration(math.Round(s.Float64*1000)) * time.Millisecond)
+}
@@ -0,0 +1,313 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSummarizeResults(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	const (
+		taskRun     = "tekton.dev/v1beta1.TaskRun"
+		pipelineRun = "tekton.dev/v1beta1.PipelineRun"
+	)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var created []time.Time
+	for i, r := range []struct {
+		parent   string
+		typ      string
+		status   pb.RecordSummary_Status
+		duration time.Duration
+		repo     string
+	}{
+		{parent: "foo", typ: taskRun, status: pb.RecordSummary_SUCCESS, duration: time.Minute, repo: "a"},
+		{parent: "foo", typ: taskRun, status: pb.RecordSummary_SUCCESS, duration: 2 * time.Minute, repo: "a"},
+		{parent: "foo", typ: taskRun, status: pb.RecordSummary_FAILURE, duration: 3 * time.Minute, repo: "b"},
+		{parent: "foo", typ: taskRun},
+		{parent: "foo", typ: pipelineRun, status: pb.RecordSummary_SUCCESS, duration: 10 * time.Minute, repo: "a"},
+		{parent: "bar", typ: taskRun, status: pb.RecordSummary_FAILURE, duration: time.Minute},
+	} {
+		name := result.FormatName(r.parent, fmt.Sprint(i))
+		summary := &pb.RecordSummary{
+			Record:    name + "/records/0",
+			Type:      r.typ,
+			Status:    r.status,
+			StartTime: timestamppb.New(start),
+		}
+		if r.duration > 0 {
+			summary.EndTime = timestamppb.New(start.Add(r.duration))
+		}
+		if r.repo != "" {
+			summary.Annotations = map[string]string{"repo": r.repo}
+		}
+		created = append(created, fakeClock.Now())
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: r.parent,
+			Result: &pb.Result{Name: name, Summary: summary},
+		}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		fakeClock.Advance(time.Hour)
+	}
+	// Results without a summary are counted with an UNKNOWN status.
+	if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: result.FormatName("foo", "nosummary")},
+	}); err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.SummarizeResultsRequest
+		want []*pb.ResultsSummary
+	}{
+		{
+			name: "all",
+			req:  &pb.SummarizeResultsRequest{Parent: "-"},
+			want: []*pb.ResultsSummary{{
+				Group:         map[string]string{},
+				Count:         7,
+				StatusCounts:  map[string]int64{"SUCCESS": 3, "FAILURE": 2, "UNKNOWN": 2},
+				SuccessRate:   0.6,
+				DurationCount: 5,
+				MeanDuration:  durationpb.New(204 * time.Second),
+				P50Duration:   durationpb.New(2 * time.Minute),
+				P90Duration:   durationpb.New(10 * time.Minute),
+				P95Duration:   durationpb.New(10 * time.Minute),
+				P99Duration:   durationpb.New(10 * time.Minute),
+			}},
+		},
+		{
+			name: "group by type",
+			req:  &pb.SummarizeResultsRequest{Parent: "foo", GroupBy: []string{"summary.type"}},
+			want: []*pb.ResultsSummary{
+				{
+					Group:        map[string]string{"summary.type": ""},
+					Count:        1,
+					StatusCounts: map[string]int64{"UNKNOWN": 1},
+				},
+				{
+					Group:         map[string]string{"summary.type": pipelineRun},
+					Count:         1,
+					StatusCounts:  map[string]int64{"SUCCESS": 1},
+					SuccessRate:   1,
+					DurationCount: 1,
+					MeanDuration:  durationpb.New(10 * time.Minute),
+					P50Duration:   durationpb.New(10 * time.Minute),
+					P90Duration:   durationpb.New(10 * time.Minute),
+					P95Duration:   durationpb.New(10 * time.Minute),
+					P99Duration:   durationpb.New(10 * time.Minute),
+				},
+				{
+					Group:         map[string]string{"summary.type": taskRun},
+					Count:         4,
+					StatusCounts:  map[string]int64{"SUCCESS": 2, "FAILURE": 1, "UNKNOWN": 1},
+					SuccessRate:   2.0 / 3,
+					DurationCount: 3,
+					MeanDuration:  durationpb.New(2 * time.Minute),
+					P50Duration:   durationpb.New(2 * time.Minute),
+					P90Duration:   durationpb.New(3 * time.Minute),
+					P95Duration:   durationpb.New(3 * time.Minute),
+					P99Duration:   durationpb.New(3 * time.Minute),
+				},
+			},
+		},
+		{
+			name: "group by annotation and status",
+			req: &pb.SummarizeResultsRequest{
+				Parent:  "foo",
+				Filter:  fmt.Sprintf("result.summary.type == %q", taskRun),
+				GroupBy: []string{`summary.annotations["repo"]`, "summary.status"},
+			},
+			want: []*pb.ResultsSummary{
+				{
+					Group:        map[string]string{"summary.status": "UNKNOWN"},
+					Count:        1,
+					StatusCounts: map[string]int64{"UNKNOWN": 1},
+				},
+				{
+					Group:         map[string]string{`summary.annotations["repo"]`: "a", "summary.status": "SUCCESS"},
+					Count:         2,
+					StatusCounts:  map[string]int64{"SUCCESS": 2},
+					SuccessRate:   1,
+					DurationCount: 2,
+					MeanDuration:  durationpb.New(90 * time.Second),
+					P50Duration:   durationpb.New(time.Minute),
+					P90Duration:   durationpb.New(2 * time.Minute),
+					P95Duration:   durationpb.New(2 * time.Minute),
+					P99Duration:   durationpb.New(2 * time.Minute),
+				},
+				{
+					Group:         map[string]string{`summary.annotations["repo"]`: "b", "summary.status": "FAILURE"},
+					Count:         1,
+					StatusCounts:  map[string]int64{"FAILURE": 1},
+					DurationCount: 1,
+					MeanDuration:  durationpb.New(3 * time.Minute),
+					P50Duration:   durationpb.New(3 * time.Minute),
+					P90Duration:   durationpb.New(3 * time.Minute),
+					P95Duration:   durationpb.New(3 * time.Minute),
+					P99Duration:   durationpb.New(3 * time.Minute),
+				},
+			},
+		},
+		{
+			name: "time window",
+			req: &pb.SummarizeResultsRequest{
+				Parent:    "foo",
+				StartTime: timestamppb.New(created[1]),
+				EndTime:   timestamppb.New(created[3]),
+			},
+			want: []*pb.ResultsSummary{{
+				Group:         map[string]string{},
+				Count:         2,
+				StatusCounts:  map[string]int64{"SUCCESS": 1, "FAILURE": 1},
+				SuccessRate:   0.5,
+				DurationCount: 2,
+				MeanDuration:  durationpb.New(150 * time.Second),
+				P50Duration:   durationpb.New(2 * time.Minute),
+				P90Duration:   durationpb.New(3 * time.Minute),
+				P95Duration:   durationpb.New(3 * time.Minute),
+				P99Duration:   durationpb.New(3 * time.Minute),
+			}},
+		},
+		{
+			name: "no match",
+			req:  &pb.SummarizeResultsRequest{Parent: "baz"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := srv.SummarizeResults(ctx, tc.req)
+			if err != nil {
+				t.Fatalf("SummarizeResults: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.GetSummaries(), protocmp.Transform()); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarizeResults_Error(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		req  *pb.SummarizeResultsRequest
+	}{
+		{
+			name: "missing parent",
+			req:  &pb.SummarizeResultsRequest{},
+		},
+		{
+			name: "invalid filter",
+			req:  &pb.SummarizeResultsRequest{Parent: "foo", Filter: "result.foo"},
+		},
+		{
+			name: "filter not translatable to SQL",
+			req:  &pb.SummarizeResultsRequest{Parent: "foo", Filter: "size(result.annotations) > 0"},
+		},
+		{
+			name: "unknown group_by",
+			req:  &pb.SummarizeResultsRequest{Parent: "foo", GroupBy: []string{"foo"}},
+		},
+		{
+			name: "unsupported group_by",
+			req:  &pb.SummarizeResultsRequest{Parent: "foo", GroupBy: []string{"summary.start_time"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := srv.SummarizeResults(context.Background(), tc.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("want %v, got %v", codes.InvalidArgument, err)
+			}
+		})
+	}
+}
+
+func TestSummarizeResults_FilterParents(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, AUTH_FILTER_LISTS: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	for _, name := range []string{"foo/results/a", "foo/results/b", "bar/results/a"} {
+		parent, _, err := result.ParseName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: parent,
+			Result: &pb.Result{Name: name},
+		}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		parents parentChecker
+		want    *pb.SummarizeResultsResponse
+	}{
+		{
+			name:    "some parents",
+			parents: parentChecker{"foo"},
+			want: &pb.SummarizeResultsResponse{
+				Summaries:       []*pb.ResultsSummary{{Count: 2, StatusCounts: map[string]int64{"UNKNOWN": 2}}},
+				ParentsFiltered: true,
+			},
+		},
+		{
+			name:    "no parents",
+			parents: parentChecker{},
+			want:    &pb.SummarizeResultsResponse{ParentsFiltered: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			WithAuth(tc.parents)(srv)
+			// Summaries across parents only count the Results of the
+			// parents the caller may list.
+			got, err := srv.SummarizeResults(ctx, &pb.SummarizeResultsRequest{Parent: "-"})
+			if err != nil {
+				t.Fatalf("SummarizeResults: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("SummarizeResults (-want, +got): %s", diff)
+			}
+		})
+	}
+}
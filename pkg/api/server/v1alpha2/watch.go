@@ -0,0 +1,181 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+
+	celenv "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/events"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchEventLogSize is the number of events kept for clients resuming a Watch
+// from a cursor.
+const watchEventLogSize = 1000
+
+// watchHeartbeatEvents is the number of events in a row that a watch skips,
+// because they do not match it, after which a heartbeat with the cursor of the
+// last one is sent. The last cursor of clients thus stays in the event log.
+const watchHeartbeatEvents = watchEventLogSize / 10
+
+// WatchResults streams changes to the Results of a parent.
+func (s *Server) WatchResults(req *pb.WatchResultsRequest, srv pb.Results_WatchResultsServer) error {
+	ctx := srv.Context()
+	if req.GetParent() == "" {
+		return status.Error(codes.InvalidArgument, "parent missing")
+	}
+	allowed, err := s.checkWatch(ctx, req.GetParent(), auth.ResourceResults)
+	if err != nil {
+		return err
+	}
+	prg, err := celenv.ParseFilter(s.env, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	return s.watch(ctx, req.GetCursor(), func(e *events.Event) (bool, error) {
+		r := e.Result
+		if r == nil {
+			return false, nil
+		}
+		parent, _, err := result.ParseName(r.GetName())
+		if err != nil || (req.GetParent() != "-" && parent != req.GetParent()) || !allowed(parent) {
+			return false, nil
+		}
+		if !s.watchMatch(r.GetName(), func() (bool, error) { return result.Match(r, prg) }) {
+			return false, nil
+		}
+		return true, srv.Send(&pb.WatchResultsResponse{
+			Type:   e.Type,
+			Result: r,
+			Cursor: e.Cursor,
+		})
+	}, func(cursor string) error {
+		return srv.Send(&pb.WatchResultsResponse{Cursor: cursor})
+	})
+}
+
+// WatchRecords streams changes to the Records of a Result.
+func (s *Server) WatchRecords(req *pb.WatchRecordsRequest, srv pb.Results_WatchRecordsServer) error {
+	ctx := srv.Context()
+	if req.GetParent() == "" {
+		return status.Error(codes.InvalidArgument, "parent missing")
+	}
+	parent, resultName, err := result.ParseName(req.GetParent())
+	if err != nil {
+		return err
+	}
+	allowed, err := s.checkWatch(ctx, parent, auth.ResourceRecords)
+	if err != nil {
+		return err
+	}
+	env, err := recordCEL()
+	if err != nil {
+		return err
+	}
+	prg, err := celenv.ParseFilter(env, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	return s.watch(ctx, req.GetCursor(), func(e *events.Event) (bool, error) {
+		r := e.Record
+		if r == nil {
+			return false, nil
+		}
+		p, res, _, err := record.ParseName(r.GetName())
+		if err != nil {
+			return false, nil
+		}
+		if (parent != "-" && p != parent) || (resultName != "-" && res != resultName) || !allowed(p) {
+			return false, nil
+		}
+		if !s.watchMatch(r.GetName(), func() (bool, error) { return record.Match(r, prg) }) {
+			return false, nil
+		}
+		return true, srv.Send(&pb.WatchRecordsResponse{
+			Type:   e.Type,
+			Record: r,
+			Cursor: e.Cursor,
+		})
+	}, func(cursor string) error {
+		return srv.Send(&pb.WatchRecordsResponse{Cursor: cursor})
+	})
+}
+
+// watchMatch returns whether the resource of an event matches the filter of a
+// watch. Filters that fail to evaluate on the resource, e.g. on a missing key
+// of its data, do not match, instead of ending the watch.
+func (s *Server) watchMatch(name string, match func() (bool, error)) bool {
+	ok, err := match()
+	if err != nil {
+		s.logger.Debugf("failed to evaluate watch filter on %s: %v", name, err)
+		return false
+	}
+	return ok
+}
+
+// watch subscribes to the event broadcaster from the given cursor and calls
+// send for each event until the client goes away or send fails. send returns
+// whether it sent the event, and heartbeat is called with the cursor of the
+// last event after watchHeartbeatEvents events in a row were not sent.
+func (s *Server) watch(ctx context.Context, cursor string, send func(*events.Event) (bool, error), heartbeat func(cursor string) error) error {
+	sub, err := s.events.Subscribe(cursor)
+	switch {
+	case errors.Is(err, events.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, events.ErrCursorExpired):
+		return status.Error(codes.OutOfRange, "cursor expired, list again and watch without a cursor")
+	case err != nil:
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer sub.Close()
+
+	skipped := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), events.ErrOverflow) {
+					return status.Error(codes.Aborted, "watch fell behind, resume from the last cursor received")
+				}
+				return status.Error(codes.Unavailable, "watch closed")
+			}
+			sent, err := send(e)
+			if err != nil {
+				return err
+			}
+			if sent {
+				skipped = 0
+				continue
+			}
+			if skipped++; skipped >= watchHeartbeatEvents {
+				if err := heartbeat(e.Cursor); err != nil {
+					return err
+				}
+				skipped = 0
+			}
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockWatchResultsServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pb.WatchResultsResponse
+}
+
+func (m *mockWatchResultsServer) Send(e *pb.WatchResultsResponse) error {
+	m.events <- e
+	return nil
+}
+
+func (m *mockWatchResultsServer) Context() context.Context {
+	return m.ctx
+}
+
+type mockWatchRecordsServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pb.WatchRecordsResponse
+}
+
+func (m *mockWatchRecordsServer) Send(e *pb.WatchRecordsResponse) error {
+	m.events <- e
+	return nil
+}
+
+func (m *mockWatchRecordsServer) Context() context.Context {
+	return m.ctx
+}
+
+// watchCursor returns a cursor positioned after all events published so far,
+// so that watches started from it deterministically see subsequent events.
+func watchCursor(t *testing.T, srv *Server) string {
+	t.Helper()
+	sub, err := srv.events.Subscribe("")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer sub.Close()
+	srv.events.PublishResult(pb.EventType_EVENT_TYPE_UNSPECIFIED, nil)
+	return (<-sub.Events()).Cursor
+}
+
+func TestWatchResults(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &mockWatchResultsServer{ctx: ctx, events: make(chan *pb.WatchResultsResponse, 10)}
+	done := make(chan error)
+	req := &pb.WatchResultsRequest{
+		Parent: "foo",
+		Filter: `!result.name.endsWith("ignored")`,
+		Cursor: watchCursor(t, srv),
+	}
+	go func() {
+		done <- srv.WatchResults(req, stream)
+	}()
+
+	for _, p := range []string{"foo", "bar"} {
+		for _, n := range []string{"a", "ignored"} {
+			if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+				Parent: p,
+				Result: &pb.Result{Name: result.FormatName(p, n)},
+			}); err != nil {
+				t.Fatalf("CreateResult: %v", err)
+			}
+		}
+	}
+	name := result.FormatName("foo", "a")
+	if _, err := srv.UpdateResult(ctx, &pb.UpdateResultRequest{
+		Name:   name,
+		Result: &pb.Result{Annotations: map[string]string{"foo": "bar"}},
+	}); err != nil {
+		t.Fatalf("UpdateResult: %v", err)
+	}
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: name}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+
+	var cursors []string
+	for _, want := range []pb.EventType{pb.EventType_ADDED, pb.EventType_MODIFIED, pb.EventType_DELETED} {
+		select {
+		case e := <-stream.events:
+			if e.GetType() != want || e.GetResult().GetName() != name {
+				t.Errorf("want %v event for %s, got %v", want, name, e)
+			}
+			cursors = append(cursors, e.GetCursor())
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %v event", want)
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("WatchResults: %v", err)
+	}
+	select {
+	case e := <-stream.events:
+		t.Errorf("unexpected event %v", e)
+	default:
+	}
+
+	// Resume after the first event.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stream.ctx = ctx
+	req.Cursor = cursors[0]
+	go func() {
+		done <- srv.WatchResults(req, stream)
+	}()
+	for i, want := range []pb.EventType{pb.EventType_MODIFIED, pb.EventType_DELETED} {
+		select {
+		case e := <-stream.events:
+			if e.GetType() != want || e.GetCursor() != cursors[i+1] {
+				t.Errorf("want %v event with cursor %s, got %v", want, cursors[i+1], e)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %v event", want)
+		}
+	}
+	cancel()
+	<-done
+}
+
+func TestWatchRecords(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &mockWatchRecordsServer{ctx: ctx, events: make(chan *pb.WatchRecordsResponse, 10)}
+	done := make(chan error)
+	req := &pb.WatchRecordsRequest{
+		Parent: result.FormatName("foo", "-"),
+		Cursor: watchCursor(t, srv),
+	}
+	go func() {
+		done <- srv.WatchRecords(req, stream)
+	}()
+
+	var names []string
+	for _, p := range []string{"foo", "bar"} {
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: p,
+			Result: &pb.Result{Name: result.FormatName(p, "a")},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		for i := 0; i < 2; i++ {
+			r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+				Parent: res.GetName(),
+				Record: &pb.Record{Name: record.FormatName(res.GetName(), fmt.Sprint(i))},
+			})
+			if err != nil {
+				t.Fatalf("CreateRecord: %v", err)
+			}
+			if p == "foo" {
+				names = append(names, r.GetName())
+			}
+		}
+	}
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: names[0]}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	// Deleting the Result deletes its remaining Record.
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: result.FormatName("foo", "a")}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+
+	for _, want := range []struct {
+		typ  pb.EventType
+		name string
+	}{
+		{pb.EventType_ADDED, names[0]},
+		{pb.EventType_ADDED, names[1]},
+		{pb.EventType_DELETED, names[0]},
+		{pb.EventType_DELETED, names[1]},
+	} {
+		select {
+		case e := <-stream.events:
+			if e.GetType() != want.typ || e.GetRecord().GetName() != want.name {
+				t.Errorf("want %v event for %s, got %v", want.typ, want.name, e)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %v event", want.typ)
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("WatchRecords: %v", err)
+	}
+}
+
+func TestWatchErrors(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	stream := &mockWatchResultsServer{ctx: context.Background()}
+	for _, tc := range []struct {
+		name string
+		req  *pb.WatchResultsRequest
+		want codes.Code
+	}{
+		{
+			name: "missing parent",
+			req:  &pb.WatchResultsRequest{},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "invalid filter",
+			req:  &pb.WatchResultsRequest{Parent: "foo", Filter: "foo"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "invalid cursor",
+			req:  &pb.WatchResultsRequest{Parent: "foo", Cursor: "tacocat"},
+			want: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := srv.WatchResults(tc.req, stream); status.Code(err) != tc.want {
+				t.Errorf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestWatch_SkippedEvents(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &mockWatchRecordsServer{ctx: ctx, events: make(chan *pb.WatchRecordsResponse, 10)}
+	done := make(chan error)
+	req := &pb.WatchRecordsRequest{
+		Parent: result.FormatName("foo", "-"),
+		Filter: `data.spec.name == "match"`,
+		Cursor: watchCursor(t, srv),
+	}
+	go func() {
+		done <- srv.WatchRecords(req, stream)
+	}()
+
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: result.FormatName("foo", "a")},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	// The filter fails to evaluate on the data of the first Record, which
+	// does not end the watch.
+	var names []string
+	for i, data := range []string{`{}`, `{"spec": {"name": "match"}}`} {
+		if i == 1 {
+			// Heartbeats are sent after many events that do not match.
+			for j := 0; j < watchHeartbeatEvents; j++ {
+				srv.events.PublishResult(pb.EventType_EVENT_TYPE_UNSPECIFIED, nil)
+			}
+		}
+		r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), fmt.Sprint(i)),
+				Data: &pb.Any{Type: "test", Value: []byte(data)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		names = append(names, r.GetName())
+	}
+
+	for _, want := range []string{"", names[1]} {
+		select {
+		case e := <-stream.events:
+			if e.GetRecord().GetName() != want || e.GetCursor() == "" {
+				t.Errorf("want event for %q, got %v", want, e)
+			}
+			if want == "" && e.GetType() != pb.EventType_EVENT_TYPE_UNSPECIFIED {
+				t.Errorf("want heartbeat, got %v", e)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for event for %q", want)
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("WatchRecords: %v", err)
+	}
+}
@@ -20,6 +20,28 @@ type Log struct {
 type LogSpec struct {
 	Resource Resource `json:"resource"`
 	Type     LogType  `json:"type"`
+	// Step is set on the log of a single step or sidecar of a TaskRun. Logs
+	// without it combine the output of all the steps of their run.
+	Step *LogStep `json:"step,omitempty"`
+}
+
+// LogStep is the step or sidecar of a TaskRun whose output a Log stores.
+type LogStep struct {
+	// Name of the step or sidecar.
+	Name string `json:"name"`
+	// Container is the name of the container of the step in the pod of the
+	// TaskRun.
+	Container string `json:"container"`
+	// Sidecar is set on the logs of sidecars.
+	Sidecar bool `json:"sidecar,omitempty"`
+	// Record is the name of the Record of the TaskRun.
+	Record string `json:"record,omitempty"`
+	// ExitCode and Reason are set once the container terminated.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	// StartTime and CompletionTime of the container.
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 type Resource struct {
@@ -34,13 +56,59 @@ type LogType string
 const (
 	FileLogType LogType = "File"
 	S3LogType   LogType = "S3"
+	// DBLogType logs are stored in chunks in the database of the API server.
+	DBLogType LogType = "DB"
+)
+
+// LogCompression is the codec of a compressed log.
+type LogCompression string
+
+const (
+	GzipLogCompression LogCompression = "gzip"
+	ZstdLogCompression LogCompression = "zstd"
 )
 
 type LogStatus struct {
 	Path string `json:"path,omitempty"`
-	Size int64  `json:"size"`
+	// Size is the size of the stored log, i.e. the bytes committed by its
+	// uploads.
+	Size int64 `json:"size"`
+	// InProgress is set while the log is being uploaded. The log is complete
+	// once its upload ends.
+	InProgress bool `json:"inProgress,omitempty"`
+	// Upload is the state of the upload of the log. It is not set on logs
+	// uploaded before upload states were recorded.
+	Upload LogUploadState `json:"upload,omitempty"`
+	// Checksum is the checksum of the complete log, as "sha256:" followed by
+	// the hex encoded SHA-256 digest of the uncompressed log.
+	Checksum string `json:"checksum,omitempty"`
+	// Compression is the codec the log is stored with. Logs without it are
+	// stored uncompressed. Size is the size of the uncompressed log.
+	Compression LogCompression `json:"compression,omitempty"`
+	// Received is the size of the log received by its uploads, before its
+	// secrets are redacted. It is only set when the API server redacts
+	// secrets, as Size is then the size of the redacted log, and uploads
+	// resume at it rather than at Size.
+	Received int64 `json:"received,omitempty"`
+	// Redactions are the numbers of secrets redacted from the log, by name
+	// of the pattern that matched them.
+	Redactions map[string]int64 `json:"redactions,omitempty"`
 }
 
+// LogUploadState is the state of the upload of a log.
+type LogUploadState string
+
+const (
+	// LogUploadInProgress logs are being uploaded.
+	LogUploadInProgress LogUploadState = "InProgress"
+	// LogUploadComplete logs were uploaded in whole.
+	LogUploadComplete LogUploadState = "Complete"
+	// LogUploadIncomplete logs were interrupted before the end of their
+	// upload. Their upload can be resumed at the size of the stored log, or
+	// the log uploaded again.
+	LogUploadIncomplete LogUploadState = "Incomplete"
+)
+
 func (t *Log) Default() {
 	t.TypeMeta.Kind = "Log"
 	t.TypeMeta.APIVersion = "results.tekton.dev/v1alpha2"
@@ -0,0 +1,167 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protoutil
+
+import (
+	"fmt"
+	"strings"
+
+	fbpb "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ApplyFieldMask copies the fields of src selected by paths into dst,
+// following https://google.aip.dev/134:
+//
+//   - A path selects a (possibly nested) field, e.g. `summary.status`. The
+//     field is set to its value in src, or cleared if it is unset in src.
+//   - The last element of a path into a map field with string keys may be a
+//     key, e.g. `annotations.foo`, selecting a single entry. Keys that are not
+//     valid field names can be quoted with backticks, e.g.
+//     "annotations.`example.com/foo`".
+//   - The path `*` selects every field.
+//
+// Fields marked as OUTPUT_ONLY and the top-level fields listed in immutable
+// are never modified, and paths selecting them are ignored. An error is
+// returned for paths that do not exist in the message.
+func ApplyFieldMask(dst, src proto.Message, paths []string, immutable ...string) error {
+	d, s := dst.ProtoReflect(), src.ProtoReflect()
+	if d.Descriptor().FullName() != s.Descriptor().FullName() {
+		return fmt.Errorf("mismatched message types %s and %s", d.Descriptor().FullName(), s.Descriptor().FullName())
+	}
+	skip := make(map[string]bool, len(immutable))
+	for _, f := range immutable {
+		skip[f] = true
+	}
+
+	for _, p := range paths {
+		if p == "*" {
+			fields := d.Descriptor().Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if skip[string(fd.Name())] || outputOnly(fd) {
+					continue
+				}
+				copyField(d, s, fd)
+			}
+			continue
+		}
+
+		elems, err := splitPath(p)
+		if err != nil {
+			return err
+		}
+		if skip[elems[0]] {
+			continue
+		}
+		if err := applyPath(d, s, elems); err != nil {
+			return fmt.Errorf("invalid path %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+func applyPath(dst, src protoreflect.Message, path []string) error {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return fmt.Errorf("unknown field %q in %s", path[0], dst.Descriptor().FullName())
+	}
+	if outputOnly(fd) {
+		return nil
+	}
+	rest := path[1:]
+	switch {
+	case len(rest) == 0:
+		copyField(dst, src, fd)
+	case fd.IsMap():
+		if len(rest) != 1 || fd.MapKey().Kind() != protoreflect.StringKind {
+			return fmt.Errorf("field %q only supports selecting a single key", fd.Name())
+		}
+		key := protoreflect.ValueOfString(rest[0]).MapKey()
+		if src.Has(fd) && src.Get(fd).Map().Has(key) {
+			dst.Mutable(fd).Map().Set(key, src.Get(fd).Map().Get(key))
+		} else if dst.Has(fd) {
+			dst.Mutable(fd).Map().Clear(key)
+		}
+	case fd.Message() != nil && !fd.IsList():
+		if !dst.Has(fd) && !src.Has(fd) {
+			// Nothing to set or clear.
+			return nil
+		}
+		return applyPath(dst.Mutable(fd).Message(), src.Get(fd).Message(), rest)
+	default:
+		return fmt.Errorf("field %q has no subfields", fd.Name())
+	}
+	return nil
+}
+
+// copyField sets the field of dst to its value in src, clearing it if it is
+// unset in src.
+func copyField(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if !src.Has(fd) {
+		dst.Clear(fd)
+		return
+	}
+	v := src.Get(fd)
+	if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+		v = protoreflect.ValueOfMessage(proto.Clone(v.Message().Interface()).ProtoReflect())
+	}
+	dst.Set(fd, v)
+}
+
+// splitPath splits a field mask path on dots, honoring backtick quoting.
+func splitPath(p string) ([]string, error) {
+	var (
+		elems  []string
+		b      strings.Builder
+		quoted bool
+	)
+	for _, r := range p {
+		switch {
+		case r == '`':
+			quoted = !quoted
+		case r == '.' && !quoted:
+			elems = append(elems, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("invalid path %q: unterminated quote", p)
+	}
+	elems = append(elems, b.String())
+	for _, e := range elems {
+		if e == "" {
+			return nil, fmt.Errorf("invalid path %q: empty element", p)
+		}
+	}
+	return elems, nil
+}
+
+func outputOnly(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+	for _, b := range proto.GetExtension(opts, fbpb.E_FieldBehavior).([]fbpb.FieldBehavior) {
+		if b == fbpb.FieldBehavior_OUTPUT_ONLY {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protoutil
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestApplyFieldMask(t *testing.T) {
+	dst := &pb.Result{
+		Name:        "a",
+		Etag:        "b",
+		Annotations: map[string]string{"foo": "1", "bar": "2", "example.com/baz": "3"},
+		Summary: &pb.RecordSummary{
+			Record:      "c",
+			Status:      pb.RecordSummary_FAILURE,
+			Annotations: map[string]string{"foo": "4"},
+		},
+	}
+	src := &pb.Result{
+		Name:        "d",
+		Etag:        "e",
+		Annotations: map[string]string{"foo": "5", "example.com/baz": "6"},
+		Summary: &pb.RecordSummary{
+			Type:        "f",
+			Status:      pb.RecordSummary_SUCCESS,
+			Annotations: map[string]string{"qux": "7"},
+		},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		paths []string
+		want  *pb.Result
+		err   bool
+	}{
+		{
+			name: "no paths",
+			want: dst,
+		},
+		{
+			name:  "all",
+			paths: []string{"*"},
+			want: &pb.Result{
+				Name:        "a",
+				Etag:        "b",
+				Annotations: src.Annotations,
+				Summary:     src.Summary,
+			},
+		},
+		{
+			name:  "map field",
+			paths: []string{"annotations"},
+			want: &pb.Result{
+				Name:        "a",
+				Etag:        "b",
+				Annotations: src.Annotations,
+				Summary:     dst.Summary,
+			},
+		},
+		{
+			name:  "map keys",
+			paths: []string{"annotations.foo", "annotations.bar", "annotations.`example.com/baz`"},
+			want: &pb.Result{
+				Name:        "a",
+				Etag:        "b",
+				Annotations: map[string]string{"foo": "5", "example.com/baz": "6"},
+				Summary:     dst.Summary,
+			},
+		},
+		{
+			name:  "nested fields",
+			paths: []string{"summary.status", "summary.record", "summary.annotations.qux"},
+			want: &pb.Result{
+				Name:        "a",
+				Etag:        "b",
+				Annotations: dst.Annotations,
+				Summary: &pb.RecordSummary{
+					Status:      pb.RecordSummary_SUCCESS,
+					Annotations: map[string]string{"foo": "4", "qux": "7"},
+				},
+			},
+		},
+		{
+			name:  "immutable and output only fields",
+			paths: []string{"name", "etag"},
+			want:  dst,
+		},
+		{
+			name:  "unknown field",
+			paths: []string{"summary.foo"},
+			err:   true,
+		},
+		{
+			name:  "scalar subfield",
+			paths: []string{"summary.status.foo"},
+			err:   true,
+		},
+		{
+			name:  "empty element",
+			paths: []string{"summary..status"},
+			err:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := proto.Clone(dst).(*pb.Result)
+			err := ApplyFieldMask(got, src, tc.paths, "name")
+			if (err != nil) != tc.err {
+				t.Fatalf("want error %t, got %v", tc.err, err)
+			}
+			if tc.err {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
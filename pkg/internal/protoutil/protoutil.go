@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package protoutil provides utilities for manipulating protos in tests.
+// Package protoutil provides utilities for manipulating protos.
 package protoutil
 
 import (
@@ -20,11 +20,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
-	fbpb "google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
-	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -53,11 +51,8 @@ func AnyBytes(t testing.TB, m proto.Message) []byte {
 func ClearOutputOnly(pb proto.Message) {
 	m := pb.ProtoReflect()
 	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
-		opts := fd.Options().(*descriptorpb.FieldOptions)
-		for _, b := range proto.GetExtension(opts, fbpb.E_FieldBehavior).([]fbpb.FieldBehavior) {
-			if b == fbpb.FieldBehavior_OUTPUT_ONLY {
-				m.Clear(fd)
-			}
+		if outputOnly(fd) {
+			m.Clear(fd)
 		}
 		return true
 	})
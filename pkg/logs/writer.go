@@ -2,6 +2,7 @@ package logs
 
 import (
 	"bytes"
+	"sync"
 
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 )
@@ -17,7 +18,10 @@ type Sender interface {
 	Send(log *pb.Log) error
 }
 
+// BufferedLog is an io.Writer that sends the written data in chunks. It is
+// safe for concurrent use, so that it can be flushed while it is written to.
 type BufferedLog struct {
+	mu     sync.Mutex
 	sender Sender
 	name   string
 	size   int
@@ -40,6 +44,8 @@ func NewBufferedWriter(sender Sender, name string, size int) *BufferedLog {
 }
 
 func (w *BufferedLog) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	allBts := make([]byte, 0)
 	allBts = append(allBts, w.buffer.Bytes()...)
 	allBts = append(allBts, p...)
@@ -71,9 +77,20 @@ func (w *BufferedLog) Write(p []byte) (n int, err error) {
 	return len(p), err
 }
 
+// Flush sends the buffered data. The writer can be written to again after
+// it is flushed.
 func (w *BufferedLog) Flush() (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	if len(w.buffer.Bytes()) > 0 {
-		return w.sendBytes(w.buffer.Bytes())
+		n, err := w.sendBytes(w.buffer.Bytes())
+		if err != nil {
+			return n, err
+		}
+		// The sent message may still reference the buffered data, so it is not
+		// reused.
+		w.buffer = bytes.Buffer{}
+		return n, nil
 	}
 	return 0, nil
 }
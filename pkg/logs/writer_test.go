@@ -92,3 +92,26 @@ func TestBufferedLog_Flush(t *testing.T) {
 		})
 	}
 }
+
+func TestBufferedLog_WriteAfterFlush(t *testing.T) {
+	sender := &mockGetLogServer{
+		receivedData: &bytes.Buffer{},
+	}
+	writer := NewBufferedWriter(sender, "test-result", 4)
+	for _, chunk := range []string{"Test", "ing", "!"} {
+		if _, err := writer.Write([]byte(chunk)); err != nil {
+			t.Fatalf("failed to write bytes: %v", err)
+		}
+		if _, err := writer.Flush(); err != nil {
+			t.Fatalf("failed to flush: %v", err)
+		}
+	}
+	// Flushing without buffered data sends nothing.
+	if _, err := writer.Flush(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+
+	if received := sender.receivedData.String(); received != "Testing!" {
+		t.Errorf("expected to receive %q, but received %q", "Testing!", received)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	rpb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -55,6 +56,19 @@ func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
 	if in == nil {
 		return nil, nil
 	}
+	return toLogProto(in, kind, name, fmt.Sprintf("%s-log", in.GetName()), nil)
+}
+
+// ToStepLogProto returns the Log of a step or sidecar of the TaskRun, stored
+// in the Record of the given name.
+func ToStepLogProto(in metav1.Object, name string, step *v1alpha2.LogStep) (*rpb.Any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	return toLogProto(in, "TaskRun", name, fmt.Sprintf("%s-%s-log", in.GetName(), step.Name), step)
+}
+
+func toLogProto(in metav1.Object, kind, name, logName string, step *v1alpha2.LogStep) (*rpb.Any, error) {
 	_, _, uid, err := record.ParseName(name)
 	if err != nil {
 		return nil, err
@@ -62,7 +76,7 @@ func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
 	log := &v1alpha2.Log{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: in.GetNamespace(),
-			Name:      fmt.Sprintf("%s-log", in.GetName()),
+			Name:      logName,
 			UID:       types.UID(uid),
 		},
 		Spec: v1alpha2.LogSpec{
@@ -72,6 +86,7 @@ func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
 				Name:      in.GetName(),
 				UID:       in.GetUID(),
 			},
+			Step: step,
 		},
 	}
 	log.Default()
@@ -85,6 +100,40 @@ func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
 	}, nil
 }
 
+// LogSteps returns the steps and sidecars of the TaskRun, with the state of
+// their containers, as recorded in its status. recordName is the name of the
+// Record of the TaskRun.
+func LogSteps(tr *v1beta1.TaskRun, recordName string) []*v1alpha2.LogStep {
+	steps := make([]*v1alpha2.LogStep, 0, len(tr.Status.Steps)+len(tr.Status.Sidecars))
+	for _, s := range tr.Status.Steps {
+		steps = append(steps, logStep(s.Name, s.ContainerName, false, recordName, s.ContainerState))
+	}
+	for _, s := range tr.Status.Sidecars {
+		steps = append(steps, logStep(s.Name, s.ContainerName, true, recordName, s.ContainerState))
+	}
+	return steps
+}
+
+func logStep(name, container string, sidecar bool, recordName string, state corev1.ContainerState) *v1alpha2.LogStep {
+	step := &v1alpha2.LogStep{
+		Name:      name,
+		Container: container,
+		Sidecar:   sidecar,
+		Record:    recordName,
+	}
+	switch {
+	case state.Running != nil:
+		step.StartTime = state.Running.StartedAt.DeepCopy()
+	case state.Terminated != nil:
+		exitCode := state.Terminated.ExitCode
+		step.ExitCode = &exitCode
+		step.Reason = state.Terminated.Reason
+		step.StartTime = state.Terminated.StartedAt.DeepCopy()
+		step.CompletionTime = state.Terminated.FinishedAt.DeepCopy()
+	}
+	return step
+}
+
 // TypeName returns a string representation of type Object type.
 // We do not know of any formalized spec for identifying objects across API
 // versions. Standard GVK string formatting does not produce something that's
@@ -368,6 +368,81 @@ func TestToLogProto(t *testing.T) {
 	}
 }
 
+func TestToStepLogProto(t *testing.T) {
+	step := &v1alpha2.LogStep{Name: "build", Container: "step-build", Record: "foo/results/bar/records/run"}
+	got, err := ToStepLogProto(taskrun, "foo/results/bar/records/baz", step)
+	if err != nil {
+		t.Fatalf("ToStepLogProto: %v", err)
+	}
+	log := &v1alpha2.Log{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: taskrun.GetNamespace(),
+			Name:      fmt.Sprintf("%s-build-log", taskrun.GetName()),
+			UID:       types.UID("baz"),
+		},
+		Spec: v1alpha2.LogSpec{
+			Resource: v1alpha2.Resource{
+				Kind:      "TaskRun",
+				Namespace: taskrun.GetNamespace(),
+				Name:      taskrun.GetName(),
+				UID:       taskrun.GetUID(),
+			},
+			Step: step,
+		},
+	}
+	log.Default()
+	want := &rpb.Any{
+		Type:  v1alpha2.LogRecordType,
+		Value: toJSON(log),
+	}
+	if d := cmp.Diff(want, got, protocmp.Transform()); d != "" {
+		t.Errorf("Diff(-want,+got): %s", d)
+	}
+}
+
+func TestLogSteps(t *testing.T) {
+	start := metav1.NewTime(time.Unix(1, 0))
+	end := metav1.NewTime(time.Unix(2, 0))
+	tr := &v1beta1.TaskRun{
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{
+					{
+						Name:          "build",
+						ContainerName: "step-build",
+						ContainerState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", StartedAt: start, FinishedAt: end},
+						},
+					},
+					{
+						Name:          "push",
+						ContainerName: "step-push",
+						ContainerState: corev1.ContainerState{
+							Running: &corev1.ContainerStateRunning{StartedAt: end},
+						},
+					},
+				},
+				Sidecars: []v1beta1.SidecarState{{
+					Name:          "db",
+					ContainerName: "sidecar-db",
+					ContainerState: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+					},
+				}},
+			},
+		},
+	}
+	exitCode := int32(1)
+	want := []*v1alpha2.LogStep{
+		{Name: "build", Container: "step-build", Record: "run", ExitCode: &exitCode, Reason: "Error", StartTime: &start, CompletionTime: &end},
+		{Name: "push", Container: "step-push", Record: "run", StartTime: &end},
+		{Name: "db", Container: "sidecar-db", Sidecar: true, Record: "run"},
+	}
+	if d := cmp.Diff(want, LogSteps(tr, "run")); d != "" {
+		t.Errorf("Diff(-want,+got): %s", d)
+	}
+}
+
 func TestTypeName(t *testing.T) {
 	for _, tc := range []struct {
 		i    runtime.Object
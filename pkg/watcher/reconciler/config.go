@@ -38,6 +38,10 @@ type Config struct {
 	// How long the controller waits to reprocess keys on certain events
 	// (e.g. an object doesn't match the provided label selectors).
 	RequeueInterval time.Duration
+
+	// LogsPerStep configures whether the logs of TaskRuns are stored in a Log
+	// per step and sidecar, instead of a single Log combining all the steps.
+	LogsPerStep bool
 }
 
 // GetDisableAnnotationupdate returns whether annotation updates should be
@@ -49,6 +53,15 @@ func (c *Config) GetDisableAnnotationUpdate() bool {
 	return c.DisableAnnotationUpdate
 }
 
+// GetLogsPerStep returns whether the logs of TaskRuns are stored per step.
+// This is safe to call for missing configs.
+func (c *Config) GetLogsPerStep() bool {
+	if c == nil {
+		return false
+	}
+	return c.LogsPerStep
+}
+
 // GetCompletedResourceGracePeriod returns the grace period to wait for
 // deleting Run objects.
 // If value < 0, objects will be deleted immediately.
@@ -46,6 +46,10 @@ import (
 
 var (
 	clock = clockwork.NewRealClock()
+
+	// logFlushInterval is the interval at which the buffered log of an
+	// object is sent while it is streamed, so that it can be followed.
+	logFlushInterval = 10 * time.Second
 )
 
 // Reconciler implements common reconciler behavior across different Tekton Run
@@ -55,6 +59,7 @@ type Reconciler struct {
 	objectClient           ObjectClient
 	cfg                    *reconciler.Config
 	IsReadyForDeletionFunc IsReadyForDeletion
+	ChildrenFunc           Children
 }
 
 func init() {
@@ -71,6 +76,11 @@ func init() {
 // API server before deleting all objects in cascade.
 type IsReadyForDeletion func(ctx context.Context, object results.Object) (bool, error)
 
+// Children is a function listing the objects whose Records are upserted along
+// with the Record of the object being reconciled, in a single request. For
+// instance, the controller that reconciles PipelineRuns lists their TaskRuns.
+type Children func(ctx context.Context, object results.Object) ([]results.Object, error)
+
 // NewDynamicReconciler creates a new dynamic Reconciler.
 func NewDynamicReconciler(rc pb.ResultsClient, lc pb.LogsClient, oc ObjectClient, cfg *reconciler.Config) *Reconciler {
 	return &Reconciler{
@@ -98,9 +108,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, o results.Object) error {
 		logger.Debugf("Post SetGroupVersionKind: %s", o.GetObjectKind().GroupVersionKind().String())
 	}
 
-	// Upsert record.
+	var children []results.Object
+	if r.ChildrenFunc != nil {
+		var err error
+		if children, err = r.ChildrenFunc(ctx, o); err != nil {
+			return err
+		}
+		for _, child := range children {
+			if child.GetObjectKind().GroupVersionKind().Empty() {
+				gvk, err := convert.InferGVK(child)
+				if err != nil {
+					return err
+				}
+				child.GetObjectKind().SetGroupVersionKind(gvk)
+			}
+		}
+	}
+
+	// Upsert record, along with the records of the children.
 	startTime := time.Now()
-	res, rec, err := r.resultsClient.Put(ctx, o)
+	res, rec, err := r.resultsClient.PutAll(ctx, o, children)
 	timeTakenField := zap.Int64("results.tekton.dev/time-taken-ms", time.Since(startTime).Milliseconds())
 
 	if err != nil {
@@ -254,39 +281,39 @@ func getCompletionTime(object results.Object) (*time.Time, error) {
 	return completionTime, nil
 }
 
-// sendLog streams logs to the API server
+// sendLog streams logs to the API server. Logs are streamed once the object
+// has started, and followed until it is done.
 func (r *Reconciler) sendLog(ctx context.Context, o results.Object) error {
 	logger := logging.FromContext(ctx)
 	condition := o.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
 	GVK := o.GetObjectKind().GroupVersionKind()
 	if !GVK.Empty() &&
 		(GVK.Kind == "TaskRun" || GVK.Kind == "PipelineRun") &&
-		condition != nil &&
-		condition.Type == "Succeeded" &&
-		(condition.Reason == "Succeeded" || condition.Reason == "Failed") {
+		condition != nil {
+
+		if tr, ok := o.(*pipelinev1beta1.TaskRun); ok && r.cfg.GetLogsPerStep() {
+			return r.sendStepLogs(ctx, tr)
+		}
 
 		rec, err := r.resultsClient.GetLogRecord(ctx, o)
 		if err != nil {
 			return err
 		}
-		if rec != nil {
-			// we had already started logs streaming
-			parent, resName, recName, err := record.ParseName(rec.GetName())
+		// The log is uploaded once its record is created, and uploaded
+		// again if its upload was interrupted.
+		upload := rec == nil
+		if rec == nil {
+			// Create a log record if the object has/supports logs.
+			rec, err = r.resultsClient.PutLog(ctx, o)
 			if err != nil {
 				return err
 			}
-			logName := log.FormatName(result.FormatName(parent, resName), recName)
-			// Update log annotation if it doesn't exist
-			if err := r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName}); err != nil {
+		} else {
+			object, err := decodeLog(rec)
+			if err != nil {
 				return err
 			}
-			return nil
-		}
-
-		// Create a log record if the object has/supports logs.
-		rec, err = r.resultsClient.PutLog(ctx, o)
-		if err != nil {
-			return err
+			upload = interrupted(object)
 		}
 
 		parent, resName, recName, err := record.ParseName(rec.GetName())
@@ -294,6 +321,14 @@ func (r *Reconciler) sendLog(ctx context.Context, o results.Object) error {
 			return err
 		}
 		logName := log.FormatName(result.FormatName(parent, resName), recName)
+		// Update log annotation if it doesn't exist
+		if err := r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName}); err != nil {
+			return err
+		}
+		if !upload || !uploads.start(logName) {
+			// The log was uploaded, or is being uploaded.
+			return nil
+		}
 
 		var logType string
 		switch o.GetObjectKind().GroupVersionKind().Kind {
@@ -303,10 +338,6 @@ func (r *Reconciler) sendLog(ctx context.Context, o results.Object) error {
 			logType = tknlog.LogTypePipeline
 		}
 
-		if err := r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName}); err != nil {
-			return err
-		}
-
 		logger.Debugw("Streaming log started",
 			zap.String("namespace", o.GetNamespace()),
 			zap.String("kind", o.GetObjectKind().GroupVersionKind().Kind),
@@ -314,6 +345,7 @@ func (r *Reconciler) sendLog(ctx context.Context, o results.Object) error {
 		)
 
 		go func() {
+			defer uploads.done(logName)
 			err := r.streamLogs(ctx, o, logType, logName)
 			if err != nil {
 				logger.Errorw("Error streaming log",
@@ -351,6 +383,7 @@ func (r *Reconciler) streamLogs(ctx context.Context, o results.Object, logType,
 
 	reader, err := tknlog.NewReader(logType, &tknopts.LogOptions{
 		AllSteps:        true,
+		Follow:          true,
 		Params:          tknParams,
 		PipelineRunName: o.GetName(),
 		TaskrunName:     o.GetName(),
@@ -367,11 +400,33 @@ func (r *Reconciler) streamLogs(ctx context.Context, o results.Object, logType,
 		return fmt.Errorf("error reading from tkn reader: %v", err)
 	}
 
+	// Send the buffered log periodically while the object runs, since its
+	// output may take a long time to fill the buffer.
+	stopFlush := make(chan struct{})
+	flushDone := make(chan struct{})
+	go func() {
+		defer close(flushDone)
+		ticker := clock.NewTicker(logFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopFlush:
+				return
+			case <-ticker.Chan():
+				if _, err := writer.Flush(); err != nil {
+					logger.Error(err)
+				}
+			}
+		}
+	}()
+
 	errChanRepeater := make(chan error)
 	go func(echan <-chan error, o metav1.Object) {
 		writeErr := <-echan
 		errChanRepeater <- writeErr
 
+		close(stopFlush)
+		<-flushDone
 		_, err := writer.Flush()
 		if err != nil {
 			logger.Error(err)
@@ -394,3 +394,44 @@ func TestReconcile_PipelineRun(t *testing.T) {
 	// since everything is handled as a generic object testing TaskRuns should
 	// be sufficient coverage.
 }
+
+func TestReconcile_RunningTaskRunLog(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+
+	tr := taskrun.DeepCopy()
+	tr.Name = "running-taskrun"
+	tr.UID = "67890"
+	tr.Status = v1beta1.TaskRunStatus{}
+	trclient := &TaskRunClient{TaskRunInterface: pipelineclient.Get(ctx).TektonV1beta1().TaskRuns(tr.GetNamespace())}
+	if _, err := trclient.Create(ctx, tr, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewDynamicReconciler(resultsClient, logsClient, trclient, &reconciler.Config{DisableAnnotationUpdate: true})
+
+	// Logs are not streamed before the TaskRun starts.
+	if err := r.Reconcile(ctx, tr); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := r.resultsClient.GetLogRecord(ctx, tr)
+	if err != nil {
+		t.Fatalf("GetLogRecord: %v", err)
+	}
+	if rec != nil {
+		t.Fatalf("want no log record before the TaskRun starts, got %v", rec)
+	}
+
+	// Logs are streamed while the TaskRun runs.
+	tr.Status.InitializeConditions()
+	tr.Status.MarkResourceOngoing(v1beta1.TaskRunReasonRunning, "running")
+	if err := r.Reconcile(ctx, tr); err != nil {
+		t.Fatal(err)
+	}
+	rec, err = r.resultsClient.GetLogRecord(ctx, tr)
+	if err != nil {
+		t.Fatalf("GetLogRecord: %v", err)
+	}
+	if rec == nil {
+		t.Fatal("want log record of the running TaskRun")
+	}
+}
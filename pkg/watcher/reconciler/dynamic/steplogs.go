@@ -0,0 +1,273 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tektoncd/cli/pkg/cli"
+	tknlog "github.com/tektoncd/cli/pkg/log"
+	tknopts "github.com/tektoncd/cli/pkg/options"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/logs"
+	"github.com/tektoncd/results/pkg/watcher/convert"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// sendStepLogs streams the log of each step and sidecar of the TaskRun to a
+// Log of its own. Logs are streamed once the pod of the TaskRun is created,
+// and the state of the containers is recorded in the Logs once the TaskRun is
+// done. If the streaming was interrupted, e.g. by a restart of the watcher,
+// the logs whose upload did not complete are streamed again.
+func (r *Reconciler) sendStepLogs(ctx context.Context, tr *pipelinev1beta1.TaskRun) error {
+	logger := logging.FromContext(ctx)
+	steps := convert.LogSteps(tr, "")
+	if len(steps) == 0 {
+		// The steps are listed in the status once the pod is created.
+		return nil
+	}
+	recs, created, err := r.resultsClient.PutStepLogs(ctx, tr, steps)
+	if err != nil {
+		return err
+	}
+
+	// The log of each step is named after the name tkn gives to the step.
+	names := make(map[string]string, len(recs))
+	for i, rec := range recs {
+		if !created {
+			object, err := decodeLog(rec)
+			if err != nil {
+				return err
+			}
+			// Logs whose upload was interrupted are streamed again, as
+			// are the logs yet to be uploaded while the TaskRun runs.
+			if !interrupted(object) && (object.Status.Upload != "" || tr.IsDone()) {
+				continue
+			}
+		}
+		parent, resName, recName, err := record.ParseName(rec.GetName())
+		if err != nil {
+			return err
+		}
+		names[strings.TrimPrefix(steps[i].Container, "step-")] = log.FormatName(result.FormatName(parent, resName), recName)
+	}
+	logNames := make([]string, 0, len(names))
+	for _, name := range names {
+		logNames = append(logNames, name)
+	}
+	if len(names) == 0 || !uploads.start(logNames...) {
+		// The logs were streamed, or are being streamed.
+		return nil
+	}
+
+	logger.Debugw("Streaming step logs started",
+		zap.String("namespace", tr.GetNamespace()),
+		zap.String("name", tr.GetName()),
+		zap.Int("steps", len(names)),
+	)
+	go func() {
+		defer uploads.done(logNames...)
+		if err := r.streamStepLogs(ctx, tr, names); err != nil {
+			logger.Errorw("Error streaming step logs",
+				zap.String("namespace", tr.GetNamespace()),
+				zap.String("name", tr.GetName()),
+				zap.Error(err),
+			)
+		}
+		logger.Debugw("Streaming step logs completed",
+			zap.String("namespace", tr.GetNamespace()),
+			zap.String("name", tr.GetName()),
+		)
+	}()
+	return nil
+}
+
+func (r *Reconciler) streamStepLogs(ctx context.Context, tr *pipelinev1beta1.TaskRun, names map[string]string) error {
+	tknParams := &cli.TektonParams{}
+	tknParams.SetNamespace(tr.GetNamespace())
+	reader, err := tknlog.NewReader(tknlog.LogTypeTask, &tknopts.LogOptions{
+		Follow:      true,
+		Params:      tknParams,
+		TaskrunName: tr.GetName(),
+		// Errors are received from the error channel of the reader.
+		Stream: &cli.Stream{
+			Out: io.Discard,
+			Err: io.Discard,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tkn reader: %v", err)
+	}
+	logC, errC, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("error reading from tkn reader: %v", err)
+	}
+	r.writeStepLogs(ctx, names, logC, errC)
+
+	clients, err := tknParams.Clients()
+	if err != nil {
+		return err
+	}
+	return r.updateStepLogs(ctx, names, func(ctx context.Context) (*pipelinev1beta1.TaskRun, error) {
+		return clients.Tekton.TektonV1beta1().TaskRuns(tr.GetNamespace()).Get(ctx, tr.GetName(), metav1.GetOptions{})
+	})
+}
+
+// writeStepLogs uploads the lines read from the steps to the Logs of the
+// given names, keyed by step name. tkn reads the steps one after the other,
+// and ends the lines of each step with an EOFLOG line.
+func (r *Reconciler) writeStepLogs(ctx context.Context, names map[string]string, logC <-chan tknlog.Log, errC <-chan error) {
+	logger := logging.FromContext(ctx)
+	var current *stepLog
+	// failed are the steps whose upload failed, the rest of whose lines
+	// are dropped.
+	failed := map[string]bool{}
+	closeCurrent := func() {
+		if current == nil {
+			return
+		}
+		if err := current.Close(); err != nil {
+			logger.Errorw("Error sending step log", zap.String("step", current.step), zap.Error(err))
+		}
+		current = nil
+	}
+
+	// Send the buffered log periodically while the step runs, since its
+	// output may take a long time to fill the buffer.
+	ticker := clock.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+	for logC != nil || errC != nil {
+		select {
+		case l, ok := <-logC:
+			if !ok {
+				logC = nil
+				continue
+			}
+			if current != nil && current.step != l.Step {
+				closeCurrent()
+			}
+			if l.Log == "EOFLOG" {
+				closeCurrent()
+				continue
+			}
+			if current == nil {
+				name, ok := names[l.Step]
+				if !ok || failed[l.Step] {
+					continue
+				}
+				current = newStepLog(ctx, r.resultsClient, l.Step, name)
+			}
+			if _, err := fmt.Fprintf(current, "%s\n", l.Log); err != nil {
+				logger.Errorw("Error sending step log", zap.String("step", current.step), zap.Error(err))
+				failed[current.step] = true
+				closeCurrent()
+			}
+		case err, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			logger.Warnw("Error reading step log", zap.Error(err))
+		case <-ticker.Chan():
+			if current == nil {
+				continue
+			}
+			if _, err := current.Flush(); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+	closeCurrent()
+}
+
+// updateStepLogs records the state of the containers of the steps in their
+// Logs, once the TaskRun is done.
+func (r *Reconciler) updateStepLogs(ctx context.Context, names map[string]string, get func(context.Context) (*pipelinev1beta1.TaskRun, error)) error {
+	for {
+		tr, err := get(ctx)
+		if err != nil {
+			return err
+		}
+		if tr.IsDone() {
+			for _, step := range convert.LogSteps(tr, "") {
+				name, ok := names[strings.TrimPrefix(step.Container, "step-")]
+				if !ok {
+					continue
+				}
+				parent, resName, recName, err := log.ParseName(name)
+				if err != nil {
+					return err
+				}
+				if _, err := r.resultsClient.UpdateStepLog(ctx, record.FormatName(result.FormatName(parent, resName), recName), step); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(logFlushInterval):
+		}
+	}
+}
+
+// stepLog uploads the log of a step. Its UpdateLog stream is opened once the
+// log is first sent, so that no log is uploaded for steps without output.
+type stepLog struct {
+	*logs.BufferedLog
+	ctx    context.Context
+	client pb.LogsClient
+	step   string
+	stream pb.Logs_UpdateLogClient
+}
+
+func newStepLog(ctx context.Context, client pb.LogsClient, step, name string) *stepLog {
+	s := &stepLog{ctx: ctx, client: client, step: step}
+	s.BufferedLog = logs.NewBufferedWriter(s, name, logs.DefaultBufferSize)
+	return s
+}
+
+func (s *stepLog) Send(l *pb.Log) error {
+	if s.stream == nil {
+		stream, err := s.client.UpdateLog(s.ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create UpdateLog client: %v", err)
+		}
+		s.stream = stream
+	}
+	return s.stream.Send(l)
+}
+
+// Close sends the rest of the log, and waits for its upload to complete.
+func (s *stepLog) Close() error {
+	if _, err := s.Flush(); err != nil {
+		return err
+	}
+	if s.stream == nil {
+		return nil
+	}
+	_, err := s.stream.CloseAndRecv()
+	return err
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	tknlog "github.com/tektoncd/cli/pkg/log"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	rtesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"github.com/tektoncd/results/pkg/internal/test"
+	"github.com/tektoncd/results/pkg/watcher/convert"
+	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// stepsTaskRun returns a running TaskRun with a build and a test step, and a
+// sidecar.
+func stepsTaskRun() *v1beta1.TaskRun {
+	tr := taskrun.DeepCopy()
+	tr.Name = "steps-taskrun"
+	tr.UID = "steps-uid"
+	tr.Status = v1beta1.TaskRunStatus{}
+	tr.Status.InitializeConditions()
+	tr.Status.MarkResourceOngoing(v1beta1.TaskRunReasonRunning, "running")
+	tr.Status.Steps = []v1beta1.StepState{
+		{Name: "build", ContainerName: "step-build"},
+		{Name: "test", ContainerName: "step-test"},
+	}
+	tr.Status.Sidecars = []v1beta1.SidecarState{{Name: "db", ContainerName: "sidecar-db"}}
+	return tr
+}
+
+func TestReconcile_StepLogs(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+
+	tr := stepsTaskRun()
+	steps, sidecars := tr.Status.Steps, tr.Status.Sidecars
+	tr.Status.Steps, tr.Status.Sidecars = nil, nil
+	trclient := &TaskRunClient{TaskRunInterface: pipelineclient.Get(ctx).TektonV1beta1().TaskRuns(tr.GetNamespace())}
+	if _, err := trclient.Create(ctx, tr, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewDynamicReconciler(resultsClient, logsClient, trclient, &reconciler.Config{DisableAnnotationUpdate: true, LogsPerStep: true})
+	listStepLogs := func() []*pb.Record {
+		t.Helper()
+		resp, err := logsClient.ListLogs(ctx, &pb.ListRecordsRequest{
+			Parent: "ns/results/-",
+			Filter: `data.spec.resource.uid == "steps-uid"`,
+		})
+		if err != nil {
+			t.Fatalf("ListLogs: %v", err)
+		}
+		return resp.GetRecords()
+	}
+
+	// Logs are not streamed before the pod of the TaskRun is created.
+	if err := r.Reconcile(ctx, tr); err != nil {
+		t.Fatal(err)
+	}
+	if got := listStepLogs(); len(got) != 0 {
+		t.Fatalf("want no log records before the steps start, got %v", got)
+	}
+
+	// A Log is created per step and sidecar, without a combined Log.
+	tr.Status.Steps, tr.Status.Sidecars = steps, sidecars
+	if err := r.Reconcile(ctx, tr); err != nil {
+		t.Fatal(err)
+	}
+	got := listStepLogs()
+	if len(got) != 3 {
+		t.Fatalf("want a log record per step and sidecar, got %v", got)
+	}
+	for _, rec := range got {
+		object := &v1alpha2.Log{}
+		if err := json.Unmarshal(rec.GetData().GetValue(), object); err != nil {
+			t.Fatal(err)
+		}
+		if object.Spec.Step == nil {
+			t.Errorf("want the log of a step, got %+v", object)
+		}
+	}
+}
+
+func TestWriteStepLogs(t *testing.T) {
+	ctx := context.Background()
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
+	r := NewDynamicReconciler(resultsClient, logsClient, nil, &reconciler.Config{LogsPerStep: true})
+
+	tr := stepsTaskRun()
+	recs, _, err := r.resultsClient.PutStepLogs(ctx, tr, convert.LogSteps(tr, ""))
+	if err != nil {
+		t.Fatalf("PutStepLogs: %v", err)
+	}
+	names := map[string]string{}
+	for i, step := range []string{"build", "test", "sidecar-db"} {
+		names[step] = logName(t, recs[i])
+	}
+
+	logC := make(chan tknlog.Log)
+	errC := make(chan error)
+	go func() {
+		defer close(logC)
+		defer close(errC)
+		for _, l := range []tknlog.Log{
+			{Step: "place-scripts", Log: "init"},
+			{Step: "build", Log: "building"},
+			{Step: "build", Log: "built"},
+			{Step: "build", Log: "EOFLOG"},
+			{Step: "test", Log: "EOFLOG"},
+			{Step: "sidecar-db", Log: "ready"},
+		} {
+			logC <- l
+		}
+		errC <- errors.New("step test failed")
+	}()
+	r.writeStepLogs(ctx, names, logC, errC)
+
+	for _, tc := range []struct {
+		step string
+		want string
+	}{
+		{step: "build", want: "building\nbuilt\n"},
+		{step: "db", want: "ready\n"},
+	} {
+		if got := readLog(t, r, names["build"], tc.step); got != tc.want {
+			t.Errorf("log of step %s: want %q, got %q", tc.step, tc.want, got)
+		}
+	}
+	// No log is uploaded for steps without output.
+	_, err = readLogErr(r, names["build"], "test")
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("log of step test: want NotFound, got %v", err)
+	}
+}
+
+func TestUpdateStepLogs(t *testing.T) {
+	ctx := context.Background()
+	fakeclock := clockwork.NewFakeClock()
+	clock = fakeclock
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+	r := NewDynamicReconciler(resultsClient, logsClient, nil, &reconciler.Config{LogsPerStep: true})
+
+	tr := stepsTaskRun()
+	recs, _, err := r.resultsClient.PutStepLogs(ctx, tr, convert.LogSteps(tr, ""))
+	if err != nil {
+		t.Fatalf("PutStepLogs: %v", err)
+	}
+	names := map[string]string{
+		"build": logName(t, recs[0]),
+		"test":  logName(t, recs[1]),
+	}
+
+	// The states are recorded once the TaskRun is done.
+	done := tr.DeepCopy()
+	done.Status.MarkResourceFailed(v1beta1.TaskRunReasonFailed, errors.New("failed"))
+	start, end := metav1.NewTime(time.Unix(1, 0)), metav1.NewTime(time.Unix(2, 0))
+	done.Status.Steps[1].ContainerState = corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", StartedAt: start, FinishedAt: end},
+	}
+	gets := 0
+	get := func(context.Context) (*v1beta1.TaskRun, error) {
+		if gets++; gets == 1 {
+			return tr, nil
+		}
+		return done, nil
+	}
+	errC := make(chan error)
+	go func() {
+		errC <- r.updateStepLogs(ctx, names, get)
+	}()
+	fakeclock.BlockUntil(1)
+	fakeclock.Advance(logFlushInterval)
+	if err := <-errC; err != nil {
+		t.Fatalf("updateStepLogs: %v", err)
+	}
+
+	rec, err := r.resultsClient.GetRecord(ctx, &pb.GetRecordRequest{Name: recs[1].GetName()})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	object := &v1alpha2.Log{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), object); err != nil {
+		t.Fatal(err)
+	}
+	step := object.Spec.Step
+	if step.ExitCode == nil || *step.ExitCode != 1 || step.Reason != "Error" ||
+		!step.StartTime.Equal(&start) || !step.CompletionTime.Equal(&end) {
+		t.Errorf("want the state of the terminated container of the step, got %+v", step)
+	}
+	if step.Record == "" {
+		t.Error("want the Record of the TaskRun kept")
+	}
+}
+
+func logName(t *testing.T, rec *pb.Record) string {
+	t.Helper()
+	parent, resName, recName, err := record.ParseName(rec.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log.FormatName(result.FormatName(parent, resName), recName)
+}
+
+func readLog(t *testing.T, r *Reconciler, name, step string) string {
+	t.Helper()
+	data, err := readLogErr(r, name, step)
+	if err != nil {
+		t.Fatalf("GetLog: %v", err)
+	}
+	return data
+}
+
+func readLogErr(r *Reconciler, name, step string) (string, error) {
+	stream, err := r.resultsClient.GetLog(context.Background(), &pb.GetLogRequest{Name: name, Step: step})
+	if err != nil {
+		return "", err
+	}
+	var data bytes.Buffer
+	for {
+		l, err := stream.Recv()
+		if err == io.EOF {
+			return data.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		data.Write(l.GetData())
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+// uploads are the logs being uploaded by the watcher. Reconcilers are created
+// per reconciliation, so the uploads are kept across them.
+var uploads = &uploadSet{names: map[string]bool{}}
+
+// uploadSet is a set of the names of the logs being uploaded.
+type uploadSet struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+// start adds the logs to the set. It returns false, and adds none of them, if
+// any of the logs is already being uploaded.
+func (s *uploadSet) start(names ...string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, name := range names {
+		if s.names[name] {
+			return false
+		}
+	}
+	for _, name := range names {
+		s.names[name] = true
+	}
+	return true
+}
+
+// done removes the logs from the set, once their upload ends.
+func (s *uploadSet) done(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, name := range names {
+		delete(s.names, name)
+	}
+}
+
+// decodeLog returns the Log of a Log Record.
+func decodeLog(rec *pb.Record) (*v1alpha2.Log, error) {
+	object := &v1alpha2.Log{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), object); err != nil {
+		return nil, fmt.Errorf("could not decode Log record %s: %w", rec.GetName(), err)
+	}
+	return object, nil
+}
+
+// interrupted reports whether the upload of the log started, but did not
+// complete. Unless the watcher is uploading the log, its upload was
+// interrupted, e.g. by a restart of the watcher, and the log is uploaded
+// again.
+func interrupted(object *v1alpha2.Log) bool {
+	switch object.Status.Upload {
+	case v1alpha2.LogUploadInProgress, v1alpha2.LogUploadIncomplete:
+		return true
+	}
+	return false
+}
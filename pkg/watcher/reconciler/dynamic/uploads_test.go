@@ -0,0 +1,71 @@
+// Copyright 2023 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
+	"github.com/tektoncd/results/pkg/internal/jsonutil"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+func TestUploadSet(t *testing.T) {
+	s := &uploadSet{names: map[string]bool{}}
+	if !s.start("a", "b") {
+		t.Fatal("want the upload of a and b started")
+	}
+	// None of the logs is started if one is being uploaded.
+	if s.start("b", "c") {
+		t.Error("want b being uploaded")
+	}
+	if s.names["c"] {
+		t.Error("want c not started")
+	}
+	s.done("a", "b")
+	if !s.start("b", "c") {
+		t.Error("want the upload of b and c started once b is done")
+	}
+}
+
+func TestInterrupted(t *testing.T) {
+	for _, tc := range []struct {
+		status v1alpha2.LogStatus
+		want   bool
+	}{
+		{status: v1alpha2.LogStatus{}, want: false},
+		{status: v1alpha2.LogStatus{Upload: v1alpha2.LogUploadComplete, Size: 10}, want: false},
+		{status: v1alpha2.LogStatus{Upload: v1alpha2.LogUploadIncomplete, Size: 10}, want: true},
+		// Logs uploaded by the watcher are not looked up, so logs in
+		// progress were uploaded before the watcher restarted.
+		{status: v1alpha2.LogStatus{Upload: v1alpha2.LogUploadInProgress, InProgress: true}, want: true},
+	} {
+		t.Run(string(tc.status.Upload), func(t *testing.T) {
+			object, err := decodeLog(&pb.Record{
+				Name: "ns/results/uid/records/log",
+				Data: &pb.Any{
+					Type:  v1alpha2.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha2.Log{Status: tc.status}),
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := interrupted(object); got != tc.want {
+				t.Errorf("want interrupted %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
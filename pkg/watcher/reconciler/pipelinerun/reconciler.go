@@ -17,6 +17,7 @@ package pipelinerun
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
@@ -84,6 +85,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	// that the TaskRuns will not be deleted before their final state being
 	// properly archived into the API server.
 	dyn.IsReadyForDeletionFunc = r.areAllUnderlyingTaskRunsReadyForDeletion
+	// Upsert the Records of the TaskRuns along with the PipelineRun's, in
+	// a single request.
+	dyn.ChildrenFunc = r.listUnderlyingTaskRuns
 
 	if err := dyn.Reconcile(logging.WithLogger(ctx, logger), pr); err != nil {
 		return err
@@ -100,50 +104,77 @@ func (r *Reconciler) areAllUnderlyingTaskRunsReadyForDeletion(ctx context.Contex
 
 	logger := logging.FromContext(ctx)
 
+	taskRuns, err := r.underlyingTaskRuns(ctx, pipelineRun)
+	if err != nil {
+		return false, err
+	}
+	for _, taskRun := range taskRuns {
+		if !isMarkedAsReadyForDeletion(taskRun) {
+			logger.Debugf("TaskRun %s/%s isn't yet ready to be deleted - the annotation %s is missing", taskRun.Namespace, taskRun.Name, resultsannotation.ChildReadyForDeletion)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// listUnderlyingTaskRuns returns the TaskRuns of the PipelineRun, so that
+// their Records are upserted along with the PipelineRun's.
+func (r *Reconciler) listUnderlyingTaskRuns(ctx context.Context, object results.Object) ([]results.Object, error) {
+	pipelineRun, ok := object.(*pipelinev1beta1.PipelineRun)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object (must not happen): want %T, but got %T", &pipelinev1beta1.PipelineRun{}, object)
+	}
+
+	taskRuns, err := r.underlyingTaskRuns(ctx, pipelineRun)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]results.Object, 0, len(taskRuns))
+	for _, taskRun := range taskRuns {
+		children = append(children, taskRun)
+	}
+	return children, nil
+}
+
+// underlyingTaskRuns returns the TaskRuns of the PipelineRun that are still
+// available in the indexer.
+func (r *Reconciler) underlyingTaskRuns(ctx context.Context, pipelineRun *pipelinev1beta1.PipelineRun) ([]*pipelinev1beta1.TaskRun, error) {
+	logger := logging.FromContext(ctx)
+
 	// Support both minimal and full embedded status (see the TODO comment
 	// below).
+	var names []string
 	if len(pipelineRun.Status.ChildReferences) > 0 {
 		for _, reference := range pipelineRun.Status.ChildReferences {
-			taskRun, err := r.taskRunLister.TaskRuns(pipelineRun.Namespace).Get(reference.Name)
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					// Let's assume that the TaskRun in
-					// question is gone and therefore, we
-					// can safely ignore it.
-					logger.Debugf("TaskRun %s/%s is no longer available - ignoring", pipelineRun.Namespace, reference.Name)
-					continue
-				}
-				return false, fmt.Errorf("error reading TaskRun from the indexer: %w", err)
-			}
-			if !isMarkedAsReadyForDeletion(taskRun) {
-				logger.Debugf("TaskRun %s/%s isn't yet ready to be deleted - the annotation %s is missing", taskRun.Namespace, taskRun.Name, resultsannotation.ChildReadyForDeletion)
-				return false, nil
-			}
+			names = append(names, reference.Name)
 		}
 	} else {
 		// TODO(alan-ghelardi): remove this else once we support only
 		// Tekton v1 API since the full embedded status will no longer
 		// be supported.
 		for taskRunName := range pipelineRun.Status.TaskRuns {
-			taskRun, err := r.taskRunLister.TaskRuns(pipelineRun.Namespace).Get(taskRunName)
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					// Let's assume that the TaskRun in
-					// question is gone and therefore, we
-					// can safely ignore it.
-					logger.Debugf("TaskRun %s/%s is no longer available - ignoring", pipelineRun.Namespace, taskRunName)
-					continue
-				}
-				return false, fmt.Errorf("error reading TaskRun from the indexer: %w", err)
-			}
-			if !isMarkedAsReadyForDeletion(taskRun) {
-				logger.Debugf("TaskRun %s/%s isn't yet ready to be deleted - the annotation %s is missing", taskRun.Namespace, taskRun.Name, resultsannotation.ChildReadyForDeletion)
-				return false, nil
-			}
+			names = append(names, taskRunName)
 		}
+		sort.Strings(names)
 	}
 
-	return true, nil
+	var taskRuns []*pipelinev1beta1.TaskRun
+	for _, name := range names {
+		taskRun, err := r.taskRunLister.TaskRuns(pipelineRun.Namespace).Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// Let's assume that the TaskRun in
+				// question is gone and therefore, we
+				// can safely ignore it.
+				logger.Debugf("TaskRun %s/%s is no longer available - ignoring", pipelineRun.Namespace, name)
+				continue
+			}
+			return nil, fmt.Errorf("error reading TaskRun from the indexer: %w", err)
+		}
+		taskRuns = append(taskRuns, taskRun)
+	}
+	return taskRuns, nil
 }
 
 func isMarkedAsReadyForDeletion(taskRun *pipelinev1beta1.TaskRun) bool {
@@ -2,9 +2,13 @@ package results
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+
 	"github.com/google/uuid"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	"github.com/tektoncd/results/pkg/watcher/convert"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
@@ -84,3 +88,79 @@ func (c *Client) GetLogRecord(ctx context.Context, o Object) (*pb.Record, error)
 	}
 	return rec, err
 }
+
+// PutStepLogs creates the Log records of the given steps and sidecars of the
+// TaskRun, in the same order. It reports whether the records were created, or
+// already existed.
+func (c *Client) PutStepLogs(ctx context.Context, o Object, steps []*v1alpha2.LogStep, opts ...grpc.CallOption) ([]*pb.Record, bool, error) {
+	res, err := c.ensureResult(ctx, o, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	runRecord := recordName(res.GetName(), o)
+	recs := make([]*pb.Record, 0, len(steps))
+	created := false
+	for _, step := range steps {
+		name, err := getStepLogRecordName(res, o, step)
+		if err != nil {
+			return nil, false, err
+		}
+		rec, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: name}, opts...)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, false, err
+		}
+		if rec == nil {
+			step.Record = runRecord
+			data, err := convert.ToStepLogProto(o, name, step)
+			if err != nil {
+				return nil, false, err
+			}
+			rec, err = c.CreateRecord(ctx, &pb.CreateRecordRequest{
+				Parent: res.GetName(),
+				Record: &pb.Record{
+					Name: name,
+					Data: data,
+				},
+			}, opts...)
+			if err != nil {
+				return nil, false, err
+			}
+			created = true
+		}
+		recs = append(recs, rec)
+	}
+	return recs, created, nil
+}
+
+// UpdateStepLog updates the step of the Log record of the given name, e.g.
+// with the exit code of its container once it terminated.
+func (c *Client) UpdateStepLog(ctx context.Context, name string, step *v1alpha2.LogStep, opts ...grpc.CallOption) (*pb.Record, error) {
+	rec, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: name}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	log := &v1alpha2.Log{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), log); err != nil {
+		return nil, fmt.Errorf("could not decode Log record %s: %w", name, err)
+	}
+	if log.Spec.Step != nil {
+		step.Record = log.Spec.Step.Record
+	}
+	log.Spec.Step = step
+	data, err := json.Marshal(log)
+	if err != nil {
+		return nil, err
+	}
+	rec.Data.Value = data
+	return c.UpdateRecord(ctx, &pb.UpdateRecordRequest{Record: rec, Etag: rec.GetEtag()}, opts...)
+}
+
+// getStepLogRecordName gets the name of the Log record of a step of the
+// object, derived from its UID and the name of the container of the step.
+func getStepLogRecordName(result *pb.Result, o Object, step *v1alpha2.LogStep) (string, error) {
+	uid, err := uuid.Parse(result.GetUid())
+	if err != nil {
+		return "", err
+	}
+	return record.FormatName(result.GetName(), uuid.NewMD5(uid, []byte(string(o.GetUID())+"/"+step.Container)).String()), nil
+}
@@ -2,8 +2,11 @@ package results
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/results/pkg/apis/v1alpha2"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
@@ -66,3 +69,68 @@ func TestClient_PutLog(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_PutStepLogs(t *testing.T) {
+	ctx := context.Background()
+	c := client(t)
+
+	tr := &v1beta1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "TaskRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun",
+			Namespace: "test",
+			UID:       "taskrun-id",
+		},
+	}
+	steps := func() []*v1alpha2.LogStep {
+		return []*v1alpha2.LogStep{
+			{Name: "build", Container: "step-build"},
+			{Name: "db", Container: "sidecar-db", Sidecar: true},
+		}
+	}
+	recs, created, err := c.PutStepLogs(ctx, tr, steps())
+	if err != nil {
+		t.Fatalf("PutStepLogs: %v", err)
+	}
+	if !created || len(recs) != 2 || recs[0].GetName() == recs[1].GetName() {
+		t.Fatalf("PutStepLogs: want 2 Log records created, got %v, %t", recs, created)
+	}
+	again, created, err := c.PutStepLogs(ctx, tr, steps())
+	if err != nil {
+		t.Fatalf("PutStepLogs: %v", err)
+	}
+	if created || again[0].GetName() != recs[0].GetName() || again[1].GetName() != recs[1].GetName() {
+		t.Errorf("PutStepLogs: want the existing Log records, got %v, %t", again, created)
+	}
+
+	exitCode := int32(1)
+	rec, err := c.UpdateStepLog(ctx, recs[0].GetName(), &v1alpha2.LogStep{
+		Name:      "build",
+		Container: "step-build",
+		ExitCode:  &exitCode,
+		Reason:    "Error",
+	})
+	if err != nil {
+		t.Fatalf("UpdateStepLog: %v", err)
+	}
+	log := &v1alpha2.Log{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), log); err != nil {
+		t.Fatal(err)
+	}
+	want := &v1alpha2.LogStep{
+		Name:      "build",
+		Container: "step-build",
+		Record:    "test/results/taskrun-id/records/taskrun-id",
+		ExitCode:  &exitCode,
+		Reason:    "Error",
+	}
+	if diff := cmp.Diff(want, log.Spec.Step); diff != "" {
+		t.Errorf("step (-want, +got): %s", diff)
+	}
+	if log.Spec.Resource.UID != tr.GetUID() || log.GetName() != "taskrun-build-log" {
+		t.Errorf("want the Log of the build step of the TaskRun, got %+v", log)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 
@@ -46,6 +47,10 @@ import (
 type Client struct {
 	pb.ResultsClient
 	pb.LogsClient
+
+	// batchUnimplemented is set once the API server reported that it does not
+	// implement the batch RPCs, to fall back to the single Record RPCs.
+	batchUnimplemented atomic.Bool
 }
 
 // NewClient returns a new results client for the particular kind.
@@ -70,25 +75,54 @@ type StatusConditionGetter interface {
 	GetStatusCondition() apis.ConditionAccessor
 }
 
+// maxBatchSize is the maximum number of Records upserted by a single
+// BatchUpsertRecords call, as allowed by the API server.
+const maxBatchSize = 1000
+
 // Put adds the given Object to the Results API.
 // If the parent result is missing or the object is not yet associated with a
 // result, one is created automatically.
 // If the Object is already associated with a Record, the existing Record is
 // updated - otherwise a new Record is created.
 func (c *Client) Put(ctx context.Context, o Object, opts ...grpc.CallOption) (*pb.Result, *pb.Record, error) {
+	return c.PutAll(ctx, o, nil, opts...)
+}
+
+// PutAll adds the given Object to the Results API as Put does, along with its
+// children, e.g. the TaskRuns of a PipelineRun. The Records of the Object and
+// of its children are upserted together, with a single BatchUpsertRecords
+// call. Children usually belong to the Result of the Object; the Results of
+// the others are ensured first. It returns the Result and the Record of the
+// Object. Errors upserting the Records of children are only logged, since the
+// children are reconciled on their own too.
+func (c *Client) PutAll(ctx context.Context, o Object, children []Object, opts ...grpc.CallOption) (*pb.Result, *pb.Record, error) {
 	// Make sure parent Result exists (or create one)
 	res, err := c.ensureResult(ctx, o, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Create or update the rec.
-	rec, err := c.upsertRecord(ctx, res.GetName(), o, opts...)
+	objects := append([]Object{o}, children...)
+	parents := []string{res.GetName()}
+	for _, child := range children {
+		parent := resultName(child)
+		if parent != res.GetName() {
+			childRes, err := c.ensureResult(ctx, child, opts...)
+			if err != nil {
+				return nil, nil, err
+			}
+			parent = childRes.GetName()
+		}
+		parents = append(parents, parent)
+	}
+
+	// Create or update the records.
+	recs, err := c.upsertRecords(ctx, parents, objects, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return res, rec, nil
+	return res, recs[0], nil
 }
 
 // ensureResult gets the Result corresponding to the Object, creates a new
@@ -274,26 +308,77 @@ func parentName(o metav1.Object) string {
 // upsertRecord updates or creates a record for the object. If there has been
 // no change in the Record data, the existing Record is returned.
 func (c *Client) upsertRecord(ctx context.Context, parent string, o Object, opts ...grpc.CallOption) (*pb.Record, error) {
-	recName := recordName(parent, o)
-	logger := logging.FromContext(ctx).With(zap.String(annotation.Record, recName))
-	data, err := convert.ToProto(o)
+	recs, err := c.upsertRecords(ctx, []string{parent}, []Object{o}, opts...)
 	if err != nil {
 		return nil, err
 	}
+	return recs[0], nil
+}
+
+// upsertRecords updates or creates the records of the objects, in the
+// Results of the given parents. The error of the first object is returned,
+// and the errors of the others are logged, leaving their Records nil.
+func (c *Client) upsertRecords(ctx context.Context, parents []string, objects []Object, opts ...grpc.CallOption) ([]*pb.Record, error) {
+	logger := logging.FromContext(ctx)
+	recs := make([]*pb.Record, len(objects))
+	for i, o := range objects {
+		data, err := convert.ToProto(o)
+		if err != nil {
+			return nil, err
+		}
+		recs[i] = &pb.Record{Name: recordName(parents[i], o), Data: data}
+	}
+
+	var (
+		out  []*pb.Record
+		errs []error
+	)
+	if !c.batchUnimplemented.Load() {
+		var err error
+		out, errs, err = c.batchUpsertRecords(ctx, recs, opts...)
+		if status.Code(err) == codes.Unimplemented {
+			logger.Debug("BatchUpsertRecords is not implemented by the API server - falling back to single Record requests")
+			c.batchUnimplemented.Store(true)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if c.batchUnimplemented.Load() {
+		out = make([]*pb.Record, len(recs))
+		errs = make([]error, len(recs))
+		for i, rec := range recs {
+			out[i], errs[i] = c.putRecord(ctx, parents[i], rec, opts...)
+		}
+	}
 
-	curr, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: recName}, opts...)
+	for i, err := range errs[1:] {
+		if err != nil {
+			logger.Warnw("Error upserting the record of a child object", zap.String(annotation.Record, recs[i+1].GetName()), zap.Error(err))
+		}
+	}
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	return out, nil
+}
+
+// putRecord gets the Record and then creates or updates it, for API servers
+// that do not implement BatchUpsertRecords.
+func (c *Client) putRecord(ctx context.Context, parent string, rec *pb.Record, opts ...grpc.CallOption) (*pb.Record, error) {
+	logger := logging.FromContext(ctx).With(zap.String(annotation.Record, rec.GetName()))
+	curr, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()}, opts...)
 	if err != nil && status.Code(err) != codes.NotFound {
 		return nil, err
 	}
 	if curr != nil {
 		// Data already exists for the Record - update it iff there is a diff of Data.
-		if cmp.Equal(data, curr.GetData(), protocmp.Transform()) {
+		if cmp.Equal(rec.GetData(), curr.GetData(), protocmp.Transform()) {
 			logger.Debug("No further actions to be done on the Record: no changes found")
 			return curr, nil
 		}
 
 		logger.Debug("Updating Record")
-		curr.Data = data
+		curr.Data = rec.GetData()
 		return c.UpdateRecord(ctx, &pb.UpdateRecordRequest{
 			Record: curr,
 			Etag:   curr.GetEtag(),
@@ -303,13 +388,67 @@ func (c *Client) upsertRecord(ctx context.Context, parent string, o Object, opts
 	logger.Debug("Record doesn't exist yet - creating")
 	return c.CreateRecord(ctx, &pb.CreateRecordRequest{
 		Parent: parent,
-		Record: &pb.Record{
-			Name: recName,
-			Data: data,
-		},
+		Record: rec,
 	}, opts...)
 }
 
+// batchUpsertRecords upserts the Records with BatchUpsertRecords calls of at
+// most maxBatchSize Records, instead of getting each of them and then
+// creating or updating it. It returns the Records and the errors of each
+// request, or the error of the calls.
+func (c *Client) batchUpsertRecords(ctx context.Context, recs []*pb.Record, opts ...grpc.CallOption) ([]*pb.Record, []error, error) {
+	out := make([]*pb.Record, 0, len(recs))
+	errs := make([]error, 0, len(recs))
+	for start := 0; start < len(recs); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(recs) {
+			end = len(recs)
+		}
+		chunk := recs[start:end]
+		parent, err := batchParent(chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+		req := &pb.BatchUpsertRecordsRequest{Parent: parent}
+		for _, rec := range chunk {
+			req.Requests = append(req.Requests, &pb.UpsertRecordRequest{Record: rec})
+		}
+		resp, err := c.BatchUpsertRecords(ctx, req, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(resp.GetResponses()) != len(chunk) {
+			return nil, nil, fmt.Errorf("BatchUpsertRecords(%s): got %d responses for %d requests", parent, len(resp.GetResponses()), len(chunk))
+		}
+		for _, r := range resp.GetResponses() {
+			out = append(out, r.GetRecord())
+			errs = append(errs, status.ErrorProto(r.GetStatus()))
+		}
+	}
+	return out, errs, nil
+}
+
+// batchParent returns the parent of a batch of Records: their Result, or all
+// the Results of their namespace (`-`) if they belong to several Results.
+func batchParent(recs []*pb.Record) (string, error) {
+	var parent, name string
+	for _, rec := range recs {
+		recParent, recResult, _, err := record.ParseName(rec.GetName())
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case parent == "":
+			parent, name = recParent, recResult
+		case recParent != parent:
+			return "", fmt.Errorf("records %s and %s are not in the same parent", recs[0].GetName(), rec.GetName())
+		case recResult != name:
+			name = "-"
+		}
+	}
+	return result.FormatName(parent, name), nil
+}
+
 // defaultName is the default Result/Record name that should be used if one is
 // not already associated to the Object.
 func defaultName(o metav1.Object) string {
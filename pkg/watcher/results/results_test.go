@@ -29,6 +29,7 @@ import (
 	"github.com/tektoncd/results/pkg/watcher/convert"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -602,6 +603,130 @@ func TestPut(t *testing.T) {
 	}
 }
 
+// unimplementedBatchClient is a ResultsClient of an API server that does not
+// implement the batch RPCs.
+type unimplementedBatchClient struct {
+	pb.ResultsClient
+}
+
+func (unimplementedBatchClient) BatchUpsertRecords(context.Context, *pb.BatchUpsertRecordsRequest, ...grpc.CallOption) (*pb.BatchUpsertRecordsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unimplemented")
+}
+
+func TestPut_BatchUnimplemented(t *testing.T) {
+	ctx := context.Background()
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+	client := NewClient(unimplementedBatchClient{resultsClient}, logsClient)
+
+	o := &v1beta1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "TaskRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun",
+			Namespace: "test",
+			UID:       "taskrun-id",
+		},
+	}
+	for _, tc := range []string{"create", "update"} {
+		t.Run(tc, func(t *testing.T) {
+			if _, _, err := client.Put(ctx, o); err != nil {
+				t.Fatal(err)
+			}
+			if !client.batchUnimplemented.Load() {
+				t.Error("batch RPCs should be marked as unimplemented")
+			}
+		})
+	}
+	if _, err := client.GetRecord(ctx, &pb.GetRecordRequest{Name: "test/results/taskrun-id/records/taskrun-id"}); err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+}
+
+// countingClient is a ResultsClient counting the Record RPCs.
+type countingClient struct {
+	pb.ResultsClient
+	batches, gets, creates, updates int
+}
+
+func (c *countingClient) BatchUpsertRecords(ctx context.Context, in *pb.BatchUpsertRecordsRequest, opts ...grpc.CallOption) (*pb.BatchUpsertRecordsResponse, error) {
+	c.batches++
+	return c.ResultsClient.BatchUpsertRecords(ctx, in, opts...)
+}
+
+func (c *countingClient) GetRecord(ctx context.Context, in *pb.GetRecordRequest, opts ...grpc.CallOption) (*pb.Record, error) {
+	c.gets++
+	return c.ResultsClient.GetRecord(ctx, in, opts...)
+}
+
+func (c *countingClient) CreateRecord(ctx context.Context, in *pb.CreateRecordRequest, opts ...grpc.CallOption) (*pb.Record, error) {
+	c.creates++
+	return c.ResultsClient.CreateRecord(ctx, in, opts...)
+}
+
+func (c *countingClient) UpdateRecord(ctx context.Context, in *pb.UpdateRecordRequest, opts ...grpc.CallOption) (*pb.Record, error) {
+	c.updates++
+	return c.ResultsClient.UpdateRecord(ctx, in, opts...)
+}
+
+func TestPutAll(t *testing.T) {
+	ctx := context.Background()
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+	counter := &countingClient{ResultsClient: resultsClient}
+	client := NewClient(counter, logsClient)
+
+	pipelineRun := &v1beta1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "PipelineRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipelinerun",
+			Namespace: "test",
+			UID:       "pipelinerun-id",
+		},
+	}
+	var children []Object
+	for _, name := range []string{"a", "b"} {
+		children = append(children, &v1beta1.TaskRun{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tekton.dev/v1beta1",
+				Kind:       "TaskRun",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "taskrun-" + name,
+				Namespace: "test",
+				UID:       types.UID("taskrun-" + name + "-id"),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "tekton.dev/v1beta1",
+					Kind:       "PipelineRun",
+					Name:       "pipelinerun",
+					UID:        "pipelinerun-id",
+				}},
+			},
+		})
+	}
+
+	// Every reconcile, creating or updating the Records, sends them in a
+	// single request.
+	for i, tc := range []string{"create", "update"} {
+		t.Run(tc, func(t *testing.T) {
+			if _, _, err := client.PutAll(ctx, pipelineRun, children); err != nil {
+				t.Fatal(err)
+			}
+			if counter.batches != i+1 || counter.gets != 0 || counter.creates != 0 || counter.updates != 0 {
+				t.Errorf("want a single BatchUpsertRecords request, got %+v", counter)
+			}
+		})
+	}
+	for _, name := range []string{"pipelinerun-id", "taskrun-a-id", "taskrun-b-id"} {
+		if _, err := client.GetRecord(ctx, &pb.GetRecordRequest{Name: "test/results/pipelinerun-id/records/" + name}); err != nil {
+			t.Errorf("GetRecord(%s): %v", name, err)
+		}
+	}
+}
+
 func crdToRecord(t *testing.T, name string, o Object) *pb.Record {
 	t.Helper()
 
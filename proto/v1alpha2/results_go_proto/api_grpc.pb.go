@@ -27,12 +27,36 @@ type ResultsClient interface {
 	UpdateResult(ctx context.Context, in *UpdateResultRequest, opts ...grpc.CallOption) (*Result, error)
 	GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*Result, error)
 	DeleteResult(ctx context.Context, in *DeleteResultRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteResult restores a deleted Result, along with the Records that were
+	// deleted with it.
+	UndeleteResult(ctx context.Context, in *UndeleteResultRequest, opts ...grpc.CallOption) (*Result, error)
 	ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (*ListResultsResponse, error)
+	// WatchResults streams changes to the Results matching the request.
+	WatchResults(ctx context.Context, in *WatchResultsRequest, opts ...grpc.CallOption) (Results_WatchResultsClient, error)
+	// SummarizeResults aggregates the Results matching the request, optionally
+	// grouped by fields such as the type or status of their RecordSummary.
+	SummarizeResults(ctx context.Context, in *SummarizeResultsRequest, opts ...grpc.CallOption) (*SummarizeResultsResponse, error)
 	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*Record, error)
 	UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*Record, error)
 	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*Record, error)
 	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
+	// WatchRecords streams changes to the Records matching the request.
+	WatchRecords(ctx context.Context, in *WatchRecordsRequest, opts ...grpc.CallOption) (Results_WatchRecordsClient, error)
 	DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// BatchCreateRecords creates Records in a single transaction. Each Record is
+	// created independently, and the response reports the outcome of each
+	// request, in order.
+	BatchCreateRecords(ctx context.Context, in *BatchCreateRecordsRequest, opts ...grpc.CallOption) (*BatchCreateRecordsResponse, error)
+	// BatchUpsertRecords creates Records, or updates them if they already
+	// exist, in a single transaction. Each Record is written independently, and
+	// the response reports the outcome of each request, in order.
+	BatchUpsertRecords(ctx context.Context, in *BatchUpsertRecordsRequest, opts ...grpc.CallOption) (*BatchUpsertRecordsResponse, error)
+	// ListRecordRevisions lists the previous revisions of a Record, newest
+	// first. Revisions are only kept if enabled on the server.
+	ListRecordRevisions(ctx context.Context, in *ListRecordRevisionsRequest, opts ...grpc.CallOption) (*ListRecordRevisionsResponse, error)
+	// UndeleteRecord restores a deleted Record. The Result of the Record must
+	// not be deleted.
+	UndeleteRecord(ctx context.Context, in *UndeleteRecordRequest, opts ...grpc.CallOption) (*Record, error)
 }
 
 type resultsClient struct {
@@ -79,6 +103,15 @@ func (c *resultsClient) DeleteResult(ctx context.Context, in *DeleteResultReques
 	return out, nil
 }
 
+func (c *resultsClient) UndeleteResult(ctx context.Context, in *UndeleteResultRequest, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/UndeleteResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resultsClient) ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (*ListResultsResponse, error) {
 	out := new(ListResultsResponse)
 	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/ListResults", in, out, opts...)
@@ -88,6 +121,47 @@ func (c *resultsClient) ListResults(ctx context.Context, in *ListResultsRequest,
 	return out, nil
 }
 
+func (c *resultsClient) WatchResults(ctx context.Context, in *WatchResultsRequest, opts ...grpc.CallOption) (Results_WatchResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Results_ServiceDesc.Streams[0], "/tekton.results.v1alpha2.Results/WatchResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resultsWatchResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Results_WatchResultsClient interface {
+	Recv() (*WatchResultsResponse, error)
+	grpc.ClientStream
+}
+
+type resultsWatchResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *resultsWatchResultsClient) Recv() (*WatchResultsResponse, error) {
+	m := new(WatchResultsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resultsClient) SummarizeResults(ctx context.Context, in *SummarizeResultsRequest, opts ...grpc.CallOption) (*SummarizeResultsResponse, error) {
+	out := new(SummarizeResultsResponse)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/SummarizeResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resultsClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*Record, error) {
 	out := new(Record)
 	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/CreateRecord", in, out, opts...)
@@ -124,6 +198,38 @@ func (c *resultsClient) ListRecords(ctx context.Context, in *ListRecordsRequest,
 	return out, nil
 }
 
+func (c *resultsClient) WatchRecords(ctx context.Context, in *WatchRecordsRequest, opts ...grpc.CallOption) (Results_WatchRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Results_ServiceDesc.Streams[1], "/tekton.results.v1alpha2.Results/WatchRecords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resultsWatchRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Results_WatchRecordsClient interface {
+	Recv() (*WatchRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type resultsWatchRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *resultsWatchRecordsClient) Recv() (*WatchRecordsResponse, error) {
+	m := new(WatchRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *resultsClient) DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/DeleteRecord", in, out, opts...)
@@ -133,6 +239,42 @@ func (c *resultsClient) DeleteRecord(ctx context.Context, in *DeleteRecordReques
 	return out, nil
 }
 
+func (c *resultsClient) BatchCreateRecords(ctx context.Context, in *BatchCreateRecordsRequest, opts ...grpc.CallOption) (*BatchCreateRecordsResponse, error) {
+	out := new(BatchCreateRecordsResponse)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/BatchCreateRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) BatchUpsertRecords(ctx context.Context, in *BatchUpsertRecordsRequest, opts ...grpc.CallOption) (*BatchUpsertRecordsResponse, error) {
+	out := new(BatchUpsertRecordsResponse)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/BatchUpsertRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) ListRecordRevisions(ctx context.Context, in *ListRecordRevisionsRequest, opts ...grpc.CallOption) (*ListRecordRevisionsResponse, error) {
+	out := new(ListRecordRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/ListRecordRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) UndeleteRecord(ctx context.Context, in *UndeleteRecordRequest, opts ...grpc.CallOption) (*Record, error) {
+	out := new(Record)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Results/UndeleteRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResultsServer is the server API for Results service.
 // All implementations must embed UnimplementedResultsServer
 // for forward compatibility
@@ -141,12 +283,36 @@ type ResultsServer interface {
 	UpdateResult(context.Context, *UpdateResultRequest) (*Result, error)
 	GetResult(context.Context, *GetResultRequest) (*Result, error)
 	DeleteResult(context.Context, *DeleteResultRequest) (*emptypb.Empty, error)
+	// UndeleteResult restores a deleted Result, along with the Records that were
+	// deleted with it.
+	UndeleteResult(context.Context, *UndeleteResultRequest) (*Result, error)
 	ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error)
+	// WatchResults streams changes to the Results matching the request.
+	WatchResults(*WatchResultsRequest, Results_WatchResultsServer) error
+	// SummarizeResults aggregates the Results matching the request, optionally
+	// grouped by fields such as the type or status of their RecordSummary.
+	SummarizeResults(context.Context, *SummarizeResultsRequest) (*SummarizeResultsResponse, error)
 	CreateRecord(context.Context, *CreateRecordRequest) (*Record, error)
 	UpdateRecord(context.Context, *UpdateRecordRequest) (*Record, error)
 	GetRecord(context.Context, *GetRecordRequest) (*Record, error)
 	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
+	// WatchRecords streams changes to the Records matching the request.
+	WatchRecords(*WatchRecordsRequest, Results_WatchRecordsServer) error
 	DeleteRecord(context.Context, *DeleteRecordRequest) (*emptypb.Empty, error)
+	// BatchCreateRecords creates Records in a single transaction. Each Record is
+	// created independently, and the response reports the outcome of each
+	// request, in order.
+	BatchCreateRecords(context.Context, *BatchCreateRecordsRequest) (*BatchCreateRecordsResponse, error)
+	// BatchUpsertRecords creates Records, or updates them if they already
+	// exist, in a single transaction. Each Record is written independently, and
+	// the response reports the outcome of each request, in order.
+	BatchUpsertRecords(context.Context, *BatchUpsertRecordsRequest) (*BatchUpsertRecordsResponse, error)
+	// ListRecordRevisions lists the previous revisions of a Record, newest
+	// first. Revisions are only kept if enabled on the server.
+	ListRecordRevisions(context.Context, *ListRecordRevisionsRequest) (*ListRecordRevisionsResponse, error)
+	// UndeleteRecord restores a deleted Record. The Result of the Record must
+	// not be deleted.
+	UndeleteRecord(context.Context, *UndeleteRecordRequest) (*Record, error)
 	mustEmbedUnimplementedResultsServer()
 }
 
@@ -166,9 +332,18 @@ func (UnimplementedResultsServer) GetResult(context.Context, *GetResultRequest)
 func (UnimplementedResultsServer) DeleteResult(context.Context, *DeleteResultRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteResult not implemented")
 }
+func (UnimplementedResultsServer) UndeleteResult(context.Context, *UndeleteResultRequest) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteResult not implemented")
+}
 func (UnimplementedResultsServer) ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListResults not implemented")
 }
+func (UnimplementedResultsServer) WatchResults(*WatchResultsRequest, Results_WatchResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResults not implemented")
+}
+func (UnimplementedResultsServer) SummarizeResults(context.Context, *SummarizeResultsRequest) (*SummarizeResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeResults not implemented")
+}
 func (UnimplementedResultsServer) CreateRecord(context.Context, *CreateRecordRequest) (*Record, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
 }
@@ -181,9 +356,24 @@ func (UnimplementedResultsServer) GetRecord(context.Context, *GetRecordRequest)
 func (UnimplementedResultsServer) ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
 }
+func (UnimplementedResultsServer) WatchRecords(*WatchRecordsRequest, Results_WatchRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRecords not implemented")
+}
 func (UnimplementedResultsServer) DeleteRecord(context.Context, *DeleteRecordRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecord not implemented")
 }
+func (UnimplementedResultsServer) BatchCreateRecords(context.Context, *BatchCreateRecordsRequest) (*BatchCreateRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateRecords not implemented")
+}
+func (UnimplementedResultsServer) BatchUpsertRecords(context.Context, *BatchUpsertRecordsRequest) (*BatchUpsertRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpsertRecords not implemented")
+}
+func (UnimplementedResultsServer) ListRecordRevisions(context.Context, *ListRecordRevisionsRequest) (*ListRecordRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordRevisions not implemented")
+}
+func (UnimplementedResultsServer) UndeleteRecord(context.Context, *UndeleteRecordRequest) (*Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteRecord not implemented")
+}
 func (UnimplementedResultsServer) mustEmbedUnimplementedResultsServer() {}
 
 // UnsafeResultsServer may be embedded to opt out of forward compatibility for this service.
@@ -269,6 +459,24 @@ func _Results_DeleteResult_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Results_UndeleteResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).UndeleteResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Results/UndeleteResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).UndeleteResult(ctx, req.(*UndeleteResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Results_ListResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListResultsRequest)
 	if err := dec(in); err != nil {
@@ -287,6 +495,45 @@ func _Results_ListResults_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Results_WatchResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResultsServer).WatchResults(m, &resultsWatchResultsServer{stream})
+}
+
+type Results_WatchResultsServer interface {
+	Send(*WatchResultsResponse) error
+	grpc.ServerStream
+}
+
+type resultsWatchResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *resultsWatchResultsServer) Send(m *WatchResultsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Results_SummarizeResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).SummarizeResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Results/SummarizeResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).SummarizeResults(ctx, req.(*SummarizeResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Results_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRecordRequest)
 	if err := dec(in); err != nil {
@@ -359,6 +606,27 @@ func _Results_ListRecords_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Results_WatchRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResultsServer).WatchRecords(m, &resultsWatchRecordsServer{stream})
+}
+
+type Results_WatchRecordsServer interface {
+	Send(*WatchRecordsResponse) error
+	grpc.ServerStream
+}
+
+type resultsWatchRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *resultsWatchRecordsServer) Send(m *WatchRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Results_DeleteRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRecordRequest)
 	if err := dec(in); err != nil {
@@ -377,6 +645,78 @@ func _Results_DeleteRecord_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Results_BatchCreateRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).BatchCreateRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Results/BatchCreateRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).BatchCreateRecords(ctx, req.(*BatchCreateRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_BatchUpsertRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpsertRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).BatchUpsertRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Results/BatchUpsertRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).BatchUpsertRecords(ctx, req.(*BatchUpsertRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_ListRecordRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).ListRecordRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Results/ListRecordRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).ListRecordRevisions(ctx, req.(*ListRecordRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_UndeleteRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).UndeleteRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Results/UndeleteRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).UndeleteRecord(ctx, req.(*UndeleteRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Results_ServiceDesc is the grpc.ServiceDesc for Results service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -400,10 +740,18 @@ var Results_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteResult",
 			Handler:    _Results_DeleteResult_Handler,
 		},
+		{
+			MethodName: "UndeleteResult",
+			Handler:    _Results_UndeleteResult_Handler,
+		},
 		{
 			MethodName: "ListResults",
 			Handler:    _Results_ListResults_Handler,
 		},
+		{
+			MethodName: "SummarizeResults",
+			Handler:    _Results_SummarizeResults_Handler,
+		},
 		{
 			MethodName: "CreateRecord",
 			Handler:    _Results_CreateRecord_Handler,
@@ -424,8 +772,35 @@ var Results_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRecord",
 			Handler:    _Results_DeleteRecord_Handler,
 		},
+		{
+			MethodName: "BatchCreateRecords",
+			Handler:    _Results_BatchCreateRecords_Handler,
+		},
+		{
+			MethodName: "BatchUpsertRecords",
+			Handler:    _Results_BatchUpsertRecords_Handler,
+		},
+		{
+			MethodName: "ListRecordRevisions",
+			Handler:    _Results_ListRecordRevisions_Handler,
+		},
+		{
+			MethodName: "UndeleteRecord",
+			Handler:    _Results_UndeleteRecord_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResults",
+			Handler:       _Results_WatchResults_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRecords",
+			Handler:       _Results_WatchRecords_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
 
@@ -434,7 +809,14 @@ var Results_ServiceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LogsClient interface {
 	GetLog(ctx context.Context, in *GetLogRequest, opts ...grpc.CallOption) (Logs_GetLogClient, error)
+	// Returns a short-lived pre-signed URL to download a log stored in S3
+	// directly from S3, rather than streaming it through the API server.
+	GetLogDownloadURL(ctx context.Context, in *GetLogDownloadURLRequest, opts ...grpc.CallOption) (*GetLogDownloadURLResponse, error)
 	ListLogs(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
+	// Uploads a log. The offset of the first message sets where the upload
+	// starts: 0 replaces the stored log, and the size of the stored log resumes
+	// an interrupted upload. The state of the upload is recorded in the status
+	// of the Log.
 	UpdateLog(ctx context.Context, opts ...grpc.CallOption) (Logs_UpdateLogClient, error)
 	DeleteLog(ctx context.Context, in *DeleteLogRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
@@ -479,6 +861,15 @@ func (x *logsGetLogClient) Recv() (*Log, error) {
 	return m, nil
 }
 
+func (c *logsClient) GetLogDownloadURL(ctx context.Context, in *GetLogDownloadURLRequest, opts ...grpc.CallOption) (*GetLogDownloadURLResponse, error) {
+	out := new(GetLogDownloadURLResponse)
+	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Logs/GetLogDownloadURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *logsClient) ListLogs(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error) {
 	out := new(ListRecordsResponse)
 	err := c.cc.Invoke(ctx, "/tekton.results.v1alpha2.Logs/ListLogs", in, out, opts...)
@@ -536,7 +927,14 @@ func (c *logsClient) DeleteLog(ctx context.Context, in *DeleteLogRequest, opts .
 // for forward compatibility
 type LogsServer interface {
 	GetLog(*GetLogRequest, Logs_GetLogServer) error
+	// Returns a short-lived pre-signed URL to download a log stored in S3
+	// directly from S3, rather than streaming it through the API server.
+	GetLogDownloadURL(context.Context, *GetLogDownloadURLRequest) (*GetLogDownloadURLResponse, error)
 	ListLogs(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
+	// Uploads a log. The offset of the first message sets where the upload
+	// starts: 0 replaces the stored log, and the size of the stored log resumes
+	// an interrupted upload. The state of the upload is recorded in the status
+	// of the Log.
 	UpdateLog(Logs_UpdateLogServer) error
 	DeleteLog(context.Context, *DeleteLogRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedLogsServer()
@@ -549,6 +947,9 @@ type UnimplementedLogsServer struct {
 func (UnimplementedLogsServer) GetLog(*GetLogRequest, Logs_GetLogServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetLog not implemented")
 }
+func (UnimplementedLogsServer) GetLogDownloadURL(context.Context, *GetLogDownloadURLRequest) (*GetLogDownloadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogDownloadURL not implemented")
+}
 func (UnimplementedLogsServer) ListLogs(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListLogs not implemented")
 }
@@ -592,6 +993,24 @@ func (x *logsGetLogServer) Send(m *Log) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Logs_GetLogDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServer).GetLogDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tekton.results.v1alpha2.Logs/GetLogDownloadURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServer).GetLogDownloadURL(ctx, req.(*GetLogDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Logs_ListLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListRecordsRequest)
 	if err := dec(in); err != nil {
@@ -661,6 +1080,10 @@ var Logs_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "tekton.results.v1alpha2.Logs",
 	HandlerType: (*LogsServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogDownloadURL",
+			Handler:    _Logs_GetLogDownloadURL_Handler,
+		},
 		{
 			MethodName: "ListLogs",
 			Handler:    _Logs_ListLogs_Handler,